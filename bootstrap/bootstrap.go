@@ -12,6 +12,7 @@ import (
 	_ "github.com/MichaelS11/go-cql-driver"
 	"github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	_ "github.com/viant/asc"
 	_ "github.com/viant/bgc"
 
@@ -32,12 +33,15 @@ import (
 	_ "github.com/viant/toolbox/storage/scp"
 
 	_ "github.com/viant/endly/testing/dsunit"
+	_ "github.com/viant/endly/testing/generate"
 	_ "github.com/viant/endly/testing/log"
 	_ "github.com/viant/endly/testing/validator"
 
 	_ "github.com/viant/endly/testing/endpoint/http"
+	_ "github.com/viant/endly/testing/endpoint/oidc"
 	_ "github.com/viant/endly/testing/endpoint/smtp"
 	_ "github.com/viant/endly/testing/msg"
+	_ "github.com/viant/endly/testing/runner/cdp"
 	_ "github.com/viant/endly/testing/runner/http"
 	_ "github.com/viant/endly/testing/runner/rest"
 	_ "github.com/viant/endly/testing/runner/selenium"
@@ -93,9 +97,11 @@ import (
 
 	_ "github.com/viant/endly/system/daemon"
 	_ "github.com/viant/endly/system/docker"
+	_ "github.com/viant/endly/system/docker/compose"
 	_ "github.com/viant/endly/system/docker/ssh"
 	_ "github.com/viant/endly/system/exec"
 	_ "github.com/viant/endly/system/network"
+	_ "github.com/viant/endly/system/network/proxy"
 	_ "github.com/viant/endly/system/process"
 	_ "github.com/viant/endly/system/storage"
 
@@ -116,6 +122,7 @@ import (
 	"github.com/google/gops/agent"
 	rec "github.com/viant/endly/testing/endpoint/http"
 	"gopkg.in/yaml.v2"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
@@ -161,6 +168,7 @@ func init() {
 	flag.Int("e", 5, "max number of failures CLI reported per validation, 0 - all failures reported")
 	flag.String("run", "", "run specified service action it expect valid service:action to run")
 	flag.String("req", "", "optional request URL when run option is specified")
+	flag.String("cleanup", "", "<session id> tear down resources recorded by a previous run, -cleanup='?' lists sessions with pending resources")
 	_ = mysql.SetLogger(&emptyLogger{})
 
 }
@@ -246,6 +254,13 @@ func Bootstrap() {
 		return
 	}
 
+	if sessionID, ok := flagset["cleanup"]; ok {
+		if err := cleanup(sessionID); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if run, ok := flagset["run"]; ok {
 		err := runAction(run, flagset)
 		if err != nil {
@@ -331,6 +346,32 @@ func runAction(run string, flagset map[string]string) error {
 	return nil
 }
 
+func cleanup(sessionID string) error {
+	if sessionID == "?" {
+		files, err := ioutil.ReadDir(endly.LedgerDirectory())
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, file := range files {
+			if path.Ext(file.Name()) != ".json" {
+				continue
+			}
+			ledger := endly.SessionLedger(strings.Replace(file.Name(), ".json", "", 1))
+			fmt.Printf("%v: %v resource(s)\n", ledger.SessionID, len(ledger.Entries))
+		}
+		return nil
+	}
+	manager := endly.New()
+	context := manager.NewContext(nil)
+	defer context.Close()
+	response := &workflow.CleanupResponse{}
+	err := endly.Run(context, &workflow.CleanupRequest{SessionID: sessionID}, response)
+	for _, entry := range response.Removed {
+		fmt.Printf("removed %v %v\n", entry.Kind, entry.Name)
+	}
+	return err
+}
+
 func runWorkflow(request *workflow.RunRequest, interactive bool) {
 	runner := cli.New()
 	request.Interactive = interactive
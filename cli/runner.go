@@ -260,6 +260,20 @@ func (r *Runner) processAssertable(event msg.Event) bool {
 	return true
 }
 
+func (r *Runner) processTableReporter(event msg.Event, filter map[string]bool) bool {
+	reporter, ok := event.Value().(msg.TableReporter)
+	if !ok {
+		return false
+	}
+	if !r.canReport(event, filter) {
+		return true
+	}
+	for _, table := range reporter.Tables() {
+		r.PrintTable(r.ColorText(table.Caption, r.Style.ErrorColor), table.Headers, table.Rows, 110)
+	}
+	return true
+}
+
 func (r *Runner) processActivityStart(event msg.Event) bool {
 	if r.activityEnded {
 		r.Pop()
@@ -315,6 +329,9 @@ func (r *Runner) processEvent(event msg.Event, filter map[string]bool) {
 		return
 	}
 
+	if r.processTableReporter(event, filter) {
+		return
+	}
 	if r.processAssertable(event) {
 		return
 	}
@@ -568,6 +585,9 @@ func (r *Runner) reportTagSummary() {
 		if failureLog != nil {
 			useCase.Sysout = failureLog.JSONOutput
 		}
+		if (tag.FailedCount) > 0 {
+			r.reportArtifacts(tag, useCase)
+		}
 	}
 	r.xUnitSummary.TestCases = fmt.Sprintf("%d", useCaseCount)
 	r.xUnitSummary.Reports = fmt.Sprintf("%d", useCaseCount)
@@ -586,6 +606,25 @@ func (r *Runner) reportTagSummary() {
 	}
 }
 
+//reportArtifacts prints stored event artifact locations i.e. selenium failure screenshots and adds them as xUnit attachments
+func (r *Runner) reportArtifacts(tag *Event, useCase *xunit.TestCase) {
+	var events = append([]msg.Event{}, tag.Events...)
+	if tag.subEvent != nil {
+		events = append(events, tag.subEvent.Events...)
+	}
+	for _, event := range events {
+		provider, ok := event.Value().(msg.ArtifactProvider)
+		if !ok {
+			continue
+		}
+		for _, location := range msg.ArtifactLocations(provider) {
+			r.printMessage(r.ColorText(tag.TagID, "red"), messageTypeTagDescription, "artifact", msg.MessageStyleError, location)
+			useCase.Attachments = append(useCase.Attachments, location)
+			useCase.Sysout += fmt.Sprintf("\n[[ATTACHMENT|%v]]", location)
+		}
+	}
+}
+
 func (r *Runner) reportEvent(context *endly.Context, event msg.Event, filter map[string]bool) error {
 	eventTag := r.EventTag()
 	r.processEvent(event, filter)
@@ -632,6 +671,18 @@ func (r *Runner) onCallerEnd() {
 	r.processEventTags()
 	r.reportSummaryEvent()
 	r.printSummary()
+	r.printPendingResources()
+}
+
+//printPendingResources prints cleanup hint if the session left resources recorded in the ledger
+func (r *Runner) printPendingResources() {
+	if r.context == nil {
+		return
+	}
+	ledger := r.context.Ledger()
+	if count := len(ledger.Entries); count > 0 && ledger.Persistent() {
+		r.Printf("%v resource(s) left by session, run: endly -cleanup=%v\n", count, r.context.SessionID)
+	}
 }
 
 func (r *Runner) printSummary() {
@@ -0,0 +1,195 @@
+package endly
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/toolbox"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+//LedgerDirectoryEnv env key name to configure ledger directory, when set every session ledger is persisted there, default ~/.endly/ledger
+const LedgerDirectoryEnv = "ENDLY_LEDGER"
+
+//SessionLabel label key used to mark resources created by a workflow session
+const SessionLabel = "endly.session"
+
+var ledgers = make(map[string]*Ledger)
+var ledgersMux = &sync.Mutex{}
+
+//LedgerEntry represents a resource created by a session with its teardown service request
+type LedgerEntry struct {
+	Kind    string                 `description:"resource kind i.e. docker/container, kubernetes/Pod, msg/topic"`
+	Name    string                 `description:"resource name or ID"`
+	Service string                 `description:"teardown service ID"`
+	Action  string                 `description:"teardown service action"`
+	Request map[string]interface{} `description:"teardown service request"`
+	Created time.Time
+}
+
+//Ledger represents a session resource ledger, it is kept in memory unless persistence is enabled, persisted ledger lets a later run tear down resources
+type Ledger struct {
+	SessionID  string
+	Entries    []*LedgerEntry
+	mux        *sync.Mutex
+	persistent bool
+}
+
+//LedgerDirectory returns ledger directory
+func LedgerDirectory() string {
+	if location := os.Getenv(LedgerDirectoryEnv); location != "" {
+		return location
+	}
+	return path.Join(os.Getenv("HOME"), ".endly", "ledger")
+}
+
+func (l *Ledger) location() string {
+	return path.Join(LedgerDirectory(), l.SessionID+".json")
+}
+
+//Register registers created resource with its teardown request, if resource was already registered it is replaced
+func (l *Ledger) Register(kind, name, service, action string, request interface{}) error {
+	var requestMap = make(map[string]interface{})
+	if err := toolbox.DefaultConverter.AssignConverted(&requestMap, request); err != nil {
+		return fmt.Errorf("unable to register %v %v teardown request: %v", kind, name, err)
+	}
+	entry := &LedgerEntry{
+		Kind:    kind,
+		Name:    name,
+		Service: service,
+		Action:  action,
+		Request: toolbox.DeleteEmptyKeys(requestMap),
+		Created: time.Now(),
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.release(kind, name)
+	l.Entries = append(l.Entries, entry)
+	l.persistIfNeeded()
+	return nil
+}
+
+//Release removes resource from ledger, it is used when a workflow removes resource itself
+func (l *Ledger) Release(kind, name string) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.release(kind, name) {
+		l.persistIfNeeded()
+	}
+	return nil
+}
+
+//EnablePersistence persists ledger in ledger directory from now on, so that resources can be torn down by a later run
+func (l *Ledger) EnablePersistence() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.persistent = true
+	l.persistIfNeeded()
+}
+
+//Persistent returns true if ledger is persisted in ledger directory
+func (l *Ledger) Persistent() bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.persistent
+}
+
+//persistIfNeeded persists persistent ledger, I/O errors are only logged as ledger must not fail resource provisioning
+func (l *Ledger) persistIfNeeded() {
+	if !l.persistent {
+		return
+	}
+	if err := l.persist(); err != nil {
+		log.Printf("failed to persist session %v ledger: %v", l.SessionID, err)
+	}
+}
+
+func (l *Ledger) release(kind, name string) bool {
+	for i, entry := range l.Entries {
+		if entry.Kind == kind && entry.Name == name {
+			l.Entries = append(l.Entries[:i], l.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Ledger) persist() error {
+	location := l.location()
+	if len(l.Entries) == 0 {
+		if toolbox.FileExists(location) {
+			return os.Remove(location)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(LedgerDirectory(), 0744); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(location, data, 0644)
+}
+
+//Teardown runs teardown requests in reverse creation order, failed entries are kept in the ledger
+func (l *Ledger) Teardown(context *Context) ([]*LedgerEntry, error) {
+	l.mux.Lock()
+	var entries = append([]*LedgerEntry{}, l.Entries...)
+	l.mux.Unlock()
+	var removed = make([]*LedgerEntry, 0)
+	var errors = make([]string, 0)
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if err := l.teardown(context, entry); err != nil {
+			errors = append(errors, fmt.Sprintf("%v %v: %v", entry.Kind, entry.Name, err))
+			continue
+		}
+		removed = append(removed, entry)
+		_ = l.Release(entry.Kind, entry.Name)
+	}
+	if len(errors) > 0 {
+		return removed, fmt.Errorf("failed to teardown session %v resources: %v", l.SessionID, strings.Join(errors, "; "))
+	}
+	ledgersMux.Lock()
+	delete(ledgers, l.SessionID)
+	ledgersMux.Unlock()
+	return removed, nil
+}
+
+func (l *Ledger) teardown(context *Context, entry *LedgerEntry) error {
+	request, err := context.NewRequest(entry.Service, entry.Action, entry.Request)
+	if err != nil {
+		return err
+	}
+	return Run(context, request, nil)
+}
+
+//Ledger returns context session resource ledger
+func (c *Context) Ledger() *Ledger {
+	return SessionLedger(c.SessionID)
+}
+
+//SessionLedger returns ledger for supplied session, ledger persisted by previous run is loaded if exists,
+//ledger is persistent if it was loaded or ledger directory was configured with LedgerDirectoryEnv
+func SessionLedger(sessionID string) *Ledger {
+	ledgersMux.Lock()
+	defer ledgersMux.Unlock()
+	if ledger, ok := ledgers[sessionID]; ok {
+		return ledger
+	}
+	ledger := &Ledger{SessionID: sessionID, Entries: make([]*LedgerEntry, 0), persistent: os.Getenv(LedgerDirectoryEnv) != ""}
+	if data, err := ioutil.ReadFile(ledger.location()); err == nil {
+		_ = json.Unmarshal(data, ledger)
+		ledger.SessionID = sessionID
+		ledger.persistent = true
+	}
+	ledger.mux = &sync.Mutex{}
+	ledgers[sessionID] = ledger
+	return ledger
+}
@@ -0,0 +1,102 @@
+package endly_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/storage"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestLedger_Register(t *testing.T) {
+	directory, err := ioutil.TempDir("", "ledger")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	_ = os.Setenv(endly.LedgerDirectoryEnv, directory)
+	defer os.Unsetenv(endly.LedgerDirectoryEnv)
+
+	ledger := endly.SessionLedger("register01")
+	assert.Nil(t, ledger.Register("test/kind", "r1", "workflow", "nop", map[string]interface{}{"k": 1}))
+	assert.Nil(t, ledger.Register("test/kind", "r2", "workflow", "nop", nil))
+	assert.Nil(t, ledger.Register("test/kind", "r1", "workflow", "nop", nil))
+	assert.Equal(t, 2, len(ledger.Entries))
+	assert.Equal(t, "r2", ledger.Entries[0].Name)
+	location := path.Join(directory, "register01.json")
+	assert.True(t, toolbox.FileExists(location))
+
+	assert.Nil(t, ledger.Release("test/kind", "r1"))
+	assert.Nil(t, ledger.Release("test/kind", "unknown"))
+	assert.Equal(t, 1, len(ledger.Entries))
+	assert.Nil(t, ledger.Release("test/kind", "r2"))
+	assert.False(t, toolbox.FileExists(location))
+}
+
+func TestLedger_Teardown(t *testing.T) {
+	directory, err := ioutil.TempDir("", "ledger")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	_ = os.Setenv(endly.LedgerDirectoryEnv, directory)
+	defer os.Unsetenv(endly.LedgerDirectoryEnv)
+
+	var assets = make([]string, 0)
+	for _, name := range []string{"asset1.txt", "asset2.txt"} {
+		asset := path.Join(directory, name)
+		assert.Nil(t, ioutil.WriteFile(asset, []byte("test"), 0644))
+		assets = append(assets, asset)
+	}
+	ledger := endly.SessionLedger("teardown01")
+	for _, asset := range assets {
+		request := &storage.RemoveRequest{Assets: []*url.Resource{url.NewResource(asset)}}
+		assert.Nil(t, ledger.Register("storage/asset", asset, storage.ServiceID, "remove", request))
+	}
+
+	//teardown runs registered requests in reverse order
+	manager := endly.New()
+	context := manager.NewContext(nil)
+	defer context.Close()
+	context.SessionID = "teardown01"
+	removed, err := context.Ledger().Teardown(context)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 2, len(removed))
+	assert.Equal(t, assets[1], removed[0].Name)
+	for _, asset := range assets {
+		assert.False(t, toolbox.FileExists(asset))
+	}
+	assert.False(t, toolbox.FileExists(path.Join(directory, "teardown01.json")))
+	assert.Equal(t, 0, len(endly.SessionLedger("teardown01").Entries))
+}
+
+func TestLedger_EnablePersistence(t *testing.T) {
+	home, err := ioutil.TempDir("", "ledgerHome")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(home)
+	_ = os.Unsetenv(endly.LedgerDirectoryEnv)
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", home)
+	defer os.Setenv("HOME", originalHome)
+
+	//ledger is kept in memory by default
+	ledger := endly.SessionLedger("memory01")
+	assert.False(t, ledger.Persistent())
+	assert.Nil(t, ledger.Register("test/kind", "r1", "workflow", "nop", nil))
+	location := path.Join(home, ".endly", "ledger", "memory01.json")
+	assert.False(t, toolbox.FileExists(location))
+
+	ledger.EnablePersistence()
+	assert.True(t, ledger.Persistent())
+	assert.True(t, toolbox.FileExists(location))
+	assert.Nil(t, ledger.Release("test/kind", "r1"))
+	assert.False(t, toolbox.FileExists(location))
+}
@@ -0,0 +1,38 @@
+package msg
+
+//Table represents a tabular report
+type Table struct {
+	Caption string
+	Headers []string
+	Rows    [][]string
+}
+
+//TableReporter represents an event value reported as tables (CLI)
+type TableReporter interface {
+	//Returns zero or more tables
+	Tables() []*Table
+}
+
+//Artifact represents a named event artifact i.e. CSV or HTML report
+type Artifact struct {
+	Name     string
+	Content  []byte
+	Location string //stored artifact location, set by event logger
+}
+
+//ArtifactProvider represents an event value with artifacts stored next to the event log
+type ArtifactProvider interface {
+	//Returns zero or more artifacts
+	Artifacts() []*Artifact
+}
+
+//ArtifactLocations returns stored artifact locations
+func ArtifactLocations(provider ArtifactProvider) []string {
+	var result = make([]string, 0)
+	for _, artifact := range provider.Artifacts() {
+		if artifact.Location != "" {
+			result = append(result, artifact.Location)
+		}
+	}
+	return result
+}
@@ -14,6 +14,7 @@ type Process struct {
 	Owner    string
 	TagIDs   map[string]bool
 	HasTagID bool
+	RunTagID string
 	Workflow *Workflow
 	Task     *Task
 	TaskNode *TasksNode
@@ -38,6 +39,16 @@ func (p *Process) SetTask(task *Task) {
 	}
 }
 
+//ChangeTagID sets running TagID (use case), it returns previously running TagID if it has changed, otherwise empty string
+func (p *Process) ChangeTagID(tagID string) string {
+	if p.RunTagID == tagID {
+		return ""
+	}
+	previous := p.RunTagID
+	p.RunTagID = tagID
+	return previous
+}
+
 //CanRun returns true if current workflow can run
 func (p *Process) CanRun() bool {
 	return !(p.IsTerminated() || p.Scheduled != nil)
@@ -0,0 +1,16 @@
+package model_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/model"
+	"testing"
+)
+
+func TestProcess_ChangeTagID(t *testing.T) {
+	process := model.NewProcess(nil, nil, nil)
+	assert.Equal(t, "", process.ChangeTagID("Test1"))
+	assert.Equal(t, "", process.ChangeTagID("Test1"), "TagID spanning tasks is not changed")
+	assert.Equal(t, "Test1", process.ChangeTagID("Test2"))
+	assert.Equal(t, "Test2", process.ChangeTagID(""), "process end")
+	assert.Equal(t, "", process.ChangeTagID(""))
+}
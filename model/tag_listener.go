@@ -0,0 +1,76 @@
+package model
+
+import (
+	"github.com/viant/endly"
+	"sort"
+	"sync"
+)
+
+//TagListener represents a listener notified once all actions of a workflow TagID (use case) have been run
+type TagListener func(context *endly.Context, tagID string) error
+
+//TagListeners represents named tag listeners registered within a context
+type TagListeners struct {
+	mux       *sync.RWMutex
+	listeners map[string]TagListener
+}
+
+var tagListenersKey = (*TagListeners)(nil)
+
+//Register registers a named tag listener, existing listener with the same name is replaced
+func (l *TagListeners) Register(name string, listener TagListener) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.listeners[name] = listener
+}
+
+//Unregister removes a named tag listener
+func (l *TagListeners) Unregister(name string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	delete(l.listeners, name)
+}
+
+//Notify notifies all listeners in name order, returns the first error
+func (l *TagListeners) Notify(context *endly.Context, tagID string) error {
+	l.mux.RLock()
+	var names = make([]string, 0, len(l.listeners))
+	for name := range l.listeners {
+		names = append(names, name)
+	}
+	l.mux.RUnlock()
+	sort.Strings(names)
+	var err error
+	for _, name := range names {
+		l.mux.RLock()
+		listener, ok := l.listeners[name]
+		l.mux.RUnlock()
+		if !ok {
+			continue
+		}
+		if listenerErr := listener(context, tagID); listenerErr != nil && err == nil {
+			err = listenerErr
+		}
+	}
+	return err
+}
+
+//GetTagListeners returns context tag listeners
+func GetTagListeners(context *endly.Context) *TagListeners {
+	var result *TagListeners
+	if !context.Contains(tagListenersKey) {
+		result = &TagListeners{mux: &sync.RWMutex{}, listeners: make(map[string]TagListener)}
+		_ = context.Put(tagListenersKey, result)
+		return result
+	}
+	context.GetInto(tagListenersKey, &result)
+	return result
+}
+
+//NotifyTagListeners notifies context tag listeners if any were registered
+func NotifyTagListeners(context *endly.Context, tagID string) error {
+	if tagID == "" || !context.Contains(tagListenersKey) {
+		return nil
+	}
+	return GetTagListeners(context).Notify(context, tagID)
+}
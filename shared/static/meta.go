@@ -9,15 +9,15 @@ import (
 func init() {
 	var memStorage = storage.NewMemoryService();
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/maven.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,109,97,118,101,110,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,32,34,77,97,106,111,114,86,101,114,115,105,111,110,46,77,105,110,111,114,86,101,114,115,105,111,110,46,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,77,105,110,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,51,46,53,34,58,32,34,52,34,10,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,114,109,32,45,114,102,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,116,111,117,99,104,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,58,47,47,109,105,114,114,111,114,115,46,103,105,103,101,110,101,116,46,99,111,109,47,97,112,97,99,104,101,47,109,97,118,101,110,47,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,77,97,106,111,114,86,101,114,115,105,111,110,125,47,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,47,98,105,110,97,114,105,101,115,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,97,112,97,99,104,101,45,109,97,118,101,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,80,97,116,104,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,47,98,105,110,34,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,45,118,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,65,112,97,99,104,101,32,77,97,118,101,110,32,40,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,34,44,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,47,98,105,110,47,98,97,115,104,32,45,99,32,39,91,91,32,45,101,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,32,93,93,32,38,38,32,114,109,32,45,114,102,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,39,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,32,45,102,32,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,32,109,97,118,101,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,78,111,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,125}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/build/go.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,103,111,34,44,10,32,32,34,68,101,112,101,110,100,101,110,99,105,101,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,115,100,107,34,44,10,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,115,100,107,86,101,114,115,105,111,110,34,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,71,111,97,108,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,98,117,105,108,100,34,44,10,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,44,10,32,32,32,32,32,32,32,32,34,84,105,109,101,111,117,116,77,115,34,58,32,49,50,48,48,48,48,44,10,32,32,32,32,32,32,32,32,34,69,110,118,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,71,73,84,95,84,69,82,77,73,78,65,76,95,80,82,79,77,80,84,34,58,32,34,49,34,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,101,114,109,105,110,97,116,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,44,10,32,32,32,32,32,32,32,32,32,32,34,105,109,112,111,114,116,101,100,32,97,110,100,32,110,111,116,32,117,115,101,100,34,44,10,32,32,32,32,32,32,32,32,32,32,34,112,97,99,107,97,103,101,32,34,44,10,32,32,32,32,32,32,32,32,32,32,34,80,97,115,115,119,111,114,100,34,44,10,32,32,32,32,32,32,32,32,32,32,34,105,110,32,115,105,110,103,108,101,45,118,97,108,117,101,32,99,111,110,116,101,120,116,34,44,10,32,32,32,32,32,32,32,32,32,32,34,99,97,110,110,111,116,32,117,115,101,32,34,44,10,32,32,32,32,32,32,32,32,32,32,34,101,120,105,116,32,115,116,97,116,117,115,32,49,34,10,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,100,32,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,103,111,32,99,108,101,97,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,103,111,32,103,101,116,32,45,117,32,46,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,101,120,105,116,32,115,116,97,116,117,115,32,49,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,87,104,101,110,34,58,32,34,36,115,116,100,111,117,116,58,47,85,115,101,114,110,97,109,101,32,102,111,114,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,35,35,103,105,116,35,35,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,87,104,101,110,34,58,32,34,36,115,116,100,111,117,116,58,47,80,97,115,115,119,111,114,100,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,42,42,103,105,116,42,42,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,85,115,101,114,110,97,109,101,32,102,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,80,97,115,115,119,111,114,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,65,117,116,104,101,110,116,105,99,97,116,105,111,110,32,102,97,105,108,101,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,103,111,32,36,123,98,117,105,108,100,83,112,101,99,46,103,111,97,108,125,32,36,123,98,117,105,108,100,83,112,101,99,46,97,114,103,115,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,102,97,105,108,101,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,101,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,105,109,112,111,114,116,101,100,32,97,110,100,32,110,111,116,32,117,115,101,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,105,110,32,115,105,110,103,108,101,45,118,97,108,117,101,32,99,111,110,116,101,120,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,112,97,99,107,97,103,101,32,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,97,110,110,111,116,32,117,115,101,32,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,10,125}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/maven.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/build/go.json %v", err)
 		}
 	}
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/geckodriver.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,103,101,99,107,111,100,114,105,118,101,114,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,100,97,114,119,105,110,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,115,58,47,47,103,105,116,104,117,98,46,99,111,109,47,109,111,122,105,108,108,97,47,103,101,99,107,111,100,114,105,118,101,114,47,114,101,108,101,97,115,101,115,47,100,111,119,110,108,111,97,100,47,118,48,46,50,51,46,48,47,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,109,97,99,111,115,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,109,97,99,111,115,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,109,97,99,111,115,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,44,10,32,32,32,32,123,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,108,105,110,117,120,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,115,58,47,47,103,105,116,104,117,98,46,99,111,109,47,109,111,122,105,108,108,97,47,103,101,99,107,111,100,114,105,118,101,114,47,114,101,108,101,97,115,101,115,47,100,111,119,110,108,111,97,100,47,118,48,46,50,51,46,48,47,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,108,105,110,117,120,54,52,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,108,105,110,117,120,54,52,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,125}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/build/maven.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,109,97,118,101,110,34,44,10,32,32,34,68,101,112,101,110,100,101,110,99,105,101,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,109,97,118,101,110,34,44,10,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,36,123,98,117,105,108,100,83,112,101,99,46,118,101,114,115,105,111,110,125,34,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,71,111,97,108,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,98,117,105,108,100,34,44,10,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,44,10,32,32,32,32,32,32,32,32,34,84,105,109,101,111,117,116,77,115,34,58,32,55,50,48,48,48,48,44,10,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,100,32,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,99,108,101,97,110,32,36,98,117,105,108,100,83,112,101,99,46,97,114,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,99,108,101,97,110,32,36,98,117,105,108,100,83,112,101,99,46,97,114,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,36,98,117,105,108,100,83,112,101,99,46,103,111,97,108,32,36,98,117,105,108,100,83,112,101,99,46,97,114,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,83,117,99,99,101,115,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,66,85,73,76,68,32,83,85,67,67,69,83,83,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,65,114,116,105,102,97,99,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,66,117,105,108,100,105,110,103,32,106,97,114,58,91,94,92,47,93,43,40,46,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,10,125}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/geckodriver.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/build/maven.json %v", err)
 		}
 	}
 	{
@@ -27,9 +27,9 @@ func init() {
 		}
 	}
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/jdk.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,106,97,118,97,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,49,46,55,34,44,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,108,105,110,117,120,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,117,112,101,114,85,115,101,114,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,47,111,112,116,47,115,100,107,47,106,100,107,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,47,111,112,116,47,115,100,107,47,106,100,107,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,47,117,115,114,47,108,105,98,47,106,118,109,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,47,117,115,114,47,108,105,98,47,106,118,109,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,100,107,47,106,100,107,45,55,117,56,48,45,108,105,110,117,120,45,120,54,52,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,47,111,112,116,47,115,100,107,47,106,100,107,47,106,100,107,45,55,117,56,48,45,108,105,110,117,120,45,120,54,52,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,80,97,116,104,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,47,98,105,110,34,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,106,97,118,97,32,45,118,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,98,117,105,108,100,32,40,92,92,100,92,92,46,92,92,100,41,46,43,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,47,111,112,116,47,115,100,107,47,106,100,107,34,44,10,32,32,32,32,32,32,32,32,32,32,34,83,117,112,101,114,85,115,101,114,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,106,100,107,45,55,117,56,48,45,108,105,110,117,120,45,120,54,52,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,47,98,105,110,47,98,97,115,104,32,45,99,32,39,91,91,32,45,101,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,32,93,93,32,38,38,32,114,109,32,45,114,102,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,39,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,107,100,105,114,32,45,112,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,112,32,45,114,102,32,47,111,112,116,47,115,100,107,47,106,100,107,47,106,100,107,49,46,55,46,48,95,56,48,47,42,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,78,111,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,10,125}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/geckodriver.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,103,101,99,107,111,100,114,105,118,101,114,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,100,97,114,119,105,110,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,115,58,47,47,103,105,116,104,117,98,46,99,111,109,47,109,111,122,105,108,108,97,47,103,101,99,107,111,100,114,105,118,101,114,47,114,101,108,101,97,115,101,115,47,100,111,119,110,108,111,97,100,47,118,48,46,50,51,46,48,47,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,109,97,99,111,115,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,109,97,99,111,115,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,109,97,99,111,115,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,44,10,32,32,32,32,123,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,108,105,110,117,120,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,115,58,47,47,103,105,116,104,117,98,46,99,111,109,47,109,111,122,105,108,108,97,47,103,101,99,107,111,100,114,105,118,101,114,47,114,101,108,101,97,115,101,115,47,100,111,119,110,108,111,97,100,47,118,48,46,50,51,46,48,47,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,108,105,110,117,120,54,52,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,103,101,99,107,111,100,114,105,118,101,114,45,118,48,46,50,51,46,48,45,108,105,110,117,120,54,52,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,125}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/jdk.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/geckodriver.json %v", err)
 		}
 	}
 	{
@@ -39,27 +39,33 @@ func init() {
 		}
 	}
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/tomcat.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,116,111,109,99,97,116,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,32,34,77,97,106,111,114,86,101,114,115,105,111,110,46,77,105,110,111,114,86,101,114,115,105,111,110,46,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,77,105,110,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,55,46,48,34,58,32,34,49,48,53,34,44,10,32,32,32,32,32,32,32,32,34,56,46,53,34,58,32,34,52,51,34,44,10,32,32,32,32,32,32,32,32,34,57,46,48,34,58,32,34,50,50,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,114,109,32,45,114,102,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,32,45,82,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,116,111,117,99,104,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,100,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,58,47,47,109,105,114,114,111,114,46,109,101,116,114,111,99,97,115,116,46,110,101,116,47,97,112,97,99,104,101,47,116,111,109,99,97,116,47,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,77,97,106,111,114,86,101,114,115,105,111,110,125,47,118,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,47,98,105,110,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,116,111,109,99,97,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,36,116,111,109,99,97,116,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,47,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,32,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,32,116,111,109,99,97,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,78,111,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,115,104,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,47,98,105,110,47,118,101,114,115,105,111,110,46,115,104,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,65,112,97,99,104,101,32,84,111,109,99,97,116,47,40,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,80,111,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,47,108,111,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,47,99,111,110,102,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,32,45,82,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,125}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/selenium-server-standalone.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,115,101,108,101,110,105,117,109,45,115,101,114,118,101,114,45,115,116,97,110,100,97,108,111,110,101,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,34,77,97,106,111,114,86,101,114,115,105,111,110,46,77,105,110,111,114,86,101,114,115,105,111,110,46,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,77,105,110,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,51,46,52,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,53,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,54,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,55,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,56,34,58,32,34,48,34,10,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,115,101,108,101,110,105,117,109,45,115,101,114,118,101,114,45,115,116,97,110,100,97,108,111,110,101,46,106,97,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,58,47,47,115,101,108,101,110,105,117,109,45,114,101,108,101,97,115,101,46,115,116,111,114,97,103,101,46,103,111,111,103,108,101,97,112,105,115,46,99,111,109,47,36,123,97,114,116,105,102,97,99,116,46,77,97,106,111,114,86,101,114,115,105,111,110,125,46,36,123,97,114,116,105,102,97,99,116,46,77,105,110,111,114,86,101,114,115,105,111,110,125,47,115,101,108,101,110,105,117,109,45,115,101,114,118,101,114,45,115,116,97,110,100,97,108,111,110,101,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,106,97,114,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,10,125,10}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/tomcat.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/selenium-server-standalone.json %v", err)
 		}
 	}
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/selenium-server-standalone.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,115,101,108,101,110,105,117,109,45,115,101,114,118,101,114,45,115,116,97,110,100,97,108,111,110,101,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,34,77,97,106,111,114,86,101,114,115,105,111,110,46,77,105,110,111,114,86,101,114,115,105,111,110,46,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,77,105,110,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,51,46,52,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,53,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,54,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,55,34,58,32,34,48,34,44,10,32,32,32,32,32,32,32,32,34,51,46,56,34,58,32,34,48,34,10,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,115,101,108,101,110,105,117,109,45,115,101,114,118,101,114,45,115,116,97,110,100,97,108,111,110,101,46,106,97,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,58,47,47,115,101,108,101,110,105,117,109,45,114,101,108,101,97,115,101,46,115,116,111,114,97,103,101,46,103,111,111,103,108,101,97,112,105,115,46,99,111,109,47,36,123,97,114,116,105,102,97,99,116,46,77,97,106,111,114,86,101,114,115,105,111,110,125,46,36,123,97,114,116,105,102,97,99,116,46,77,105,110,111,114,86,101,114,115,105,111,110,125,47,115,101,108,101,110,105,117,109,45,115,101,114,118,101,114,45,115,116,97,110,100,97,108,111,110,101,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,106,97,114,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,10,125,10}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/maven.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,109,97,118,101,110,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,32,34,77,97,106,111,114,86,101,114,115,105,111,110,46,77,105,110,111,114,86,101,114,115,105,111,110,46,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,77,105,110,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,51,46,53,34,58,32,34,52,34,10,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,114,109,32,45,114,102,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,116,111,117,99,104,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,58,47,47,109,105,114,114,111,114,115,46,103,105,103,101,110,101,116,46,99,111,109,47,97,112,97,99,104,101,47,109,97,118,101,110,47,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,77,97,106,111,114,86,101,114,115,105,111,110,125,47,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,47,98,105,110,97,114,105,101,115,47,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,97,112,97,99,104,101,45,109,97,118,101,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,80,97,116,104,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,47,98,105,110,34,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,45,118,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,65,112,97,99,104,101,32,77,97,118,101,110,32,40,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,34,44,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,45,98,105,110,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,47,98,105,110,47,98,97,115,104,32,45,99,32,39,91,91,32,45,101,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,32,93,93,32,38,38,32,114,109,32,45,114,102,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,109,97,118,101,110,39,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,32,45,102,32,97,112,97,99,104,101,45,109,97,118,101,110,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,32,109,97,118,101,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,78,111,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,125}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/selenium-server-standalone.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/maven.json %v", err)
 		}
 	}
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/build/maven.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,109,97,118,101,110,34,44,10,32,32,34,68,101,112,101,110,100,101,110,99,105,101,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,109,97,118,101,110,34,44,10,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,36,123,98,117,105,108,100,83,112,101,99,46,118,101,114,115,105,111,110,125,34,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,71,111,97,108,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,98,117,105,108,100,34,44,10,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,44,10,32,32,32,32,32,32,32,32,34,84,105,109,101,111,117,116,77,115,34,58,32,55,50,48,48,48,48,44,10,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,100,32,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,99,108,101,97,110,32,36,98,117,105,108,100,83,112,101,99,46,97,114,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,99,108,101,97,110,32,36,98,117,105,108,100,83,112,101,99,46,97,114,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,110,32,36,98,117,105,108,100,83,112,101,99,46,103,111,97,108,32,36,98,117,105,108,100,83,112,101,99,46,97,114,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,83,117,99,99,101,115,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,66,85,73,76,68,32,83,85,67,67,69,83,83,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,65,114,116,105,102,97,99,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,66,117,105,108,100,105,110,103,32,106,97,114,58,91,94,92,47,93,43,40,46,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,10,125}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/chromedriver.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,99,104,114,111,109,101,100,114,105,118,101,114,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,32,34,77,97,106,111,114,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,100,97,114,119,105,110,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,115,58,47,47,115,116,111,114,97,103,101,46,103,111,111,103,108,101,97,112,105,115,46,99,111,109,47,99,104,114,111,109,101,45,102,111,114,45,116,101,115,116,105,110,103,45,112,117,98,108,105,99,47,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,47,109,97,99,45,120,54,52,47,99,104,114,111,109,101,100,114,105,118,101,114,45,109,97,99,45,120,54,52,46,122,105,112,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,99,104,114,111,109,101,100,114,105,118,101,114,45,109,97,99,45,120,54,52,46,122,105,112,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,80,97,116,104,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,104,114,111,109,101,100,114,105,118,101,114,32,45,45,118,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,67,104,114,111,109,101,68,114,105,118,101,114,32,40,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,117,110,122,105,112,32,45,111,32,99,104,114,111,109,101,100,114,105,118,101,114,45,109,97,99,45,120,54,52,46,122,105,112,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,97,110,110,111,116,32,102,105,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,112,32,99,104,114,111,109,101,100,114,105,118,101,114,45,109,97,99,45,120,54,52,47,99,104,114,111,109,101,100,114,105,118,101,114,32,99,104,114,111,109,101,100,114,105,118,101,114,32,38,38,32,99,104,109,111,100,32,43,120,32,99,104,114,111,109,101,100,114,105,118,101,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,44,10,32,32,32,32,123,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,108,105,110,117,120,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,45,82,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,115,58,47,47,115,116,111,114,97,103,101,46,103,111,111,103,108,101,97,112,105,115,46,99,111,109,47,99,104,114,111,109,101,45,102,111,114,45,116,101,115,116,105,110,103,45,112,117,98,108,105,99,47,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,47,108,105,110,117,120,54,52,47,99,104,114,111,109,101,100,114,105,118,101,114,45,108,105,110,117,120,54,52,46,122,105,112,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,47,99,104,114,111,109,101,100,114,105,118,101,114,45,108,105,110,117,120,54,52,46,122,105,112,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,80,97,116,104,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,104,114,111,109,101,100,114,105,118,101,114,32,45,45,118,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,67,104,114,111,109,101,68,114,105,118,101,114,32,40,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,115,101,108,101,110,105,117,109,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,117,110,122,105,112,32,45,111,32,99,104,114,111,109,101,100,114,105,118,101,114,45,108,105,110,117,120,54,52,46,122,105,112,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,97,110,110,111,116,32,102,105,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,112,32,99,104,114,111,109,101,100,114,105,118,101,114,45,108,105,110,117,120,54,52,47,99,104,114,111,109,101,100,114,105,118,101,114,32,99,104,114,111,109,101,100,114,105,118,101,114,32,38,38,32,99,104,109,111,100,32,43,120,32,99,104,114,111,109,101,100,114,105,118,101,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,111,112,116,34,10,125,10}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/build/maven.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/chromedriver.json %v", err)
 		}
 	}
 	{
-		err := memStorage.Upload("mem://github.com/viant/endly/meta/build/go.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,103,111,34,44,10,32,32,34,68,101,112,101,110,100,101,110,99,105,101,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,115,100,107,34,44,10,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,115,100,107,86,101,114,115,105,111,110,34,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,71,111,97,108,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,98,117,105,108,100,34,44,10,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,44,10,32,32,32,32,32,32,32,32,34,84,105,109,101,111,117,116,77,115,34,58,32,49,50,48,48,48,48,44,10,32,32,32,32,32,32,32,32,34,69,110,118,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,71,73,84,95,84,69,82,77,73,78,65,76,95,80,82,79,77,80,84,34,58,32,34,49,34,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,101,114,109,105,110,97,116,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,44,10,32,32,32,32,32,32,32,32,32,32,34,105,109,112,111,114,116,101,100,32,97,110,100,32,110,111,116,32,117,115,101,100,34,44,10,32,32,32,32,32,32,32,32,32,32,34,112,97,99,107,97,103,101,32,34,44,10,32,32,32,32,32,32,32,32,32,32,34,80,97,115,115,119,111,114,100,34,44,10,32,32,32,32,32,32,32,32,32,32,34,105,110,32,115,105,110,103,108,101,45,118,97,108,117,101,32,99,111,110,116,101,120,116,34,44,10,32,32,32,32,32,32,32,32,32,32,34,99,97,110,110,111,116,32,117,115,101,32,34,44,10,32,32,32,32,32,32,32,32,32,32,34,101,120,105,116,32,115,116,97,116,117,115,32,49,34,10,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,100,32,36,98,117,105,108,100,83,112,101,99,46,112,97,116,104,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,103,111,32,99,108,101,97,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,103,111,32,103,101,116,32,45,117,32,46,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,109,109,97,110,100,32,110,111,116,32,102,111,117,110,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,101,120,105,116,32,115,116,97,116,117,115,32,49,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,87,104,101,110,34,58,32,34,36,115,116,100,111,117,116,58,47,85,115,101,114,110,97,109,101,32,102,111,114,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,35,35,103,105,116,35,35,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,87,104,101,110,34,58,32,34,36,115,116,100,111,117,116,58,47,80,97,115,115,119,111,114,100,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,42,42,103,105,116,42,42,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,85,115,101,114,110,97,109,101,32,102,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,80,97,115,115,119,111,114,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,65,117,116,104,101,110,116,105,99,97,116,105,111,110,32,102,97,105,108,101,100,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,103,111,32,36,123,98,117,105,108,100,83,112,101,99,46,103,111,97,108,125,32,36,123,98,117,105,108,100,83,112,101,99,46,97,114,103,115,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,102,97,105,108,101,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,101,114,114,111,114,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,105,109,112,111,114,116,101,100,32,97,110,100,32,110,111,116,32,117,115,101,100,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,105,110,32,115,105,110,103,108,101,45,118,97,108,117,101,32,99,111,110,116,101,120,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,112,97,99,107,97,103,101,32,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,97,110,110,111,116,32,117,115,101,32,34,10,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,10,125}))
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/jdk.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,106,97,118,97,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,49,46,55,34,44,10,32,32,32,32,32,32,34,79,115,84,97,114,103,101,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,34,58,32,34,108,105,110,117,120,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,117,112,101,114,85,115,101,114,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,47,111,112,116,47,115,100,107,47,106,100,107,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,47,111,112,116,47,115,100,107,47,106,100,107,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,47,117,115,114,47,108,105,98,47,106,118,109,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,97,43,114,119,32,47,117,115,114,47,108,105,98,47,106,118,109,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,100,107,47,106,100,107,45,55,117,56,48,45,108,105,110,117,120,45,120,54,52,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,47,111,112,116,47,115,100,107,47,106,100,107,47,106,100,107,45,55,117,56,48,45,108,105,110,117,120,45,120,54,52,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,121,115,116,101,109,80,97,116,104,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,47,98,105,110,34,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,106,97,118,97,32,45,118,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,98,117,105,108,100,32,40,92,92,100,92,92,46,92,92,100,41,46,43,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,47,111,112,116,47,115,100,107,47,106,100,107,34,44,10,32,32,32,32,32,32,32,32,32,32,34,83,117,112,101,114,85,115,101,114,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,106,100,107,45,55,117,56,48,45,108,105,110,117,120,45,120,54,52,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,47,98,105,110,47,98,97,115,104,32,45,99,32,39,91,91,32,45,101,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,32,93,93,32,38,38,32,114,109,32,45,114,102,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,39,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,107,100,105,114,32,45,112,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,99,112,32,45,114,102,32,47,111,112,116,47,115,100,107,47,106,100,107,47,106,100,107,49,46,55,46,48,95,56,48,47,42,32,47,117,115,114,47,108,105,98,47,106,118,109,47,106,97,118,97,45,55,45,111,114,97,99,108,101,47,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,78,111,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,10,125}))
 		if err != nil {
-			log.Printf("failed to upload: mem://github.com/viant/endly/meta/build/go.json %v", err)
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/jdk.json %v", err)
+		}
+	}
+	{
+		err := memStorage.Upload("mem://github.com/viant/endly/meta/deployment/tomcat.json", bytes.NewReader([]byte{123,10,32,32,34,78,97,109,101,34,58,32,34,116,111,109,99,97,116,34,44,10,32,32,34,86,101,114,115,105,111,110,105,110,103,34,58,32,34,77,97,106,111,114,86,101,114,115,105,111,110,46,77,105,110,111,114,86,101,114,115,105,111,110,46,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,44,10,32,32,34,84,97,114,103,101,116,115,34,58,32,91,10,32,32,32,32,123,10,32,32,32,32,32,32,34,77,105,110,82,101,108,101,97,115,101,86,101,114,115,105,111,110,34,58,32,123,10,32,32,32,32,32,32,32,32,34,55,46,48,34,58,32,34,49,48,53,34,44,10,32,32,32,32,32,32,32,32,34,56,46,53,34,58,32,34,52,51,34,44,10,32,32,32,32,32,32,32,32,34,57,46,48,34,58,32,34,50,50,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,34,68,101,112,108,111,121,109,101,110,116,34,58,32,123,10,32,32,32,32,32,32,32,32,34,80,114,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,65,117,116,111,83,117,100,111,34,58,32,116,114,117,101,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,114,109,32,45,114,102,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,32,45,82,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,116,111,117,99,104,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,111,119,110,32,36,123,111,115,46,117,115,101,114,125,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,100,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,84,114,97,110,115,102,101,114,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,83,111,117,114,99,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,104,116,116,112,58,47,47,109,105,114,114,111,114,46,109,101,116,114,111,99,97,115,116,46,110,101,116,47,97,112,97,99,104,101,47,116,111,109,99,97,116,47,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,77,97,106,111,114,86,101,114,115,105,111,110,125,47,118,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,47,98,105,110,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,34,68,101,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,34,78,97,109,101,34,58,32,34,116,111,109,99,97,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,34,58,32,34,36,116,111,109,99,97,116,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,85,82,76,34,58,32,34,115,99,112,58,47,47,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,104,111,115,116,125,47,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,67,114,101,100,101,110,116,105,97,108,115,34,58,32,34,36,123,100,101,112,108,111,121,46,116,97,114,103,101,116,46,99,114,101,100,101,110,116,105,97,108,115,125,34,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,82,117,110,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,68,105,114,101,99,116,111,114,121,34,58,32,34,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,44,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,116,97,114,32,120,118,122,102,32,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,46,116,97,114,46,103,122,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,109,118,32,97,112,97,99,104,101,45,116,111,109,99,97,116,45,36,123,97,114,116,105,102,97,99,116,46,86,101,114,115,105,111,110,125,32,116,111,109,99,97,116,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,114,114,111,114,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,78,111,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,86,101,114,115,105,111,110,67,104,101,99,107,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,34,58,32,34,115,104,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,47,98,105,110,47,118,101,114,115,105,111,110,46,115,104,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,69,120,116,114,97,99,116,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,75,101,121,34,58,32,34,86,101,114,115,105,111,110,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,82,101,103,69,120,112,114,34,58,32,34,65,112,97,99,104,101,32,84,111,109,99,97,116,47,40,92,92,100,43,92,92,46,92,92,100,43,92,92,46,92,92,100,43,41,34,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,34,80,111,115,116,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,34,67,111,109,109,97,110,100,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,47,108,111,103,115,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,109,107,100,105,114,32,45,112,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,47,116,111,109,99,97,116,47,99,111,110,102,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,34,99,104,109,111,100,32,32,45,82,32,97,43,114,119,32,36,123,100,101,112,108,111,121,46,98,97,115,101,76,111,99,97,116,105,111,110,125,34,10,32,32,32,32,32,32,32,32,32,32,93,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,32,32,93,44,10,32,32,34,66,97,115,101,76,111,99,97,116,105,111,110,34,58,32,34,47,117,115,114,47,108,111,99,97,108,34,10,125}))
+		if err != nil {
+			log.Printf("failed to upload: mem://github.com/viant/endly/meta/deployment/tomcat.json %v", err)
 		}
 	}
 }
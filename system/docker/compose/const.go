@@ -0,0 +1,35 @@
+package compose
+
+const (
+	//DefaultNetwork represents project default network key
+	DefaultNetwork = "default"
+
+	//ConditionStarted represents dependency started condition
+	ConditionStarted = "service_started"
+	//ConditionHealthy represents dependency healthy condition
+	ConditionHealthy = "service_healthy"
+	//ConditionCompleted represents dependency completed successfully condition
+	ConditionCompleted = "service_completed_successfully"
+
+	//MountTypeVolume represents named or anonymous volume mount
+	MountTypeVolume = "volume"
+	//MountTypeBind represents host path bind mount
+	MountTypeBind = "bind"
+
+	//ProjectLabel represents compose project label, it is compatible with docker-compose cli
+	ProjectLabel = "com.docker.compose.project"
+	//ServiceLabel represents compose service label
+	ServiceLabel = "com.docker.compose.service"
+	//ContainerNumberLabel represents compose container number label
+	ContainerNumberLabel = "com.docker.compose.container-number"
+	//NetworkLabel represents compose network label
+	NetworkLabel = "com.docker.compose.network"
+	//VolumeLabel represents compose volume label
+	VolumeLabel = "com.docker.compose.volume"
+)
+
+const (
+	defaultTimeoutMs = 120000
+	defaultSleepMs   = 500
+	ledgerProject    = "docker/compose"
+)
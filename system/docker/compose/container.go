@@ -0,0 +1,246 @@
+package compose
+
+import (
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+//ContainerSpec represents service container create specification
+type ContainerSpec struct {
+	Name             string
+	Config           *container.Config
+	HostConfig       *container.HostConfig
+	NetworkingConfig *network.NetworkingConfig
+	Networks         map[string]*network.EndpointSettings `description:"additional networks to connect after container is created"`
+}
+
+//ContainerSpec returns service container specification
+func (p *Project) ContainerSpec(service *Service) (*ContainerSpec, error) {
+	result := &ContainerSpec{
+		Name: p.ContainerName(service),
+		Config: &container.Config{
+			Image:      service.Image,
+			Hostname:   service.Hostname,
+			WorkingDir: service.WorkingDir,
+			User:       service.User,
+			Tty:        service.Tty,
+			Labels:     map[string]string{},
+			Volumes:    map[string]struct{}{},
+		},
+		HostConfig: &container.HostConfig{
+			Privileged: service.Privileged,
+			ExtraHosts: service.ExtraHosts,
+		},
+		Networks: map[string]*network.EndpointSettings{},
+	}
+	for key, value := range service.Labels {
+		result.Config.Labels[key] = value
+	}
+	result.Config.Labels[ProjectLabel] = p.Name
+	result.Config.Labels[ServiceLabel] = service.Name
+	result.Config.Labels[ContainerNumberLabel] = "1"
+	if len(service.Command) > 0 {
+		result.Config.Cmd = []string(service.Command)
+	}
+	if len(service.Entrypoint) > 0 {
+		result.Config.Entrypoint = []string(service.Entrypoint)
+	}
+	var err error
+	if result.Config.Env, err = p.environment(service); err != nil {
+		return nil, err
+	}
+	if err = p.setPorts(service, result); err != nil {
+		return nil, err
+	}
+	p.setMounts(service, result)
+	if result.Config.Healthcheck, err = healthConfig(service.Healthcheck); err != nil {
+		return nil, fmt.Errorf("invalid %v healthcheck: %v", service.Name, err)
+	}
+	if result.HostConfig.RestartPolicy, err = restartPolicy(service.Restart); err != nil {
+		return nil, fmt.Errorf("invalid %v restart: %v", service.Name, err)
+	}
+	p.setNetworks(service, result)
+	return result, nil
+}
+
+//environment returns service environment, env_file variables are overridden by environment section
+func (p *Project) environment(service *Service) ([]string, error) {
+	var env = make(map[string]string)
+	for _, envFile := range service.EnvFile {
+		location := p.location(envFile)
+		data, err := p.loader(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %v env_file: %v, %v", service.Name, location, err)
+		}
+		for key, value := range parseEnvFile(string(data)) {
+			env[key] = value
+		}
+	}
+	for key, value := range service.Environment {
+		env[key] = value
+	}
+	var result = make([]string, 0)
+	for key, value := range env {
+		result = append(result, key+"="+value)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (p *Project) setPorts(service *Service, spec *ContainerSpec) error {
+	var specs = make([]string, 0)
+	for _, port := range service.Ports {
+		portSpec := port.Target + "/" + port.Protocol
+		if port.Published != "" {
+			portSpec = port.Published + ":" + portSpec
+			if port.HostIP != "" {
+				portSpec = port.HostIP + ":" + portSpec
+			}
+		}
+		specs = append(specs, portSpec)
+	}
+	specs = append(specs, service.Expose...)
+	if len(specs) == 0 {
+		return nil
+	}
+	exposed, bindings, err := nat.ParsePortSpecs(specs)
+	if err != nil {
+		return fmt.Errorf("invalid %v ports: %v", service.Name, err)
+	}
+	spec.Config.ExposedPorts = exposed
+	spec.HostConfig.PortBindings = bindings
+	return nil
+}
+
+func (p *Project) setMounts(service *Service, spec *ContainerSpec) {
+	for _, aMount := range service.Volumes {
+		if aMount.Source == "" {
+			spec.Config.Volumes[aMount.Target] = struct{}{}
+			continue
+		}
+		source := aMount.Source
+		if aMount.Type == MountTypeVolume {
+			source = p.VolumeName(source)
+		} else {
+			source = p.path(source)
+		}
+		spec.HostConfig.Mounts = append(spec.HostConfig.Mounts, mount.Mount{
+			Type:     mount.Type(aMount.Type),
+			Source:   source,
+			Target:   aMount.Target,
+			ReadOnly: aMount.ReadOnly,
+		})
+	}
+}
+
+func (p *Project) setNetworks(service *Service, spec *ContainerSpec) {
+	if service.NetworkMode != "" {
+		mode := service.NetworkMode
+		if strings.HasPrefix(mode, "service:") {
+			if dependency, ok := p.Services[strings.TrimPrefix(mode, "service:")]; ok {
+				mode = "container:" + p.ContainerName(dependency)
+			}
+		}
+		spec.HostConfig.NetworkMode = container.NetworkMode(mode)
+		return
+	}
+	var keys = make([]string, 0)
+	for key := range service.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		serviceNetwork := service.Networks[key]
+		endpoint := &network.EndpointSettings{
+			Aliases: append([]string{service.Name}, serviceNetwork.Aliases...),
+		}
+		if serviceNetwork.IPv4Address != "" {
+			endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: serviceNetwork.IPv4Address}
+		}
+		name := p.NetworkName(key)
+		if i == 0 {
+			spec.HostConfig.NetworkMode = container.NetworkMode(name)
+			spec.NetworkingConfig = &network.NetworkingConfig{
+				EndpointsConfig: map[string]*network.EndpointSettings{name: endpoint},
+			}
+			continue
+		}
+		spec.Networks[name] = endpoint
+	}
+}
+
+//path returns absolute path, relative path is resolved with project base directory
+func (p *Project) path(location string) string {
+	if strings.HasPrefix(location, "~") {
+		location = strings.Replace(location, "~", os.Getenv("HOME"), 1)
+	}
+	if path.IsAbs(location) {
+		return location
+	}
+	return path.Join(p.BaseDirectory, location)
+}
+
+func healthConfig(healthcheck *Healthcheck) (*container.HealthConfig, error) {
+	if healthcheck == nil {
+		return nil, nil
+	}
+	if healthcheck.Disable {
+		return &container.HealthConfig{Test: []string{"NONE"}}, nil
+	}
+	result := &container.HealthConfig{Retries: healthcheck.Retries}
+	switch len(healthcheck.Test) {
+	case 0:
+	case 1:
+		result.Test = []string{"CMD-SHELL", healthcheck.Test[0]}
+		if healthcheck.Test[0] == "NONE" {
+			result.Test = []string{"NONE"}
+		}
+	default:
+		result.Test = healthcheck.Test
+	}
+	var err error
+	for _, item := range []struct {
+		value  string
+		target *time.Duration
+	}{
+		{healthcheck.Interval, &result.Interval},
+		{healthcheck.Timeout, &result.Timeout},
+		{healthcheck.StartPeriod, &result.StartPeriod},
+	} {
+		if item.value == "" {
+			continue
+		}
+		if *item.target, err = time.ParseDuration(item.value); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func restartPolicy(restart string) (container.RestartPolicy, error) {
+	var result = container.RestartPolicy{}
+	if restart == "" || restart == "no" {
+		return result, nil
+	}
+	pair := strings.SplitN(restart, ":", 2)
+	switch pair[0] {
+	case "always", "unless-stopped", "on-failure":
+		result.Name = pair[0]
+	default:
+		return result, fmt.Errorf("unsupported restart policy: %v", restart)
+	}
+	if len(pair) == 2 {
+		if _, err := fmt.Sscanf(pair[1], "%d", &result.MaximumRetryCount); err != nil {
+			return result, fmt.Errorf("invalid restart retry count: %v", restart)
+		}
+	}
+	return result, nil
+}
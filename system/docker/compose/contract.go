@@ -0,0 +1,230 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/afs/file"
+	aurl "github.com/viant/afs/url"
+	"github.com/viant/endly"
+	estorage "github.com/viant/endly/system/storage"
+	"github.com/viant/toolbox/url"
+	"path"
+)
+
+//ProjectRequest represents compose project reference
+type ProjectRequest struct {
+	Source      *url.Resource     `description:"docker compose file location"`
+	ProjectName string            `description:"project name, default: compose file directory name"`
+	Env         map[string]string `description:"compose file variables, .env file and OS environment variables are used as fallback"`
+}
+
+//Init initializes request
+func (r *ProjectRequest) Init() error {
+	if r.Source == nil {
+		return nil
+	}
+	if err := r.Source.Init(); err != nil {
+		return err
+	}
+	if r.ProjectName == "" {
+		r.ProjectName = path.Base(path.Dir(r.Source.ParsedURL.Path))
+	}
+	r.ProjectName = projectName(r.ProjectName)
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *ProjectRequest) Validate() error {
+	if r.Source == nil && r.ProjectName == "" {
+		return errors.New("source and projectName were empty")
+	}
+	return nil
+}
+
+//Load loads compose project
+func (r *ProjectRequest) Load(context *endly.Context) (*Project, error) {
+	if r.Source == nil {
+		return nil, errors.New("source was empty")
+	}
+	resource, storageOpts, err := estorage.GetResourceWithOptions(context, r.Source)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := estorage.StorageService(context, resource)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fs.DownloadWithURL(context.Background(), resource.URL, storageOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load compose file: %v, %v", resource.URL, err)
+	}
+	if aurl.Scheme(resource.URL, file.Scheme) == file.Scheme {
+		return NewProject(r.ProjectName, path.Dir(resource.ParsedURL.Path), string(content), r.Env)
+	}
+	baseURL, _ := aurl.Split(resource.URL, file.Scheme)
+	return NewProjectWithLoader(r.ProjectName, baseURL, string(content), r.Env, func(URL string) ([]byte, error) {
+		return fs.DownloadWithURL(context.Background(), URL, storageOpts...)
+	})
+}
+
+//UpRequest represents compose up request
+type UpRequest struct {
+	ProjectRequest `json:",inline" yaml:",inline"`
+	Services       []string `description:"services to start with their dependencies, all services if empty"`
+	Credentials    string   `description:"docker registry credentials used to pull images"`
+	Reuse          bool     `description:"reuse existing project containers, otherwise containers are recreated"`
+	Wait           bool     `description:"wait for started services to be healthy (or running if healthcheck is not defined)"`
+	TimeoutMs      int      `description:"dependency and wait timeout, default 120000"`
+}
+
+//Init initializes request
+func (r *UpRequest) Init() error {
+	if r.TimeoutMs == 0 {
+		r.TimeoutMs = defaultTimeoutMs
+	}
+	return r.ProjectRequest.Init()
+}
+
+//Validate checks if request is valid
+func (r *UpRequest) Validate() error {
+	if r.Source == nil {
+		return errors.New("source was empty")
+	}
+	return nil
+}
+
+//UpResponse represents compose up response
+type UpResponse struct {
+	Project  string
+	Networks []string
+	Volumes  []string
+	Services []*ServiceStatus
+}
+
+//DownRequest represents compose down request
+type DownRequest struct {
+	ProjectRequest `json:",inline" yaml:",inline"`
+	RemoveVolumes  bool `description:"remove project named and anonymous volumes"`
+	TimeoutSec     int  `description:"container stop timeout, default 10"`
+}
+
+//Init initializes request
+func (r *DownRequest) Init() error {
+	if r.TimeoutSec == 0 {
+		r.TimeoutSec = 10
+	}
+	return r.ProjectRequest.Init()
+}
+
+//DownResponse represents compose down response
+type DownResponse struct {
+	Containers []string
+	Networks   []string
+	Volumes    []string
+}
+
+//PsRequest represents compose project containers status request
+type PsRequest struct {
+	ProjectRequest `json:",inline" yaml:",inline"`
+	Services       []string `description:"service filter"`
+}
+
+//PsResponse represents compose project containers status response
+type PsResponse struct {
+	Services []*ServiceStatus
+}
+
+//LogsRequest represents compose service logs request
+type LogsRequest struct {
+	ProjectRequest `json:",inline" yaml:",inline"`
+	Services       []string `description:"service filter"`
+	Tail           string   `description:"number of lines from the end of the logs, default all"`
+	Since          string   `description:"show logs since timestamp (i.e. 2013-01-02T13:23:37) or relative (i.e. 42m)"`
+	Timestamps     bool
+}
+
+//LogsResponse represents compose service logs response
+type LogsResponse struct {
+	Logs map[string]string `description:"logs keyed by service"`
+}
+
+//WaitRequest represents compose wait request
+type WaitRequest struct {
+	ProjectRequest `json:",inline" yaml:",inline"`
+	Services       []string `description:"services to wait for, all project services if empty"`
+	Condition      string   `description:"service_healthy (default: healthy or running if healthcheck is not defined), service_started or service_completed_successfully"`
+	TimeoutMs      int      `description:"wait timeout, default 120000"`
+	SleepMs        int      `description:"status check frequency, default 500"`
+}
+
+//Init initializes request
+func (r *WaitRequest) Init() error {
+	if r.Condition == "" {
+		r.Condition = ConditionHealthy
+	}
+	if r.TimeoutMs == 0 {
+		r.TimeoutMs = defaultTimeoutMs
+	}
+	if r.SleepMs == 0 {
+		r.SleepMs = defaultSleepMs
+	}
+	return r.ProjectRequest.Init()
+}
+
+//Validate checks if request is valid
+func (r *WaitRequest) Validate() error {
+	switch r.Condition {
+	case ConditionStarted, ConditionHealthy, ConditionCompleted:
+	default:
+		return fmt.Errorf("unsupported condition: %v", r.Condition)
+	}
+	return r.ProjectRequest.Validate()
+}
+
+//WaitResponse represents compose wait response
+type WaitResponse struct {
+	Services   []*ServiceStatus
+	WaitTimeMs int
+}
+
+//ServiceStatus represents service container status
+type ServiceStatus struct {
+	Service     string
+	Container   string
+	ContainerID string
+	Image       string
+	State       string `description:"created, running, restarting, exited etc."`
+	Health      string `json:",omitempty" description:"starting, healthy or unhealthy if container defines healthcheck"`
+	ExitCode    int
+	Ports       []string `json:",omitempty"`
+}
+
+//Satisfies returns true if status satisfies condition, error is returned if condition can not be satisfied anymore
+func (s *ServiceStatus) Satisfies(condition string) (bool, error) {
+	switch condition {
+	case ConditionCompleted:
+		if s.State != "exited" && s.State != "dead" {
+			return false, nil
+		}
+		if s.ExitCode != 0 {
+			return false, fmt.Errorf("service %v exited with code %v", s.Service, s.ExitCode)
+		}
+		return true, nil
+	}
+	if s.State == "exited" || s.State == "dead" {
+		if s.ExitCode == 0 && s.Health == "" { //one-off task completed
+			return true, nil
+		}
+		return false, fmt.Errorf("service %v exited with code %v", s.Service, s.ExitCode)
+	}
+	if s.State != "running" {
+		return false, nil
+	}
+	if condition == ConditionHealthy && s.Health != "" {
+		if s.Health == "unhealthy" {
+			return false, fmt.Errorf("service %v is unhealthy", s.Service)
+		}
+		return s.Health == "healthy", nil
+	}
+	return true, nil
+}
@@ -0,0 +1,656 @@
+package compose
+
+import (
+	"fmt"
+	"github.com/viant/afs/file"
+	aurl "github.com/viant/afs/url"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//Loader returns content of project file, i.e. .env or env_file
+type Loader func(URL string) ([]byte, error)
+
+//Project represents docker compose project
+type Project struct {
+	Name          string              `yaml:"-"`
+	BaseDirectory string              `yaml:"-"`
+	BaseURL       string              `yaml:"-"`
+	loader        Loader
+	Version       string              `yaml:"version,omitempty"`
+	Services      map[string]*Service `yaml:"services,omitempty"`
+	Networks      map[string]*Network `yaml:"networks,omitempty"`
+	Volumes       map[string]*Volume  `yaml:"volumes,omitempty"`
+}
+
+//Service represents docker compose service
+type Service struct {
+	Name          string          `yaml:"-"`
+	Image         string          `yaml:"image,omitempty"`
+	Build         *Build          `yaml:"build,omitempty"`
+	ContainerName string          `yaml:"container_name,omitempty"`
+	Hostname      string          `yaml:"hostname,omitempty"`
+	Command       Command         `yaml:"command,omitempty"`
+	Entrypoint    Command         `yaml:"entrypoint,omitempty"`
+	Environment   MappingOrList   `yaml:"environment,omitempty"`
+	EnvFile       StringOrList    `yaml:"env_file,omitempty"`
+	Labels        MappingOrList   `yaml:"labels,omitempty"`
+	Ports         []*Port         `yaml:"ports,omitempty"`
+	Expose        []string        `yaml:"expose,omitempty"`
+	Volumes       []*Mount        `yaml:"volumes,omitempty"`
+	Networks      ServiceNetworks `yaml:"networks,omitempty"`
+	NetworkMode   string          `yaml:"network_mode,omitempty"`
+	DependsOn     DependsOn       `yaml:"depends_on,omitempty"`
+	Healthcheck   *Healthcheck    `yaml:"healthcheck,omitempty"`
+	Restart       string          `yaml:"restart,omitempty"`
+	WorkingDir    string          `yaml:"working_dir,omitempty"`
+	User          string          `yaml:"user,omitempty"`
+	ExtraHosts    []string        `yaml:"extra_hosts,omitempty"`
+	Privileged    bool            `yaml:"privileged,omitempty"`
+	Tty           bool            `yaml:"tty,omitempty"`
+}
+
+//Build represents service image build
+type Build struct {
+	Context    string            `yaml:"context,omitempty"`
+	Dockerfile string            `yaml:"dockerfile,omitempty"`
+	Args       map[string]string `yaml:"args,omitempty"`
+}
+
+//UnmarshalYAML decodes build context or build definition
+func (b *Build) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var context string
+	if err := unmarshal(&context); err == nil {
+		b.Context = context
+		return nil
+	}
+	type build Build
+	return unmarshal((*build)(b))
+}
+
+//Network represents docker compose network
+type Network struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	External   External          `yaml:"external,omitempty"`
+	Internal   bool              `yaml:"internal,omitempty"`
+	Labels     MappingOrList     `yaml:"labels,omitempty"`
+}
+
+//Volume represents docker compose named volume
+type Volume struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	External   External          `yaml:"external,omitempty"`
+	Labels     MappingOrList     `yaml:"labels,omitempty"`
+}
+
+//External represents external resource flag, legacy external name is supported
+type External struct {
+	External bool
+	Name     string
+}
+
+//UnmarshalYAML decodes external flag or legacy {name: ...} definition
+func (e *External) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&e.External); err == nil {
+		return nil
+	}
+	var legacy = struct {
+		Name string `yaml:"name"`
+	}{}
+	if err := unmarshal(&legacy); err != nil {
+		return err
+	}
+	e.External = true
+	e.Name = legacy.Name
+	return nil
+}
+
+//Healthcheck represents service healthcheck
+type Healthcheck struct {
+	Test        StringOrList `yaml:"test,omitempty"`
+	Interval    string       `yaml:"interval,omitempty"`
+	Timeout     string       `yaml:"timeout,omitempty"`
+	StartPeriod string       `yaml:"start_period,omitempty"`
+	Retries     int          `yaml:"retries,omitempty"`
+	Disable     bool         `yaml:"disable,omitempty"`
+}
+
+//StringOrList represents string or list value
+type StringOrList []string
+
+//UnmarshalYAML decodes string or list
+func (s *StringOrList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		*s = []string{text}
+		return nil
+	}
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+//Command represents command list or string split with shell quoting rules
+type Command []string
+
+//UnmarshalYAML decodes command list or string
+func (c *Command) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		args, err := splitArgs(text)
+		if err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	}
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*c = list
+	return nil
+}
+
+//splitArgs splits text with shell quoting rules
+func splitArgs(text string) ([]string, error) {
+	var result = make([]string, 0)
+	var current = make([]rune, 0)
+	var quote rune
+	var hasToken, escaped bool
+	for _, r := range text {
+		switch {
+		case escaped:
+			current = append(current, r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current = append(current, r)
+		case r == '"' || r == '\'':
+			quote = r
+			hasToken = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if hasToken || len(current) > 0 {
+				result = append(result, string(current))
+			}
+			current = current[:0]
+			hasToken = false
+		default:
+			current = append(current, r)
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote: %v", text)
+	}
+	if hasToken || len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result, nil
+}
+
+//MappingOrList represents KEY=VALUE list or map value
+type MappingOrList map[string]string
+
+//UnmarshalYAML decodes KEY=VALUE list or map
+func (m *MappingOrList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var result = make(map[string]string)
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		for _, item := range list {
+			pair := strings.SplitN(item, "=", 2)
+			if len(pair) == 1 {
+				result[pair[0]] = os.Getenv(pair[0])
+				continue
+			}
+			result[pair[0]] = pair[1]
+		}
+		*m = result
+		return nil
+	}
+	var aMap map[string]interface{}
+	if err := unmarshal(&aMap); err != nil {
+		return err
+	}
+	for key, value := range aMap {
+		if value == nil {
+			result[key] = os.Getenv(key)
+			continue
+		}
+		result[key] = fmt.Sprintf("%v", value)
+	}
+	*m = result
+	return nil
+}
+
+//Dependency represents service dependency condition
+type Dependency struct {
+	Condition string `yaml:"condition,omitempty"`
+}
+
+//DependsOn represents service dependencies list or map
+type DependsOn map[string]*Dependency
+
+//UnmarshalYAML decodes dependencies list or map
+func (d *DependsOn) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var result = make(map[string]*Dependency)
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		for _, item := range list {
+			result[item] = &Dependency{Condition: ConditionStarted}
+		}
+		*d = result
+		return nil
+	}
+	var aMap map[string]*Dependency
+	if err := unmarshal(&aMap); err != nil {
+		return err
+	}
+	for key, value := range aMap {
+		if value == nil {
+			value = &Dependency{}
+		}
+		if value.Condition == "" {
+			value.Condition = ConditionStarted
+		}
+		result[key] = value
+	}
+	*d = result
+	return nil
+}
+
+//ServiceNetwork represents service network attachment
+type ServiceNetwork struct {
+	Aliases     []string `yaml:"aliases,omitempty"`
+	IPv4Address string   `yaml:"ipv4_address,omitempty"`
+}
+
+//ServiceNetworks represents service networks list or map
+type ServiceNetworks map[string]*ServiceNetwork
+
+//UnmarshalYAML decodes networks list or map
+func (n *ServiceNetworks) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var result = make(map[string]*ServiceNetwork)
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		for _, item := range list {
+			result[item] = &ServiceNetwork{}
+		}
+		*n = result
+		return nil
+	}
+	var aMap map[string]*ServiceNetwork
+	if err := unmarshal(&aMap); err != nil {
+		return err
+	}
+	for key, value := range aMap {
+		if value == nil {
+			value = &ServiceNetwork{}
+		}
+		result[key] = value
+	}
+	*n = result
+	return nil
+}
+
+//Port represents published port
+type Port struct {
+	HostIP    string `yaml:"host_ip,omitempty"`
+	Published string `yaml:"published,omitempty"`
+	Target    string `yaml:"target,omitempty"`
+	Protocol  string `yaml:"protocol,omitempty"`
+}
+
+//UnmarshalYAML decodes short ([host_ip:][published:]target[/protocol]) or long port syntax
+func (p *Port) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		if index := strings.LastIndex(text, "/"); index != -1 {
+			p.Protocol = text[index+1:]
+			text = text[:index]
+		}
+		parts := strings.Split(text, ":")
+		switch len(parts) {
+		case 1:
+			p.Target = parts[0]
+		case 2:
+			p.Published, p.Target = parts[0], parts[1]
+		case 3:
+			p.HostIP, p.Published, p.Target = parts[0], parts[1], parts[2]
+		default:
+			return fmt.Errorf("invalid port: %v", text)
+		}
+	} else {
+		var long = struct {
+			HostIP    string      `yaml:"host_ip"`
+			Published interface{} `yaml:"published"`
+			Target    interface{} `yaml:"target"`
+			Protocol  string      `yaml:"protocol"`
+		}{}
+		if err := unmarshal(&long); err != nil {
+			return err
+		}
+		p.HostIP, p.Protocol = long.HostIP, long.Protocol
+		if long.Published != nil {
+			p.Published = fmt.Sprintf("%v", long.Published)
+		}
+		if long.Target != nil {
+			p.Target = fmt.Sprintf("%v", long.Target)
+		}
+	}
+	if p.Protocol == "" {
+		p.Protocol = "tcp"
+	}
+	if p.Target == "" {
+		return fmt.Errorf("port target was empty")
+	}
+	return nil
+}
+
+//Mount represents service volume mount
+type Mount struct {
+	Type     string `yaml:"type,omitempty" description:"volume or bind"`
+	Source   string `yaml:"source,omitempty"`
+	Target   string `yaml:"target,omitempty"`
+	ReadOnly bool   `yaml:"read_only,omitempty"`
+}
+
+//UnmarshalYAML decodes short (source:target[:mode]) or long volume syntax
+func (m *Mount) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		parts := strings.Split(text, ":")
+		switch len(parts) {
+		case 1:
+			m.Target = parts[0]
+		case 2:
+			m.Source, m.Target = parts[0], parts[1]
+		default:
+			m.Source, m.Target = parts[0], parts[1]
+			m.ReadOnly = strings.Contains(parts[2], "ro")
+		}
+	} else {
+		type mount Mount
+		if err := unmarshal((*mount)(m)); err != nil {
+			return err
+		}
+	}
+	if m.Type == "" {
+		m.Type = MountTypeVolume
+		if strings.HasPrefix(m.Source, ".") || strings.HasPrefix(m.Source, "/") || strings.HasPrefix(m.Source, "~") {
+			m.Type = MountTypeBind
+		}
+	}
+	return nil
+}
+
+var variableExpr = regexp.MustCompile(`\$\$|\$\{([^}]+)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+//interpolate replaces ${VAR}, ${VAR:-default}, ${VAR-default} and $VAR with supplied or OS environment variables, $$ is escaped $
+func interpolate(content string, env map[string]string) string {
+	lookup := func(name string) (string, bool) {
+		if value, ok := env[name]; ok {
+			return value, true
+		}
+		return os.LookupEnv(name)
+	}
+	return variableExpr.ReplaceAllStringFunc(content, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		expression := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(match, "${"), "$"), "}")
+		if index := strings.Index(expression, ":-"); index != -1 {
+			if value, ok := lookup(expression[:index]); ok && value != "" {
+				return value
+			}
+			return expression[index+2:]
+		}
+		if index := strings.Index(expression, "-"); index != -1 {
+			if value, ok := lookup(expression[:index]); ok {
+				return value
+			}
+			return expression[index+1:]
+		}
+		value, _ := lookup(expression)
+		return value
+	})
+}
+
+//parseEnvFile parses KEY=VALUE env file content
+func parseEnvFile(content string) map[string]string {
+	var result = make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pair := strings.SplitN(line, "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+		result[strings.TrimSpace(pair[0])] = strings.Trim(strings.TrimSpace(pair[1]), `"'`)
+	}
+	return result
+}
+
+//projectName returns normalized compose project name
+func projectName(name string) string {
+	name = strings.ToLower(name)
+	var result = make([]rune, 0)
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			result = append(result, r)
+		}
+	}
+	return string(result)
+}
+
+//NewProject parses compose content with project files read from local base directory
+func NewProject(name, baseDirectory, content string, env map[string]string) (*Project, error) {
+	return NewProjectWithLoader(name, baseDirectory, content, env, ioutil.ReadFile)
+}
+
+//NewProjectWithLoader parses compose content, project files are read with loader relative to base URL,
+//variables are interpolated with supplied env, .env file variables and OS environment
+func NewProjectWithLoader(name, baseURL, content string, env map[string]string, loader Loader) (*Project, error) {
+	project := &Project{BaseURL: baseURL, BaseDirectory: aurl.Path(baseURL), loader: loader}
+	var variables = make(map[string]string)
+	if data, err := loader(project.location(".env")); err == nil {
+		variables = parseEnvFile(string(data))
+	}
+	for key, value := range env {
+		variables[key] = value
+	}
+	if err := yaml.Unmarshal([]byte(interpolate(content, variables)), project); err != nil {
+		return nil, fmt.Errorf("failed to decode compose file: %v", err)
+	}
+	if name == "" {
+		name = path.Base(project.BaseDirectory)
+	}
+	project.Name = projectName(name)
+	if project.Name == "" {
+		return nil, fmt.Errorf("project name was empty")
+	}
+	if len(project.Services) == 0 {
+		return nil, fmt.Errorf("services were empty")
+	}
+	if project.Networks == nil {
+		project.Networks = make(map[string]*Network)
+	}
+	if project.Volumes == nil {
+		project.Volumes = make(map[string]*Volume)
+	}
+	for serviceName, service := range project.Services {
+		if service == nil {
+			return nil, fmt.Errorf("service %v definition was empty", serviceName)
+		}
+		service.Name = serviceName
+		if service.Image == "" && service.Build == nil {
+			return nil, fmt.Errorf("service %v: image and build were empty", serviceName)
+		}
+		if service.Image == "" {
+			service.Image = project.Name + "_" + serviceName
+		}
+		for dependency := range service.DependsOn {
+			if _, ok := project.Services[dependency]; !ok {
+				return nil, fmt.Errorf("service %v depends on undefined service %v", serviceName, dependency)
+			}
+		}
+		if service.NetworkMode == "" && len(service.Networks) == 0 {
+			service.Networks = ServiceNetworks{DefaultNetwork: &ServiceNetwork{}}
+		}
+		for network := range service.Networks {
+			if _, ok := project.Networks[network]; !ok && network != DefaultNetwork {
+				return nil, fmt.Errorf("service %v refers to undefined network %v", serviceName, network)
+			}
+			if _, ok := project.Networks[network]; !ok {
+				project.Networks[network] = &Network{}
+			}
+		}
+		for _, mount := range service.Volumes {
+			if mount.Type != MountTypeVolume || mount.Source == "" {
+				continue
+			}
+			if _, ok := project.Volumes[mount.Source]; !ok {
+				return nil, fmt.Errorf("service %v refers to undefined volume %v", serviceName, mount.Source)
+			}
+		}
+	}
+	for key, network := range project.Networks {
+		if network == nil {
+			network = &Network{}
+			project.Networks[key] = network
+		}
+	}
+	for key, volume := range project.Volumes {
+		if volume == nil {
+			project.Volumes[key] = &Volume{}
+		}
+	}
+	return project, nil
+}
+
+//IsLocal returns true if project files are on local file system
+func (p *Project) IsLocal() bool {
+	return aurl.Scheme(p.BaseURL, file.Scheme) == file.Scheme
+}
+
+//location returns project file URL, relative location is resolved with project base URL
+func (p *Project) location(location string) string {
+	if p.IsLocal() {
+		return p.path(location)
+	}
+	if path.IsAbs(location) {
+		baseURL, _ := aurl.Base(p.BaseURL, file.Scheme)
+		return baseURL + location
+	}
+	return aurl.Join(p.BaseURL, location)
+}
+
+//NetworkName returns docker network name for compose network key
+func (p *Project) NetworkName(key string) string {
+	network := p.Networks[key]
+	if network != nil {
+		if network.External.Name != "" {
+			return network.External.Name
+		}
+		if network.Name != "" {
+			return network.Name
+		}
+		if network.External.External {
+			return key
+		}
+	}
+	return p.Name + "_" + key
+}
+
+//VolumeName returns docker volume name for compose volume key
+func (p *Project) VolumeName(key string) string {
+	volume := p.Volumes[key]
+	if volume != nil {
+		if volume.External.Name != "" {
+			return volume.External.Name
+		}
+		if volume.Name != "" {
+			return volume.Name
+		}
+		if volume.External.External {
+			return key
+		}
+	}
+	return p.Name + "_" + key
+}
+
+//ContainerName returns service container name
+func (p *Project) ContainerName(service *Service) string {
+	if service.ContainerName != "" {
+		return service.ContainerName
+	}
+	return p.Name + "_" + service.Name + "_1"
+}
+
+//Order returns selected services with their dependencies ordered by depends_on, if selection is empty all services are returned
+func (p *Project) Order(selected ...string) ([]*Service, error) {
+	var names = make([]string, 0)
+	if len(selected) == 0 {
+		for name := range p.Services {
+			names = append(names, name)
+		}
+	} else {
+		for _, name := range selected {
+			if _, ok := p.Services[name]; !ok {
+				return nil, fmt.Errorf("undefined service: %v", name)
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var result = make([]*Service, 0)
+	var state = make(map[string]int)
+	var visit func(name string, trail []string) error
+	visit = func(name string, trail []string) error {
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("circular dependency: %v", strings.Join(append(trail, name), " -> "))
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		service := p.Services[name]
+		var dependencies = make([]string, 0)
+		for dependency := range service.DependsOn {
+			dependencies = append(dependencies, dependency)
+		}
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if err := visit(dependency, append(trail, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		result = append(result, service)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, []string{}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
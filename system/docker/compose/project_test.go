@@ -0,0 +1,166 @@
+package compose
+
+import (
+	"fmt"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+func loadTestProject(t *testing.T, env map[string]string) *Project {
+	baseDirectory := path.Join(toolbox.CallerDirectory(3), "test", "app")
+	content, err := ioutil.ReadFile(path.Join(baseDirectory, "docker-compose.yaml"))
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	project, err := NewProject("", baseDirectory, string(content), env)
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	return project
+}
+
+func TestNewProject(t *testing.T) {
+	project := loadTestProject(t, map[string]string{"MYSQL_VERSION": "8.0"})
+	if project == nil {
+		return
+	}
+	assert.EqualValues(t, "app", project.Name)
+	db := project.Services["db"]
+	assert.EqualValues(t, "mysql:8.0", db.Image)
+	assert.EqualValues(t, "dev", db.Environment["MYSQL_ROOT_PASSWORD"], ".env variable")
+	assert.EqualValues(t, &Port{HostIP: "127.0.0.1", Published: "3306", Target: "3306", Protocol: "tcp"}, db.Ports[0])
+	assert.EqualValues(t, &Mount{Type: MountTypeVolume, Source: "db-data", Target: "/var/lib/mysql"}, db.Volumes[0])
+	assert.EqualValues(t, &Mount{Type: MountTypeBind, Source: "./config/my.cnf", Target: "/etc/mysql/conf.d/my.cnf", ReadOnly: true}, db.Volumes[1])
+	assert.EqualValues(t, []string{"mysql"}, db.Networks["backend"].Aliases)
+
+	migrate := project.Services["migrate"]
+	assert.EqualValues(t, Command{"migrate", "--dsn", "root:${DB_PASSWORD}@db/app", "up"}, migrate.Command)
+	assert.EqualValues(t, ConditionHealthy, migrate.DependsOn["db"].Condition)
+	assert.NotNil(t, migrate.Networks[DefaultNetwork])
+
+	app := project.Services["app"]
+	assert.EqualValues(t, "db", app.Environment["DB_HOST"])
+	assert.EqualValues(t, ConditionStarted, app.DependsOn["migrate"].Condition)
+	assert.EqualValues(t, &Port{Published: "19090", Target: "9090", Protocol: "udp"}, app.Ports[1])
+
+	assert.EqualValues(t, "app_backend", project.NetworkName("backend"))
+	assert.EqualValues(t, "app_default", project.NetworkName(DefaultNetwork))
+	assert.EqualValues(t, "app_db-data", project.VolumeName("db-data"))
+	assert.EqualValues(t, "app", project.ContainerName(app))
+	assert.EqualValues(t, "app_db_1", project.ContainerName(db))
+
+	services, err := project.Order()
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, []string{"db", "migrate", "app"}, serviceNames(services))
+	}
+	services, err = project.Order("migrate")
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, []string{"db", "migrate"}, serviceNames(services))
+	}
+	_, err = project.Order("web")
+	assert.NotNil(t, err)
+}
+
+func TestNewProject_Errors(t *testing.T) {
+	var useCases = []struct {
+		description string
+		content     string
+	}{
+		{"no services", "version: '3'"},
+		{"no image", "services:\n  app:\n    command: run"},
+		{"undefined dependency", "services:\n  app:\n    image: app\n    depends_on: [db]"},
+		{"undefined network", "services:\n  app:\n    image: app\n    networks: [backend]"},
+		{"undefined volume", "services:\n  app:\n    image: app\n    volumes: ['data:/data']"},
+		{"invalid port", "services:\n  app:\n    image: app\n    ports: ['1:2:3:4']"},
+	}
+	for _, useCase := range useCases {
+		_, err := NewProject("test", "/tmp", useCase.content, nil)
+		assert.NotNil(t, err, useCase.description)
+	}
+	project, err := NewProject("test", "/tmp", "services:\n  a:\n    image: a\n    depends_on: [b]\n  b:\n    image: b\n    depends_on: [a]", nil)
+	if assert.Nil(t, err) {
+		_, err = project.Order()
+		assert.NotNil(t, err, "circular dependency")
+	}
+}
+
+func TestProject_ContainerSpec(t *testing.T) {
+	project := loadTestProject(t, nil)
+	if project == nil {
+		return
+	}
+	spec, err := project.ContainerSpec(project.Services["db"])
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "mysql:5.7", spec.Config.Image)
+	assert.EqualValues(t, []string{"MYSQL_DATABASE=app", "MYSQL_ROOT_PASSWORD=dev"}, spec.Config.Env)
+	assert.EqualValues(t, map[string]string{ProjectLabel: "app", ServiceLabel: "db", ContainerNumberLabel: "1"}, spec.Config.Labels)
+	assert.EqualValues(t, []string{"CMD-SHELL", "mysqladmin ping -h 127.0.0.1"}, spec.Config.Healthcheck.Test)
+	assert.EqualValues(t, 10, spec.Config.Healthcheck.Retries)
+	assert.EqualValues(t, []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "3306"}}, spec.HostConfig.PortBindings["3306/tcp"])
+	if assert.EqualValues(t, 2, len(spec.HostConfig.Mounts)) {
+		assert.EqualValues(t, "app_db-data", spec.HostConfig.Mounts[0].Source)
+		assert.EqualValues(t, path.Join(project.BaseDirectory, "config/my.cnf"), spec.HostConfig.Mounts[1].Source)
+		assert.True(t, spec.HostConfig.Mounts[1].ReadOnly)
+	}
+	assert.EqualValues(t, "app_backend", spec.HostConfig.NetworkMode)
+	assert.EqualValues(t, []string{"db", "mysql"}, spec.NetworkingConfig.EndpointsConfig["app_backend"].Aliases)
+
+	spec, err = project.ContainerSpec(project.Services["app"])
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "on-failure", spec.HostConfig.RestartPolicy.Name)
+	assert.EqualValues(t, 3, spec.HostConfig.RestartPolicy.MaximumRetryCount)
+	_, exposed := spec.Config.ExposedPorts["6060/tcp"]
+	assert.True(t, exposed)
+	assert.EqualValues(t, "19090", spec.HostConfig.PortBindings["9090/udp"][0].HostPort)
+	assert.EqualValues(t, "app_backend", spec.HostConfig.NetworkMode)
+	assert.EqualValues(t, []string{"app"}, spec.Networks["app_default"].Aliases, "second network is connected after create")
+}
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{"A": "1", "EMPTY": ""}
+	assert.EqualValues(t, "1-1-x-y--$A", interpolate("${A}-$A-${B:-x}-${EMPTY:-y}-${EMPTY-z}-$$A", env))
+}
+
+func TestImageReference(t *testing.T) {
+	assert.EqualValues(t, "redis:latest", imageReference("redis"))
+	assert.EqualValues(t, "localhost:5000/app:latest", imageReference("localhost:5000/app"))
+	assert.EqualValues(t, "mysql:5.7", imageReference("mysql:5.7"))
+}
+
+func TestNewProjectWithLoader(t *testing.T) {
+	var files = map[string]string{
+		"scp://host:22/app/.env":       "DB_PASSWORD=secret\n",
+		"scp://host:22/app/db.env":     "MYSQL_USER=app\nMYSQL_DATABASE=test\n",
+		"scp://host:22/etc/common.env": "TZ=UTC\n",
+	}
+	loader := func(URL string) ([]byte, error) {
+		content, ok := files[URL]
+		if !ok {
+			return nil, fmt.Errorf("not found: %v", URL)
+		}
+		return []byte(content), nil
+	}
+	content := "services:\n  db:\n    image: mysql\n    env_file: [db.env, /etc/common.env]\n    environment:\n      MYSQL_PASSWORD: ${DB_PASSWORD}\n      MYSQL_DATABASE: app\n"
+	project, err := NewProjectWithLoader("", "scp://host:22/app", content, nil, loader)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "app", project.Name)
+	assert.EqualValues(t, "/app", project.BaseDirectory)
+	assert.False(t, project.IsLocal())
+	spec, err := project.ContainerSpec(project.Services["db"])
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, []string{"MYSQL_DATABASE=app", "MYSQL_PASSWORD=secret", "MYSQL_USER=app", "TZ=UTC"}, spec.Config.Env)
+	}
+	delete(files, "scp://host:22/app/db.env")
+	_, err = project.ContainerSpec(project.Services["db"])
+	assert.NotNil(t, err, "missing env_file")
+}
@@ -0,0 +1,658 @@
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/docker"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	//ServiceID represents docker compose service id
+	ServiceID = "docker/compose"
+)
+
+//service represents docker compose service, it drives docker API client directly without docker-compose cli
+type service struct {
+	*endly.AbstractService
+}
+
+func (s *service) up(context *endly.Context, request *UpRequest) (*UpResponse, error) {
+	project, err := request.Load(context)
+	if err != nil {
+		return nil, err
+	}
+	services, err := project.Order(request.Services...)
+	if err != nil {
+		return nil, err
+	}
+	client, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	response := &UpResponse{Project: project.Name}
+	downRequest := &DownRequest{ProjectRequest: ProjectRequest{ProjectName: project.Name}, RemoveVolumes: true}
+	if err = context.Ledger().Register(ledgerProject, project.Name, ServiceID, "down", downRequest); err != nil {
+		return nil, err
+	}
+	if response.Networks, err = s.createNetworks(client, project, services); err != nil {
+		return nil, err
+	}
+	if response.Volumes, err = s.createVolumes(client, project, services); err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(request.TimeoutMs) * time.Millisecond
+	for _, service := range services {
+		if err = s.waitForDependencies(client, project, service, timeout); err != nil {
+			return nil, err
+		}
+		if err = s.ensureImage(context, project, service, request.Credentials); err != nil {
+			return nil, err
+		}
+		if err = s.startContainer(client, project, service, request.Reuse); err != nil {
+			return nil, err
+		}
+	}
+	var names = serviceNames(services)
+	if request.Wait {
+		if _, err = s.waitFor(client, project.Name, names, ConditionHealthy, timeout, defaultSleepMs*time.Millisecond); err != nil {
+			return nil, err
+		}
+	}
+	response.Services, err = s.serviceStatuses(client, project.Name, names)
+	return response, err
+}
+
+func (s *service) createNetworks(client *docker.CtxClient, project *Project, services []*Service) ([]string, error) {
+	var result = make([]string, 0)
+	var created = make(map[string]bool)
+	for _, service := range services {
+		for key := range service.Networks {
+			name := project.NetworkName(key)
+			if created[name] {
+				continue
+			}
+			created[name] = true
+			result = append(result, name)
+			network := project.Networks[key]
+			existing, err := client.Client.NetworkList(client.Context, types.NetworkListOptions{Filters: filters.NewArgs(filters.Arg("name", name))})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list networks: %v", err)
+			}
+			if hasNetwork(existing, name) {
+				continue
+			}
+			if network.External.External {
+				return nil, fmt.Errorf("external network %v not found", name)
+			}
+			labels := map[string]string{ProjectLabel: project.Name, NetworkLabel: key}
+			for k, v := range network.Labels {
+				labels[k] = v
+			}
+			if _, err = client.Client.NetworkCreate(client.Context, name, types.NetworkCreate{
+				CheckDuplicate: true,
+				Driver:         network.Driver,
+				Options:        network.DriverOpts,
+				Internal:       network.Internal,
+				Labels:         labels,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to create network %v, %v", name, err)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func hasNetwork(networks []types.NetworkResource, name string) bool {
+	for _, network := range networks {
+		if network.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) createVolumes(client *docker.CtxClient, project *Project, services []*Service) ([]string, error) {
+	var result = make([]string, 0)
+	var created = make(map[string]bool)
+	for _, service := range services {
+		for _, mount := range service.Volumes {
+			if mount.Type != MountTypeVolume || mount.Source == "" {
+				continue
+			}
+			name := project.VolumeName(mount.Source)
+			if created[name] {
+				continue
+			}
+			created[name] = true
+			result = append(result, name)
+			aVolume := project.Volumes[mount.Source]
+			if aVolume.External.External {
+				if _, err := client.Client.VolumeInspect(client.Context, name); err != nil {
+					return nil, fmt.Errorf("external volume %v not found, %v", name, err)
+				}
+				continue
+			}
+			labels := map[string]string{ProjectLabel: project.Name, VolumeLabel: mount.Source}
+			for k, v := range aVolume.Labels {
+				labels[k] = v
+			}
+			if _, err := client.Client.VolumeCreate(client.Context, volume.VolumeCreateBody{
+				Name:       name,
+				Driver:     aVolume.Driver,
+				DriverOpts: aVolume.DriverOpts,
+				Labels:     labels,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to create volume %v, %v", name, err)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (s *service) waitForDependencies(client *docker.CtxClient, project *Project, service *Service, timeout time.Duration) error {
+	var dependencies = make([]string, 0)
+	for dependency := range service.DependsOn {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Strings(dependencies)
+	for _, dependency := range dependencies {
+		condition := service.DependsOn[dependency].Condition
+		if condition == ConditionStarted {
+			continue
+		}
+		if _, err := s.waitFor(client, project.Name, []string{dependency}, condition, timeout, defaultSleepMs*time.Millisecond); err != nil {
+			return fmt.Errorf("service %v dependency failed: %v", service.Name, err)
+		}
+	}
+	return nil
+}
+
+//ensureImage builds service image if build is defined, otherwise pulls image if it is not present
+func (s *service) ensureImage(context *endly.Context, project *Project, service *Service, credentials string) error {
+	if service.Build != nil {
+		if !project.IsLocal() {
+			return fmt.Errorf("failed to build %v image: build context is only supported for local compose file, but had: %v", service.Name, project.BaseURL)
+		}
+		buildRequest := &docker.BuildRequest{
+			Tag:  docker.NewTag(service.Image),
+			Path: project.path(service.Build.Context),
+		}
+		buildRequest.Dockerfile = service.Build.Dockerfile
+		if len(service.Build.Args) > 0 {
+			buildRequest.BuildArgs = make(map[string]*string)
+			for key := range service.Build.Args {
+				value := service.Build.Args[key]
+				buildRequest.BuildArgs[key] = &value
+			}
+		}
+		if err := buildRequest.Init(); err != nil {
+			return err
+		}
+		if err := endly.Run(context, buildRequest, nil); err != nil {
+			return fmt.Errorf("failed to build %v image: %v", service.Name, err)
+		}
+		return nil
+	}
+	if err := endly.Run(context, &docker.PullRequest{Image: imageReference(service.Image), Credentials: credentials}, nil); err != nil {
+		return fmt.Errorf("failed to pull %v image: %v", service.Name, err)
+	}
+	return nil
+}
+
+//imageReference returns image reference with latest tag if tag or digest was not specified
+func imageReference(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if strings.Contains(name, ":") || strings.Contains(name, "@") {
+		return image
+	}
+	return image + ":latest"
+}
+
+func (s *service) startContainer(client *docker.CtxClient, project *Project, service *Service, reuse bool) error {
+	spec, err := project.ContainerSpec(service)
+	if err != nil {
+		return err
+	}
+	containers, err := client.Client.ContainerList(client.Context, types.ContainerListOptions{All: true, Filters: filters.NewArgs(filters.Arg("name", spec.Name))})
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %v", err)
+	}
+	for _, candidate := range containers {
+		if !hasName(candidate.Names, spec.Name) {
+			continue
+		}
+		if reuse && candidate.Labels[ProjectLabel] == project.Name {
+			if candidate.State == "running" {
+				return nil
+			}
+			return client.Client.ContainerStart(client.Context, candidate.ID, types.ContainerStartOptions{})
+		}
+		if owner := candidate.Labels[ProjectLabel]; owner != project.Name {
+			return fmt.Errorf("container name conflict: %v is used by %v, remove it or change container_name", spec.Name, containerOwner(owner))
+		}
+		if err = client.Client.ContainerRemove(client.Context, candidate.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("failed to remove %v container: %v", spec.Name, err)
+		}
+	}
+	created, err := client.Client.ContainerCreate(client.Context, spec.Config, spec.HostConfig, spec.NetworkingConfig, spec.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create %v container: %v", spec.Name, err)
+	}
+	var networks = make([]string, 0)
+	for name := range spec.Networks {
+		networks = append(networks, name)
+	}
+	sort.Strings(networks)
+	for _, name := range networks {
+		if err = client.Client.NetworkConnect(client.Context, name, created.ID, spec.Networks[name]); err != nil {
+			return fmt.Errorf("failed to connect %v container to %v network: %v", spec.Name, name, err)
+		}
+	}
+	if err = client.Client.ContainerStart(client.Context, created.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("failed to start %v container: %v", spec.Name, err)
+	}
+	return nil
+}
+
+func hasName(names []string, name string) bool {
+	for _, candidate := range names {
+		if strings.TrimPrefix(candidate, "/") == name {
+			return true
+		}
+	}
+	return false
+}
+
+//containerOwner returns description of project owning conflicting container
+func containerOwner(project string) string {
+	if project == "" {
+		return "container outside of compose project"
+	}
+	return project + " project"
+}
+
+//projectContainers returns project containers filtered by services
+func (s *service) projectContainers(client *docker.CtxClient, project string, services []string) ([]types.Container, error) {
+	containers, err := client.Client.ContainerList(client.Context, types.ContainerListOptions{All: true, Filters: filters.NewArgs(filters.Arg("label", ProjectLabel+"="+project))})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %v containers: %v", project, err)
+	}
+	if len(services) == 0 {
+		return containers, nil
+	}
+	var result = make([]types.Container, 0)
+	for _, candidate := range containers {
+		for _, service := range services {
+			if candidate.Labels[ServiceLabel] == service {
+				result = append(result, candidate)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *service) serviceStatuses(client *docker.CtxClient, project string, services []string) ([]*ServiceStatus, error) {
+	containers, err := s.projectContainers(client, project, services)
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]*ServiceStatus, 0)
+	for _, candidate := range containers {
+		status := &ServiceStatus{
+			Service:     candidate.Labels[ServiceLabel],
+			ContainerID: candidate.ID,
+			Image:       candidate.Image,
+			State:       candidate.State,
+		}
+		if len(candidate.Names) > 0 {
+			status.Container = strings.TrimPrefix(candidate.Names[0], "/")
+		}
+		for _, port := range candidate.Ports {
+			if port.PublicPort == 0 {
+				status.Ports = append(status.Ports, fmt.Sprintf("%v/%v", port.PrivatePort, port.Type))
+				continue
+			}
+			status.Ports = append(status.Ports, fmt.Sprintf("%v:%v->%v/%v", port.IP, port.PublicPort, port.PrivatePort, port.Type))
+		}
+		info, err := client.Client.ContainerInspect(client.Context, candidate.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect %v container: %v", status.Container, err)
+		}
+		if info.State != nil {
+			status.State = info.State.Status
+			status.ExitCode = info.State.ExitCode
+			if info.State.Health != nil {
+				status.Health = info.State.Health.Status
+			}
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Service < result[j].Service
+	})
+	return result, nil
+}
+
+//waitFor waits till all services satisfy condition
+func (s *service) waitFor(client *docker.CtxClient, project string, services []string, condition string, timeout, sleep time.Duration) ([]*ServiceStatus, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		statuses, err := s.serviceStatuses(client, project, services)
+		if err != nil {
+			return nil, err
+		}
+		var pending = make(map[string]bool)
+		for _, service := range services {
+			pending[service] = true
+		}
+		for _, status := range statuses {
+			satisfied, err := status.Satisfies(condition)
+			if err != nil {
+				return statuses, err
+			}
+			if satisfied {
+				delete(pending, status.Service)
+			}
+		}
+		if len(pending) == 0 {
+			return statuses, nil
+		}
+		if time.Now().After(deadline) {
+			var names = make([]string, 0)
+			for name := range pending {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return statuses, fmt.Errorf("timed out after %v waiting for %v: %v", timeout, condition, strings.Join(names, ","))
+		}
+		time.Sleep(sleep)
+	}
+}
+
+func (s *service) servicesOrAll(context *endly.Context, request *ProjectRequest, services []string) ([]string, error) {
+	if len(services) > 0 {
+		return services, nil
+	}
+	if request.Source != nil {
+		project, err := request.Load(context)
+		if err != nil {
+			return nil, err
+		}
+		ordered, err := project.Order()
+		if err != nil {
+			return nil, err
+		}
+		return serviceNames(ordered), nil
+	}
+	client, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := s.projectContainers(client, request.ProjectName, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]string, 0)
+	for _, candidate := range containers {
+		result = append(result, candidate.Labels[ServiceLabel])
+	}
+	return result, nil
+}
+
+func (s *service) wait(context *endly.Context, request *WaitRequest) (*WaitResponse, error) {
+	services, err := s.servicesOrAll(context, &request.ProjectRequest, request.Services)
+	if err != nil {
+		return nil, err
+	}
+	client, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	startTime := time.Now()
+	response := &WaitResponse{}
+	response.Services, err = s.waitFor(client, request.ProjectName, services, request.Condition, time.Duration(request.TimeoutMs)*time.Millisecond, time.Duration(request.SleepMs)*time.Millisecond)
+	response.WaitTimeMs = int(time.Since(startTime) / time.Millisecond)
+	return response, err
+}
+
+func (s *service) ps(context *endly.Context, request *PsRequest) (*PsResponse, error) {
+	client, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	response := &PsResponse{}
+	response.Services, err = s.serviceStatuses(client, request.ProjectName, request.Services)
+	return response, err
+}
+
+func (s *service) logs(context *endly.Context, request *LogsRequest) (*LogsResponse, error) {
+	client, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := s.projectContainers(client, request.ProjectName, request.Services)
+	if err != nil {
+		return nil, err
+	}
+	response := &LogsResponse{Logs: make(map[string]string)}
+	for _, candidate := range containers {
+		reader, err := client.Client.ContainerLogs(client.Context, candidate.ID, types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       request.Tail,
+			Since:      request.Since,
+			Timestamps: request.Timestamps,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v logs: %v", candidate.Labels[ServiceLabel], err)
+		}
+		output, err := readLogs(client, candidate.ID, reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		response.Logs[candidate.Labels[ServiceLabel]] = output
+	}
+	return response, nil
+}
+
+//readLogs reads container logs, non TTY logs are demultiplexed
+func readLogs(client *docker.CtxClient, containerID string, reader io.Reader) (string, error) {
+	info, err := client.Client.ContainerInspect(client.Context, containerID)
+	if err != nil {
+		return "", err
+	}
+	output := new(bytes.Buffer)
+	if info.Config != nil && info.Config.Tty {
+		_, err = io.Copy(output, reader)
+	} else {
+		_, err = stdcopy.StdCopy(output, output, reader)
+	}
+	return output.String(), err
+}
+
+func (s *service) down(context *endly.Context, request *DownRequest) (*DownResponse, error) {
+	client, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	response := &DownResponse{Containers: make([]string, 0), Networks: make([]string, 0), Volumes: make([]string, 0)}
+	containers, err := s.projectContainers(client, request.ProjectName, nil)
+	if err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(request.TimeoutSec) * time.Second
+	for _, candidate := range containers {
+		name := candidate.ID
+		if len(candidate.Names) > 0 {
+			name = strings.TrimPrefix(candidate.Names[0], "/")
+		}
+		if candidate.State == "running" {
+			if err = client.Client.ContainerStop(client.Context, candidate.ID, &timeout); err != nil {
+				return nil, fmt.Errorf("failed to stop %v container: %v", name, err)
+			}
+		}
+		if err = client.Client.ContainerRemove(client.Context, candidate.ID, types.ContainerRemoveOptions{Force: true, RemoveVolumes: request.RemoveVolumes}); err != nil {
+			return nil, fmt.Errorf("failed to remove %v container: %v", name, err)
+		}
+		response.Containers = append(response.Containers, name)
+	}
+	projectFilter := filters.NewArgs(filters.Arg("label", ProjectLabel+"="+request.ProjectName))
+	networks, err := client.Client.NetworkList(client.Context, types.NetworkListOptions{Filters: projectFilter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %v", err)
+	}
+	for _, network := range networks {
+		if err = client.Client.NetworkRemove(client.Context, network.ID); err != nil {
+			return nil, fmt.Errorf("failed to remove %v network: %v", network.Name, err)
+		}
+		response.Networks = append(response.Networks, network.Name)
+	}
+	if !request.RemoveVolumes {
+		return response, context.Ledger().Release(ledgerProject, request.ProjectName)
+	}
+	volumes, err := client.Client.VolumeList(client.Context, projectFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %v", err)
+	}
+	for _, aVolume := range volumes.Volumes {
+		if err = client.Client.VolumeRemove(client.Context, aVolume.Name, true); err != nil {
+			return nil, fmt.Errorf("failed to remove %v volume: %v", aVolume.Name, err)
+		}
+		response.Volumes = append(response.Volumes, aVolume.Name)
+	}
+	return response, context.Ledger().Release(ledgerProject, request.ProjectName)
+}
+
+func serviceNames(services []*Service) []string {
+	var result = make([]string, 0)
+	for _, service := range services {
+		result = append(result, service.Name)
+	}
+	return result
+}
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "up",
+		RequestInfo: &endly.ActionInfo{
+			Description: "create project networks, volumes and start services in depends_on order",
+			Examples: []*endly.UseCase{
+				{
+					Description: "start services and wait till healthy",
+					Data: `{
+  "Source": {"URL": "config/docker-compose.yaml"},
+  "Env": {"MYSQL_VERSION": "5.7"},
+  "Wait": true
+}`,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &UpRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &UpResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*UpRequest); ok {
+				return s.up(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+	s.Register(&endly.Route{
+		Action: "down",
+		RequestInfo: &endly.ActionInfo{
+			Description: "stop and remove project containers, networks and optionally volumes",
+		},
+		RequestProvider: func() interface{} {
+			return &DownRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &DownResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*DownRequest); ok {
+				return s.down(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+	s.Register(&endly.Route{
+		Action: "ps",
+		RequestInfo: &endly.ActionInfo{
+			Description: "list project service containers status",
+		},
+		RequestProvider: func() interface{} {
+			return &PsRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &PsResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*PsRequest); ok {
+				return s.ps(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+	s.Register(&endly.Route{
+		Action: "logs",
+		RequestInfo: &endly.ActionInfo{
+			Description: "return project service container logs",
+		},
+		RequestProvider: func() interface{} {
+			return &LogsRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &LogsResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*LogsRequest); ok {
+				return s.logs(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+	s.Register(&endly.Route{
+		Action: "wait",
+		RequestInfo: &endly.ActionInfo{
+			Description: "wait for project services to be healthy, started or completed",
+		},
+		RequestProvider: func() interface{} {
+			return &WaitRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &WaitResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*WaitRequest); ok {
+				return s.wait(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+//New creates a new docker compose service
+func New() endly.Service {
+	var result = &service{
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,112 @@
+package compose
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/docker/dockertest"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+//TestMain isolates session ledger from user home directory
+func TestMain(m *testing.M) {
+	directory, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		log.Fatal(err)
+	}
+	_ = os.Setenv(endly.LedgerDirectoryEnv, directory)
+	code := m.Run()
+	_ = os.RemoveAll(directory)
+	os.Exit(code)
+}
+
+func TestService_Run(t *testing.T) {
+	daemon := dockertest.New()
+	daemon.Images = []types.ImageSummary{{ID: "1", RepoTags: []string{"mysql:5.7", "app/migrate:latest", "app/server:1.0"}}}
+	daemon.OnStart = func(info *types.ContainerJSON) {
+		if strings.Contains(info.Name, "migrate") {
+			info.State.Status = "exited"
+			info.State.Running = false
+		}
+	}
+	daemon.Logs = func(info *types.ContainerJSON) string {
+		return "started " + info.Name + "\n"
+	}
+	daemon.Start()
+	defer daemon.Close()
+
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	source := url.NewResource(path.Join(toolbox.CallerDirectory(3), "test", "app", "docker-compose.yaml"))
+
+	upResponse := &UpResponse{}
+	err := endly.Run(context, &UpRequest{ProjectRequest: ProjectRequest{Source: source}, Wait: true}, upResponse)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "app", upResponse.Project)
+	assert.EqualValues(t, []string{"app_backend", "app_default"}, upResponse.Networks)
+	assert.EqualValues(t, []string{"app_db-data"}, upResponse.Volumes)
+	if assert.EqualValues(t, 3, len(upResponse.Services)) {
+		assert.EqualValues(t, &ServiceStatus{Service: "db", Container: "app_db_1", ContainerID: "id-app_db_1", Image: "mysql:5.7", State: "running", Health: "healthy"}, upResponse.Services[1])
+	}
+	var created = make([]string, 0)
+	for _, call := range daemon.Calls {
+		if strings.HasPrefix(call, "POST /containers/create") || strings.HasPrefix(call, "POST /networks/app_default/connect") {
+			created = append(created, call)
+		}
+	}
+	assert.EqualValues(t, []string{"POST /containers/create", "POST /containers/create", "POST /containers/create", "POST /networks/app_default/connect"}, created)
+	assert.EqualValues(t, "app_backend", daemon.Containers["id-app"].HostConfig.NetworkMode)
+
+	psResponse := &PsResponse{}
+	err = endly.Run(context, &PsRequest{ProjectRequest: ProjectRequest{ProjectName: "app"}, Services: []string{"migrate"}}, psResponse)
+	if assert.Nil(t, err) && assert.EqualValues(t, 1, len(psResponse.Services)) {
+		assert.EqualValues(t, "exited", psResponse.Services[0].State)
+	}
+
+	waitResponse := &WaitResponse{}
+	err = endly.Run(context, &WaitRequest{ProjectRequest: ProjectRequest{Source: source}, Services: []string{"migrate"}, Condition: ConditionCompleted}, waitResponse)
+	assert.Nil(t, err)
+	err = endly.Run(context, &WaitRequest{ProjectRequest: ProjectRequest{Source: source}, Condition: ConditionHealthy}, waitResponse)
+	assert.Nil(t, err)
+	err = endly.Run(context, &WaitRequest{ProjectRequest: ProjectRequest{Source: source}, Services: []string{"db"}, Condition: ConditionCompleted, TimeoutMs: 100, SleepMs: 10}, waitResponse)
+	assert.NotNil(t, err, "db is still running")
+
+	logsResponse := &LogsResponse{}
+	err = endly.Run(context, &LogsRequest{ProjectRequest: ProjectRequest{ProjectName: "app"}, Services: []string{"db"}}, logsResponse)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, map[string]string{"db": "started /app_db_1\n"}, logsResponse.Logs)
+	}
+
+	err = endly.Run(context, &UpRequest{ProjectRequest: ProjectRequest{Source: source}, Services: []string{"db"}, Reuse: true}, upResponse)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 3, len(daemon.Containers), "existing container is reused")
+	}
+
+	downResponse := &DownResponse{}
+	err = endly.Run(context, &DownRequest{ProjectRequest: ProjectRequest{Source: source}, RemoveVolumes: true}, downResponse)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 3, len(downResponse.Containers))
+		assert.EqualValues(t, 2, len(downResponse.Networks))
+		assert.EqualValues(t, []string{"app_db-data"}, downResponse.Volumes)
+	}
+	assert.EqualValues(t, 0, len(daemon.Containers))
+	assert.EqualValues(t, 0, len(daemon.Networks))
+	assert.EqualValues(t, 0, len(daemon.Volumes))
+
+	other := daemon.AddContainer("other", "app_db_1", &types.ContainerState{Status: "running", Running: true})
+	other.Config.Labels[ProjectLabel] = "other"
+	err = endly.Run(context, &UpRequest{ProjectRequest: ProjectRequest{Source: source}, Services: []string{"db"}}, upResponse)
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "container name conflict"), err.Error())
+	}
+	assert.NotNil(t, daemon.Containers["other"], "container from another project is not removed")
+}
@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/go-connections/nat"
 	"github.com/go-errors/errors"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/exec"
 	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
 	"github.com/viant/toolbox/secret"
 	"github.com/viant/toolbox/url"
 	"strings"
@@ -28,12 +34,16 @@ type RunRequest struct {
 	Entrypoint                  []string
 	types.ContainerCreateConfig `json:",inline" yaml:",inline"`
 	Secrets                     map[secret.SecretKey]secret.Secret `description:"map of secrets used within env"`
+	Network                     string                             `description:"network to connect container to, docker --network option"`
+	Aliases                     []string                           `description:"container network-scoped aliases, docker --network-alias option"`
+	Readiness                   *ReadinessProbe                    `description:"readiness probe, run returns once container is ready, otherwise fails with container logs"`
 }
 
 type RunResponse struct {
 	ContainerID string
 	Status      string
 	Stdout      string
+	ReadyTimeMs int `json:",omitempty" description:"time it took container to satisfy readiness probe"`
 }
 
 //BuildRequest represents docker build request
@@ -182,6 +192,124 @@ type InspectResponse struct {
 	Info []types.ContainerJSON //you can extract any instance default, for instance to get Ip you can use Info[0].NetworkSettings.IPAddress in the variable action post from key
 }
 
+//CreateNetworkRequest represents a docker network create request, existing network with the same name is reused
+type CreateNetworkRequest struct {
+	Name                string `required:"true" description:"network name"`
+	types.NetworkCreate `json:",inline" yaml:",inline"`
+}
+
+//CreateNetworkResponse represents a docker network create response
+type CreateNetworkResponse struct {
+	NetworkID string
+	Name      string
+	Existing  bool `description:"true if network already existed"`
+}
+
+//RemoveNetworkRequest represents a docker network remove request, missing networks are ignored
+type RemoveNetworkRequest struct {
+	Name      string
+	Names     []string
+	NetworkID string `description:"network ID, kept for compatibility with client networkRemove contract"`
+	Force     bool   `description:"disconnect attached containers before removing network"`
+}
+
+//RemoveNetworkResponse represents a docker network remove response
+type RemoveNetworkResponse struct {
+	Removed []string
+}
+
+//ConnectNetworkRequest represents a docker network connect request
+type ConnectNetworkRequest struct {
+	Network     string                    `required:"true" description:"network name or ID"`
+	Container   string                    `required:"true" description:"container name or ID"`
+	Aliases     []string                  `description:"container network-scoped aliases"`
+	IPAddress   string                    `description:"container IPv4 address"`
+	NetworkID   string                    `description:"network ID, kept for compatibility with client networkConnect contract"`
+	ContainerID string                    `description:"container ID, kept for compatibility with client networkConnect contract"`
+	Config      *network.EndpointSettings `description:"endpoint settings, aliases and IP address are merged into it"`
+}
+
+//ConnectNetworkResponse represents a docker network connect response
+type ConnectNetworkResponse struct {
+	Connected bool `description:"false if container was already connected"`
+}
+
+//CreateVolumeRequest represents a docker volume create request, existing volume with the same name is reused
+type CreateVolumeRequest struct {
+	volumetypes.VolumeCreateBody `json:",inline" yaml:",inline"`
+}
+
+//CreateVolumeResponse represents a docker volume create response
+type CreateVolumeResponse struct {
+	Name       string
+	Mountpoint string
+	Existing   bool `description:"true if volume already existed"`
+}
+
+//RemoveVolumeRequest represents a docker volume remove request, missing volumes are ignored
+type RemoveVolumeRequest struct {
+	Name     string
+	Names    []string
+	VolumeID string `description:"volume name, kept for compatibility with client volumeRemove contract"`
+	Force    bool
+}
+
+//RemoveVolumeResponse represents a docker volume remove response
+type RemoveVolumeResponse struct {
+	Removed []string
+}
+
+//PruneRequest represents a docker prune request, if no resource type is selected, stopped containers, unused networks and dangling images are pruned
+type PruneRequest struct {
+	Containers bool
+	Networks   bool
+	Volumes    bool
+	Images     bool
+	Labels     map[string]string `description:"prune only resources with matching labels"`
+}
+
+//PruneResponse represents a docker prune response
+type PruneResponse struct {
+	Containers     []string `json:",omitempty"`
+	Networks       []string `json:",omitempty"`
+	Volumes        []string `json:",omitempty"`
+	Images         []string `json:",omitempty"`
+	SpaceReclaimed uint64
+}
+
+//ExecRequest represents a docker exec request, commands run within running container via docker API
+type ExecRequest struct {
+	Name       string                 `required:"true" description:"container name or ID"`
+	Command    string                 `description:"command to run, shortcut for single Commands element"`
+	Commands   []*exec.ExtractCommand `description:"commands with data extraction instruction"`
+	Shell      []string               `description:"shell used to run each command, default: /bin/sh -c"`
+	Stdin      string                 `description:"standard input passed to each command"`
+	Env        map[string]string      `description:"environment variables"`
+	User       string                 `description:"user that will run commands, docker exec -u option"`
+	Workdir    string                 `description:"working directory, docker exec -w option"`
+	Privileged bool
+	Tty        bool           `description:"allocate pseudo-TTY, stderr is merged with stdout"`
+	CheckError bool           `description:"throws error if command exit code is not 0"`
+	Secrets    secret.Secrets `description:"secrets map see https://github.com/viant/toolbox/tree/master/secret"`
+}
+
+//ExecResponse represents a docker exec response
+type ExecResponse struct {
+	Cmd      []*ExecLog
+	Output   string
+	Data     data.Map
+	ExitCode int `description:"last command exit code"`
+}
+
+//ExecLog represents command execution log
+type ExecLog struct {
+	Stdin    string
+	Stdout   string
+	Stderr   string `json:",omitempty"`
+	ExitCode int
+	Error    string `json:",omitempty"`
+}
+
 func (r *CopyRequest) Validate() error {
 	if len(r.Assets) == 0 {
 		return fmt.Errorf("asset was empty")
@@ -255,6 +383,19 @@ func (r *RunRequest) Init() error {
 	if r.Name != "" {
 		r.ContainerCreateConfig.Name = r.Name
 	}
+	if r.Network != "" {
+		r.HostConfig.NetworkMode = container.NetworkMode(r.Network)
+		if r.NetworkingConfig == nil {
+			r.NetworkingConfig = &network.NetworkingConfig{}
+		}
+		if r.NetworkingConfig.EndpointsConfig == nil {
+			r.NetworkingConfig.EndpointsConfig = make(map[string]*network.EndpointSettings)
+		}
+		r.NetworkingConfig.EndpointsConfig[r.Network] = &network.EndpointSettings{Aliases: r.Aliases}
+	}
+	if r.Readiness != nil {
+		return r.Readiness.Init()
+	}
 	return nil
 }
 
@@ -262,6 +403,12 @@ func (r *RunRequest) Validate() error {
 	if r.Config.Image == "" {
 		return errors.New("image was empty")
 	}
+	if len(r.Aliases) > 0 && r.Network == "" {
+		return errors.New("network was empty for aliases")
+	}
+	if r.Readiness != nil {
+		return r.Readiness.Validate()
+	}
 	return nil
 }
 
@@ -334,3 +481,149 @@ func (r *LogsRequest) Init() error {
 	}
 	return nil
 }
+
+func (r *CreateNetworkRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name was empty")
+	}
+	return nil
+}
+
+func (r *RemoveNetworkRequest) Init() error {
+	if r.Name != "" && len(r.Names) == 0 {
+		r.Names = strings.Split(r.Name, ",")
+	}
+	if r.NetworkID != "" && !toolbox.HasSliceAnyElements(r.Names, r.NetworkID) {
+		r.Names = append(r.Names, r.NetworkID)
+	}
+	return nil
+}
+
+func (r *RemoveNetworkRequest) Validate() error {
+	if len(r.Names) == 0 {
+		return errors.New("name was empty")
+	}
+	return nil
+}
+
+func (r *ConnectNetworkRequest) Init() error {
+	if r.Network == "" {
+		r.Network = r.NetworkID
+	}
+	if r.Container == "" {
+		r.Container = r.ContainerID
+	}
+	return nil
+}
+
+func (r *ConnectNetworkRequest) Validate() error {
+	if r.Network == "" {
+		return errors.New("network was empty")
+	}
+	if r.Container == "" {
+		return errors.New("container was empty")
+	}
+	return nil
+}
+
+func (r *CreateVolumeRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name was empty")
+	}
+	return nil
+}
+
+func (r *RemoveVolumeRequest) Init() error {
+	if r.Name != "" && len(r.Names) == 0 {
+		r.Names = strings.Split(r.Name, ",")
+	}
+	if r.VolumeID != "" && !toolbox.HasSliceAnyElements(r.Names, r.VolumeID) {
+		r.Names = append(r.Names, r.VolumeID)
+	}
+	return nil
+}
+
+func (r *RemoveVolumeRequest) Validate() error {
+	if len(r.Names) == 0 {
+		return errors.New("name was empty")
+	}
+	return nil
+}
+
+func (r *PruneRequest) Init() error {
+	if !r.Containers && !r.Networks && !r.Volumes && !r.Images {
+		r.Containers, r.Networks, r.Images = true, true, true
+	}
+	return nil
+}
+
+//Filters returns prune filters
+func (r *PruneRequest) Filters() filters.Args {
+	result := filters.NewArgs()
+	for key, value := range r.Labels {
+		result.Add("label", fmt.Sprintf("%v=%v", key, value))
+	}
+	return result
+}
+
+func (r *ExecRequest) Init() error {
+	if r.Command != "" && len(r.Commands) == 0 {
+		r.Commands = []*exec.ExtractCommand{{Command: r.Command}}
+	}
+	for _, command := range r.Commands {
+		if err := command.Init(); err != nil {
+			return err
+		}
+	}
+	if len(r.Shell) == 0 {
+		r.Shell = []string{"/bin/sh", "-c"}
+	}
+	return nil
+}
+
+func (r *ExecRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name was empty")
+	}
+	if len(r.Commands) == 0 {
+		return errors.New("commands were empty")
+	}
+	return nil
+}
+
+//ExecConfig returns exec config
+func (r *ExecRequest) ExecConfig() types.ExecConfig {
+	result := types.ExecConfig{
+		User:       r.User,
+		WorkingDir: r.Workdir,
+		Privileged: r.Privileged,
+		Tty:        r.Tty,
+	}
+	for key, value := range r.Env {
+		result.Env = append(result.Env, fmt.Sprintf("%v=%v", key, value))
+	}
+	return result
+}
+
+//state returns execution state with previous commands output
+func (r *ExecResponse) state(context *endly.Context) data.Map {
+	var state = context.State()
+	var result = state.Clone()
+	var commands = data.NewCollection()
+	for _, log := range r.Cmd {
+		var cmd = data.NewMap()
+		cmd.Put("stdin", log.Stdin)
+		cmd.Put("stdout", log.Stdout)
+		cmd.Put("stderr", log.Stderr)
+		cmd.Put("exitCode", log.ExitCode)
+		commands.Push(cmd)
+	}
+	result.Put("cmd", commands)
+	result.Put("output", r.Output)
+	var stdout = ""
+	if len(r.Cmd) > 0 {
+		stdout = r.Cmd[len(r.Cmd)-1].Stdout
+	}
+	result.Put("stdout", stdout)
+	return result
+}
@@ -0,0 +1,472 @@
+package dockertest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+//dockerHostEnv represents docker client host environment variable
+const dockerHostEnv = "DOCKER_HOST"
+
+var apiVersionExpr = regexp.MustCompile(`^/v[0-9.]+`)
+
+//Exec represents container exec instance
+type Exec struct {
+	ID          string
+	ContainerID string
+	Config      types.ExecConfig
+	Stdin       string
+	ExitCode    int
+}
+
+//Daemon represents in memory docker engine API for tests, it keeps containers, networks, volumes and exec instances state,
+//test specific behaviour is supplied with hooks and canned responses
+type Daemon struct {
+	Containers map[string]*types.ContainerJSON
+	Networks   map[string]*types.NetworkResource
+	Volumes    map[string]*types.Volume
+	Images     []types.ImageSummary
+	Execs      []*Exec
+	//Calls represents received calls as "METHOD /uri" without API version
+	Calls []string
+	//Responses represents canned JSON responses keyed by "METHOD /uri", they take precedence over in memory state
+	Responses map[string]interface{}
+	//OnStart is called after container was started
+	OnStart func(container *types.ContainerJSON)
+	//OnInspect is called before container inspect response is sent
+	OnInspect func(container *types.ContainerJSON)
+	//Logs returns container logs
+	Logs func(container *types.ContainerJSON) string
+	//Exec returns exec stdout, stderr and exit code, by default exec succeeds with no output
+	Exec       func(exec *Exec) (stdout, stderr string, exitCode int)
+	server     *httptest.Server
+	dockerHost string
+	mux        sync.Mutex
+}
+
+//AddContainer adds a container with supplied state
+func (d *Daemon) AddContainer(ID, name string, state *types.ContainerState) *types.ContainerJSON {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	info := &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: ID, Name: "/" + name, State: state, HostConfig: &container.HostConfig{}},
+		Config:            &container.Config{Labels: map[string]string{}},
+		NetworkSettings:   &types.NetworkSettings{},
+	}
+	d.Containers[ID] = info
+	return info
+}
+
+//AddNetwork adds a network with connected containers
+func (d *Daemon) AddNetwork(ID, name string, containerIDs ...string) *types.NetworkResource {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	resource := &types.NetworkResource{ID: ID, Name: name, Containers: map[string]types.EndpointResource{}}
+	for _, containerID := range containerIDs {
+		resource.Containers[containerID] = types.EndpointResource{}
+	}
+	d.Networks[ID] = resource
+	return resource
+}
+
+//AddVolume adds a volume
+func (d *Daemon) AddVolume(name string) *types.Volume {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.addVolume(name, nil)
+}
+
+func (d *Daemon) addVolume(name string, labels map[string]string) *types.Volume {
+	aVolume := &types.Volume{Name: name, Labels: labels, Mountpoint: "/var/lib/docker/volumes/" + name}
+	d.Volumes[name] = aVolume
+	return aVolume
+}
+
+//Start starts daemon HTTP server and points DOCKER_HOST to it
+func (d *Daemon) Start() {
+	d.server = httptest.NewServer(d)
+	d.dockerHost = os.Getenv(dockerHostEnv)
+	_ = os.Setenv(dockerHostEnv, d.Host())
+}
+
+//Host returns daemon docker host
+func (d *Daemon) Host() string {
+	return strings.Replace(d.server.URL, "http://", "tcp://", 1)
+}
+
+//Close stops daemon HTTP server and restores DOCKER_HOST
+func (d *Daemon) Close() {
+	d.server.Close()
+	_ = os.Setenv(dockerHostEnv, d.dockerHost)
+}
+
+func (d *Daemon) container(idOrName string) *types.ContainerJSON {
+	if info, ok := d.Containers[idOrName]; ok {
+		return info
+	}
+	for _, info := range d.Containers {
+		if strings.TrimPrefix(info.Name, "/") == idOrName {
+			return info
+		}
+	}
+	return nil
+}
+
+func (d *Daemon) network(idOrName string) *types.NetworkResource {
+	if resource, ok := d.Networks[idOrName]; ok {
+		return resource
+	}
+	for _, resource := range d.Networks {
+		if resource.Name == idOrName {
+			return resource
+		}
+	}
+	return nil
+}
+
+func (d *Daemon) exec(ID string) *Exec {
+	for _, candidate := range d.Execs {
+		if candidate.ID == ID {
+			return candidate
+		}
+	}
+	return nil
+}
+
+//matches checks name and label filters, name filter matches substring as docker does
+func matches(args filters.Args, name string, labels map[string]string) bool {
+	for _, value := range args.Get("name") {
+		if !strings.Contains(name, value) {
+			return false
+		}
+	}
+	for _, value := range args.Get("label") {
+		pair := strings.SplitN(value, "=", 2)
+		actual, ok := labels[pair[0]]
+		if !ok || (len(pair) == 2 && actual != pair[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+//frame returns docker multiplexed stream frame, stream 1 is stdout, 2 is stderr
+func frame(stream byte, text string) []byte {
+	header := []byte{stream, 0, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(header[4:], uint32(len(text)))
+	return append(header, text...)
+}
+
+func notFound(writer http.ResponseWriter, format string, args ...interface{}) {
+	writeError(writer, http.StatusNotFound, format, args...)
+}
+
+func writeError(writer http.ResponseWriter, status int, format string, args ...interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(map[string]string{"message": fmt.Sprintf(format, args...)})
+}
+
+func (d *Daemon) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	URI := apiVersionExpr.ReplaceAllString(request.URL.Path, "")
+	call := request.Method + " " + URI
+	parts := strings.Split(strings.Trim(URI, "/"), "/")
+	d.mux.Lock()
+	d.Calls = append(d.Calls, call)
+	response, ok := d.Responses[call]
+	d.mux.Unlock()
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(response)
+		return
+	}
+	if len(parts) == 3 && parts[0] == "exec" && parts[2] == "start" {
+		d.startExec(writer, request, parts[1])
+		return
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	var status int
+	switch parts[0] {
+	case "containers":
+		response, status = d.serveContainers(writer, request, parts)
+	case "exec":
+		response, status = d.serveExec(writer, parts)
+	case "networks":
+		response, status = d.serveNetworks(writer, request, parts)
+	case "volumes":
+		response, status = d.serveVolumes(writer, request, parts)
+	case "images":
+		response, status = d.Images, http.StatusOK
+	default:
+		notFound(writer, "unsupported: %v", call)
+		return
+	}
+	switch status {
+	case 0:
+		return
+	case http.StatusNoContent:
+		writer.WriteHeader(status)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(response)
+}
+
+//serveContainers handles containers API, zero status means that response was already written
+func (d *Daemon) serveContainers(writer http.ResponseWriter, request *http.Request, parts []string) (interface{}, int) {
+	if len(parts) == 2 && parts[1] == "json" {
+		args, _ := filters.FromJSON(request.URL.Query().Get("filters"))
+		var result = make([]types.Container, 0)
+		for _, info := range d.Containers {
+			if matches(args, strings.TrimPrefix(info.Name, "/"), info.Config.Labels) {
+				result = append(result, types.Container{ID: info.ID, Names: []string{info.Name}, Image: info.Config.Image, State: info.State.Status, Labels: info.Config.Labels})
+			}
+		}
+		return result, http.StatusOK
+	}
+	if len(parts) == 2 && parts[1] == "create" {
+		config := &struct {
+			*container.Config
+			HostConfig       *container.HostConfig
+			NetworkingConfig *network.NetworkingConfig
+		}{}
+		_ = json.NewDecoder(request.Body).Decode(config)
+		name := request.URL.Query().Get("name")
+		if d.container(name) != nil {
+			writeError(writer, http.StatusConflict, "container name %v is already in use", name)
+			return nil, 0
+		}
+		info := &types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: "id-" + name, Name: "/" + name, State: &types.ContainerState{Status: "created"}, HostConfig: config.HostConfig}, Config: config.Config, NetworkSettings: &types.NetworkSettings{}}
+		d.Containers[info.ID] = info
+		return container.ContainerCreateCreatedBody{ID: info.ID}, http.StatusCreated
+	}
+	if len(parts) < 2 {
+		notFound(writer, "unsupported: %v %v", request.Method, request.URL.Path)
+		return nil, 0
+	}
+	info := d.container(parts[1])
+	if info == nil {
+		notFound(writer, "No such container: %v", parts[1])
+		return nil, 0
+	}
+	if len(parts) == 2 && request.Method == "DELETE" {
+		delete(d.Containers, info.ID)
+		return nil, http.StatusNoContent
+	}
+	if len(parts) != 3 {
+		notFound(writer, "unsupported: %v %v", request.Method, request.URL.Path)
+		return nil, 0
+	}
+	switch parts[2] {
+	case "json":
+		if d.OnInspect != nil {
+			d.OnInspect(info)
+		}
+		return info, http.StatusOK
+	case "start":
+		info.State.Status = "running"
+		info.State.Running = true
+		if info.Config != nil && info.Config.Healthcheck != nil {
+			info.State.Health = &types.Health{Status: "healthy"}
+		}
+		if d.OnStart != nil {
+			d.OnStart(info)
+		}
+		return nil, http.StatusNoContent
+	case "stop":
+		info.State.Status = "exited"
+		info.State.Running = false
+		return nil, http.StatusNoContent
+	case "logs":
+		if d.Logs != nil {
+			_, _ = writer.Write(frame(1, d.Logs(info)))
+		}
+		return nil, 0
+	case "exec":
+		anExec := &Exec{ID: fmt.Sprintf("exec%d", len(d.Execs)+1), ContainerID: info.ID}
+		_ = json.NewDecoder(request.Body).Decode(&anExec.Config)
+		d.Execs = append(d.Execs, anExec)
+		return types.IDResponse{ID: anExec.ID}, http.StatusCreated
+	}
+	notFound(writer, "unsupported: %v %v", request.Method, request.URL.Path)
+	return nil, 0
+}
+
+//serveExec handles exec inspect API
+func (d *Daemon) serveExec(writer http.ResponseWriter, parts []string) (interface{}, int) {
+	if len(parts) != 3 || parts[2] != "json" {
+		notFound(writer, "unsupported exec call: %v", strings.Join(parts, "/"))
+		return nil, 0
+	}
+	anExec := d.exec(parts[1])
+	if anExec == nil {
+		notFound(writer, "No such exec instance: %v", parts[1])
+		return nil, 0
+	}
+	return types.ContainerExecInspect{ExecID: anExec.ID, ContainerID: anExec.ContainerID, ExitCode: anExec.ExitCode}, http.StatusOK
+}
+
+//startExec hijacks connection, reads attached stdin and writes Exec hook output as multiplexed stream
+func (d *Daemon) startExec(writer http.ResponseWriter, request *http.Request, ID string) {
+	d.mux.Lock()
+	anExec := d.exec(ID)
+	d.mux.Unlock()
+	if anExec == nil {
+		notFound(writer, "No such exec instance: %v", ID)
+		return
+	}
+	_, _ = ioutil.ReadAll(request.Body)
+	conn, buf, err := writer.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_, _ = buf.WriteString("HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+	_ = buf.Flush()
+	if anExec.Config.AttachStdin {
+		stdin, _ := ioutil.ReadAll(buf)
+		anExec.Stdin = string(stdin)
+	}
+	var stdout, stderr string
+	var exitCode int
+	if d.Exec != nil {
+		stdout, stderr, exitCode = d.Exec(anExec)
+	}
+	d.mux.Lock()
+	anExec.ExitCode = exitCode
+	d.mux.Unlock()
+	if stdout != "" {
+		_, _ = buf.Write(frame(1, stdout))
+	}
+	if stderr != "" {
+		_, _ = buf.Write(frame(2, stderr))
+	}
+	_ = buf.Flush()
+}
+
+//serveNetworks handles networks API
+func (d *Daemon) serveNetworks(writer http.ResponseWriter, request *http.Request, parts []string) (interface{}, int) {
+	if len(parts) == 1 && request.Method == "GET" {
+		args, _ := filters.FromJSON(request.URL.Query().Get("filters"))
+		var result = make([]types.NetworkResource, 0)
+		for _, resource := range d.Networks {
+			if matches(args, resource.Name, resource.Labels) {
+				result = append(result, *resource)
+			}
+		}
+		return result, http.StatusOK
+	}
+	if len(parts) == 2 && parts[1] == "create" {
+		createRequest := &types.NetworkCreateRequest{}
+		_ = json.NewDecoder(request.Body).Decode(createRequest)
+		resource := &types.NetworkResource{ID: "net-" + createRequest.Name, Name: createRequest.Name, Labels: createRequest.Labels, Containers: map[string]types.EndpointResource{}}
+		d.Networks[resource.ID] = resource
+		return types.NetworkCreateResponse{ID: resource.ID}, http.StatusCreated
+	}
+	if len(parts) < 2 {
+		notFound(writer, "unsupported: %v %v", request.Method, request.URL.Path)
+		return nil, 0
+	}
+	resource := d.network(parts[1])
+	if resource == nil {
+		notFound(writer, "network %v not found", parts[1])
+		return nil, 0
+	}
+	if len(parts) == 2 {
+		switch request.Method {
+		case "GET":
+			return resource, http.StatusOK
+		case "DELETE":
+			delete(d.Networks, resource.ID)
+			return nil, http.StatusNoContent
+		}
+	}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "connect":
+			connect := &types.NetworkConnect{}
+			_ = json.NewDecoder(request.Body).Decode(connect)
+			info := d.container(connect.Container)
+			if info == nil {
+				notFound(writer, "No such container: %v", connect.Container)
+				return nil, 0
+			}
+			if resource.Containers == nil {
+				resource.Containers = map[string]types.EndpointResource{}
+			}
+			resource.Containers[info.ID] = types.EndpointResource{Name: strings.TrimPrefix(info.Name, "/")}
+			return nil, http.StatusOK
+		case "disconnect":
+			disconnect := &types.NetworkDisconnect{}
+			_ = json.NewDecoder(request.Body).Decode(disconnect)
+			if info := d.container(disconnect.Container); info != nil {
+				delete(resource.Containers, info.ID)
+			}
+			delete(resource.Containers, disconnect.Container)
+			return nil, http.StatusOK
+		}
+	}
+	notFound(writer, "unsupported: %v %v", request.Method, request.URL.Path)
+	return nil, 0
+}
+
+//serveVolumes handles volumes API
+func (d *Daemon) serveVolumes(writer http.ResponseWriter, request *http.Request, parts []string) (interface{}, int) {
+	if len(parts) == 1 && request.Method == "GET" {
+		args, _ := filters.FromJSON(request.URL.Query().Get("filters"))
+		var result = make([]*types.Volume, 0)
+		for _, aVolume := range d.Volumes {
+			if matches(args, aVolume.Name, aVolume.Labels) {
+				result = append(result, aVolume)
+			}
+		}
+		return volumetypes.VolumeListOKBody{Volumes: result}, http.StatusOK
+	}
+	if len(parts) == 2 && parts[1] == "create" {
+		createBody := &volumetypes.VolumeCreateBody{}
+		_ = json.NewDecoder(request.Body).Decode(createBody)
+		return d.addVolume(createBody.Name, createBody.Labels), http.StatusCreated
+	}
+	if len(parts) != 2 {
+		notFound(writer, "unsupported: %v %v", request.Method, request.URL.Path)
+		return nil, 0
+	}
+	aVolume, ok := d.Volumes[parts[1]]
+	if !ok {
+		notFound(writer, "get %v: no such volume", parts[1])
+		return nil, 0
+	}
+	if request.Method == "DELETE" {
+		delete(d.Volumes, aVolume.Name)
+		return nil, http.StatusNoContent
+	}
+	return aVolume, http.StatusOK
+}
+
+//New creates in memory docker daemon, call Start to serve docker API
+func New() *Daemon {
+	return &Daemon{
+		Containers: make(map[string]*types.ContainerJSON),
+		Networks:   make(map[string]*types.NetworkResource),
+		Volumes:    make(map[string]*types.Volume),
+		Images:     make([]types.ImageSummary, 0),
+		Execs:      make([]*Exec, 0),
+		Calls:      make([]string, 0),
+		Responses:  make(map[string]interface{}),
+	}
+}
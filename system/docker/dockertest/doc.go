@@ -0,0 +1,2 @@
+//Package dockertest implements in memory docker daemon used by docker and docker/compose tests
+package dockertest
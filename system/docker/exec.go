@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/criteria"
+	"github.com/viant/endly/system/exec"
+	"github.com/viant/endly/util"
+	"github.com/viant/toolbox/data"
+	"io"
+	"strings"
+	"time"
+)
+
+//execInContainer runs command within container, returns exit code, stdout and stderr
+func execInContainer(client *CtxClient, containerID string, config types.ExecConfig, stdin string) (int, string, string, error) {
+	config.AttachStdout = true
+	config.AttachStderr = true
+	config.AttachStdin = stdin != ""
+	created, err := client.Client.ContainerExecCreate(client.Context, containerID, config)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("unable to create exec %v, %v", config.Cmd, err)
+	}
+	attached, err := client.Client.ContainerExecAttach(client.Context, created.ID, types.ExecStartCheck{Tty: config.Tty})
+	if err != nil {
+		return 0, "", "", fmt.Errorf("unable to attach exec %v, %v", config.Cmd, err)
+	}
+	defer attached.Close()
+	if config.AttachStdin {
+		if _, err = io.Copy(attached.Conn, strings.NewReader(stdin)); err != nil {
+			return 0, "", "", fmt.Errorf("unable to write stdin %v, %v", config.Cmd, err)
+		}
+		_ = attached.CloseWrite()
+	}
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	if config.Tty {
+		_, err = io.Copy(stdout, attached.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, attached.Reader)
+	}
+	if err != nil {
+		return 0, "", "", err
+	}
+	for {
+		inspected, err := client.Client.ContainerExecInspect(client.Context, created.ID)
+		if err != nil {
+			return 0, "", "", err
+		}
+		if !inspected.Running {
+			return inspected.ExitCode, stdout.String(), stderr.String(), nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func (s *service) exec(context *endly.Context, request *ExecRequest) (*ExecResponse, error) {
+	response := &ExecResponse{
+		Cmd:  make([]*ExecLog, 0),
+		Data: data.NewMap(),
+	}
+	client, err := GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	containerInfo := types.ContainerJSON{}
+	if err := runAdapter(context, &ContainerInspectRequest{ContainerID: request.Name}, &containerInfo); err != nil {
+		return nil, err
+	}
+	if containerInfo.State == nil || !containerInfo.State.Running {
+		return nil, fmt.Errorf("container %v is not running", request.Name)
+	}
+	for _, command := range request.Commands {
+		if err = s.execCommand(context, client, containerInfo.ID, request, command, response); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+//execCommand runs extract command within container, validates and extracts output
+func (s *service) execCommand(context *endly.Context, client *CtxClient, containerID string, request *ExecRequest, command *exec.ExtractCommand, response *ExecResponse) error {
+	securedCommand := context.Expand(command.Command)
+	if command.When != "" {
+		if ok, err := criteria.Evaluate(context, response.state(context), command.When, "Cmd.When", true); !ok {
+			response.Cmd = append(response.Cmd, &ExecLog{Stdin: securedCommand, Error: errorText(err)})
+			return err
+		}
+	} else if strings.Contains(securedCommand, "$") {
+		var state = response.state(context)
+		securedCommand = state.ExpandAsText(securedCommand)
+	}
+	insecureCommand, err := context.Secrets.Expand(securedCommand, request.Secrets)
+	if err != nil {
+		return err
+	}
+	s.Begin(context, exec.NewSdtinEvent(request.Name, securedCommand))
+	config := request.ExecConfig()
+	config.Cmd = append(append([]string{}, request.Shell...), insecureCommand)
+	exitCode, stdout, stderr, err := execInContainer(client, containerID, config, context.Expand(request.Stdin))
+	output := stdout + stderr
+	context.Publish(exec.NewStdoutEvent(request.Name, output, err))
+	log := &ExecLog{Stdin: securedCommand, Stdout: stdout, Stderr: stderr, ExitCode: exitCode, Error: errorText(err)}
+	response.Cmd = append(response.Cmd, log)
+	response.ExitCode = exitCode
+	if len(response.Output) > 0 && !strings.HasSuffix(response.Output, "\n") {
+		response.Output += "\n"
+	}
+	response.Output += output
+	if err != nil {
+		return err
+	}
+	if request.CheckError && exitCode != 0 {
+		return fmt.Errorf("exit code: %v, command: %v, stderr: %v", exitCode, securedCommand, stderr)
+	}
+	for _, fragment := range command.Errors {
+		if util.EscapedContains(output, fragment) {
+			return fmt.Errorf("encounter error fragment: (%v), command:%v, stdout: %v", fragment, securedCommand, output)
+		}
+	}
+	if len(command.Success) > 0 {
+		matched := false
+		for _, fragment := range command.Success {
+			if matched = util.EscapedContains(output, fragment); matched {
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("failed to match any fragment: '%v', command: %v; stdout: %v", strings.Join(command.Success, ","), securedCommand, output)
+		}
+	}
+	return command.Extract.Extract(context, response.Data, strings.Split(stdout, "\n")...)
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
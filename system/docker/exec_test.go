@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/system/docker/dockertest"
+	"github.com/viant/endly/system/exec"
+	"strings"
+	"testing"
+)
+
+func TestService_Exec(t *testing.T) {
+	daemon := dockertest.New()
+	daemon.AddContainer("c1", "db", &types.ContainerState{Status: "running", Running: true})
+	daemon.Exec = func(exec *dockertest.Exec) (string, string, int) {
+		if strings.HasPrefix(exec.Config.Cmd[len(exec.Config.Cmd)-1], "mysql") {
+			return "Variable_name\tValue\nversion\t5.7.31\n", "", 0
+		}
+		return "", "sh: fail: not found\n", 127
+	}
+	daemon.Start()
+	defer daemon.Close()
+
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	state := context.State()
+	state.Put("dbUser", "root")
+
+	response := &ExecResponse{}
+	err := endly.Run(context, &ExecRequest{
+		Name:    "db",
+		User:    "mysql",
+		Workdir: "/tmp",
+		Env:     map[string]string{"MYSQL_PWD": "dev"},
+		Stdin:   "SHOW VARIABLES LIKE 'version'",
+		Commands: []*exec.ExtractCommand{
+			{
+				Command: "mysql -u${dbUser}",
+				Success: []string{"Variable_name"},
+				Extract: model.Extracts{{Key: "version", RegExpr: `version\s+(\d+\.\d+)`}},
+			},
+			{
+				Command: "fail",
+				When:    "$stdout:/8.0/",
+			},
+		},
+	}, response)
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.EqualValues(t, 1, len(daemon.Execs), "second command skipped by when criteria") {
+		return
+	}
+	config := daemon.Execs[0].Config
+	assert.EqualValues(t, []string{"/bin/sh", "-c", "mysql -uroot"}, config.Cmd)
+	assert.EqualValues(t, "mysql", config.User)
+	assert.EqualValues(t, "/tmp", config.WorkingDir)
+	assert.EqualValues(t, []string{"MYSQL_PWD=dev"}, config.Env)
+	assert.EqualValues(t, "SHOW VARIABLES LIKE 'version'", daemon.Execs[0].Stdin)
+	assert.EqualValues(t, "5.7", response.Data.GetString("version"))
+	assert.EqualValues(t, 0, response.ExitCode)
+
+	response = &ExecResponse{}
+	err = endly.Run(context, &ExecRequest{Name: "db", Command: "fail"}, response)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 127, response.ExitCode)
+		assert.EqualValues(t, "sh: fail: not found\n", response.Cmd[0].Stderr)
+	}
+	err = endly.Run(context, &ExecRequest{Name: "db", Command: "fail", CheckError: true}, response)
+	assert.NotNil(t, err)
+	err = endly.Run(context, &ExecRequest{Name: "db", Commands: []*exec.ExtractCommand{{Command: "fail", Errors: []string{"not found"}}}}, response)
+	assert.NotNil(t, err)
+	err = endly.Run(context, &ExecRequest{Name: "missing", Command: "ls"}, response)
+	assert.NotNil(t, err)
+}
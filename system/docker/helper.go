@@ -70,3 +70,12 @@ func expandHomeDirectory(location string) string {
 	}
 	return location
 }
+
+//sessionLabels returns copy of labels with session label
+func sessionLabels(context *endly.Context, labels map[string]string) map[string]string {
+	var result = map[string]string{endly.SessionLabel: context.SessionID}
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,204 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	defaultReadinessTimeoutMs = 60000
+	defaultReadinessSleepMs   = 500
+)
+
+//ReadinessProbe represents container readiness probe, all defined checks have to pass
+type ReadinessProbe struct {
+	Health     bool     `description:"wait for docker HEALTHCHECK healthy status"`
+	TCP        string   `description:"container port (resolved with published host port) or host:port to be open" example:"3306"`
+	HTTP       string   `description:"URL or path (requires Port) expected to return Status" example:"/health"`
+	Port       string   `description:"container port used with HTTP path"`
+	Status     int      `description:"expected HTTP status code, default 200"`
+	Log        string   `description:"regular expression expected to match container log"`
+	Exec       []string `description:"command executed within container, expected to exit with code 0"`
+	TimeoutMs  int      `description:"readiness timeout, default 60000"`
+	SleepMs    int      `description:"sleep between probes, default 500"`
+	logPattern *regexp.Regexp
+}
+
+//Init initialises probe
+func (p *ReadinessProbe) Init() (err error) {
+	if p.TimeoutMs == 0 {
+		p.TimeoutMs = defaultReadinessTimeoutMs
+	}
+	if p.SleepMs == 0 {
+		p.SleepMs = defaultReadinessSleepMs
+	}
+	if p.HTTP != "" && p.Status == 0 {
+		p.Status = http.StatusOK
+	}
+	if p.Log != "" {
+		if p.logPattern, err = regexp.Compile(p.Log); err != nil {
+			return fmt.Errorf("invalid log pattern: %v, %v", p.Log, err)
+		}
+	}
+	return nil
+}
+
+//Validate checks if probe is valid
+func (p *ReadinessProbe) Validate() error {
+	if !p.Health && p.TCP == "" && p.HTTP == "" && p.Log == "" && len(p.Exec) == 0 {
+		return fmt.Errorf("readiness probe was empty, define health, tcp, http, log or exec")
+	}
+	if strings.HasPrefix(p.HTTP, "/") && p.Port == "" {
+		return fmt.Errorf("readiness port was empty for HTTP path: %v", p.HTTP)
+	}
+	return nil
+}
+
+//waitReady waits till container satisfies all probe checks
+func (p *ReadinessProbe) waitReady(client *CtxClient, containerID string) error {
+	deadline := time.Now().Add(time.Duration(p.TimeoutMs) * time.Millisecond)
+	for {
+		ready, err := p.check(client, containerID)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("container was not ready within %v ms", p.TimeoutMs)
+		}
+		time.Sleep(time.Duration(p.SleepMs) * time.Millisecond)
+	}
+}
+
+//check runs all probe checks once, returns error if container would never become ready
+func (p *ReadinessProbe) check(client *CtxClient, containerID string) (bool, error) {
+	info, err := client.Client.ContainerInspect(client.Context, containerID)
+	if err != nil {
+		return false, err
+	}
+	if info.State == nil || !info.State.Running {
+		status := ""
+		exitCode := 0
+		if info.State != nil {
+			status, exitCode = info.State.Status, info.State.ExitCode
+		}
+		return false, fmt.Errorf("container is %v, exit code: %v", status, exitCode)
+	}
+	if p.Health {
+		if info.State.Health == nil {
+			return false, fmt.Errorf("container does not define HEALTHCHECK")
+		}
+		switch info.State.Health.Status {
+		case types.Unhealthy:
+			return false, fmt.Errorf("container is unhealthy")
+		case types.Healthy:
+		default:
+			return false, nil
+		}
+	}
+	if p.TCP != "" {
+		address, err := hostAddress(client, &info, p.TCP)
+		if err != nil {
+			return false, err
+		}
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err != nil {
+			return false, nil
+		}
+		_ = conn.Close()
+	}
+	if p.HTTP != "" {
+		URL := p.HTTP
+		if strings.HasPrefix(URL, "/") {
+			address, err := hostAddress(client, &info, p.Port)
+			if err != nil {
+				return false, err
+			}
+			URL = "http://" + address + URL
+		}
+		httpClient := &http.Client{Timeout: time.Duration(p.SleepMs+1000) * time.Millisecond}
+		response, err := httpClient.Get(URL)
+		if err != nil {
+			return false, nil
+		}
+		_ = response.Body.Close()
+		if response.StatusCode != p.Status {
+			return false, nil
+		}
+	}
+	if p.logPattern != nil {
+		logs, err := containerLogs(client, &info)
+		if err != nil {
+			return false, err
+		}
+		if !p.logPattern.MatchString(logs) {
+			return false, nil
+		}
+	}
+	if len(p.Exec) > 0 {
+		exitCode, _, _, err := execInContainer(client, containerID, types.ExecConfig{Cmd: p.Exec}, "")
+		if err != nil {
+			return false, err
+		}
+		if exitCode != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+//hostAddress returns host address for supplied port, container port is resolved with published host port
+func hostAddress(client *CtxClient, info *types.ContainerJSON, port string) (string, error) {
+	if host, _, err := net.SplitHostPort(port); err == nil && host != "" {
+		return port, nil
+	}
+	host := "127.0.0.1"
+	if daemonURL, err := url.Parse(client.Client.DaemonHost()); err == nil && daemonURL.Scheme == "tcp" {
+		host = daemonURL.Hostname()
+	}
+	hostPort := port
+	containerPort := nat.Port(port)
+	if !strings.Contains(port, "/") {
+		containerPort = nat.Port(port + "/tcp")
+	}
+	if info.NetworkSettings != nil {
+		for _, binding := range info.NetworkSettings.Ports[containerPort] {
+			if binding.HostPort != "" {
+				hostPort = binding.HostPort
+				break
+			}
+		}
+	}
+	if _, err := nat.ParsePort(hostPort); err != nil {
+		return "", fmt.Errorf("invalid port: %v, %v", port, err)
+	}
+	return net.JoinHostPort(host, hostPort), nil
+}
+
+//containerLogs returns container stdout and stderr
+func containerLogs(client *CtxClient, info *types.ContainerJSON) (string, error) {
+	reader, err := client.Client.ContainerLogs(client.Context, info.ID, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	if info.Config != nil && info.Config.Tty {
+		data, err := ioutil.ReadAll(reader)
+		return string(data), err
+	}
+	output := new(bytes.Buffer)
+	_, err = stdcopy.StdCopy(output, output, reader)
+	return output.String(), err
+}
@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/system/docker/dockertest"
+	"golang.org/x/net/context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadinessProbe_WaitReady(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer listener.Close()
+	_, tcpPort, _ := net.SplitHostPort(listener.Addr().String())
+	httpServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/health" {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer httpServer.Close()
+	_, httpPort, _ := net.SplitHostPort(strings.Replace(httpServer.URL, "http://", "", 1))
+
+	var health, exitCode = "starting", 1
+	daemon := dockertest.New()
+	containerInfo := daemon.AddContainer("c1", "db", &types.ContainerState{Status: "running", Running: true})
+	containerInfo.NetworkSettings.Ports = nat.PortMap{
+		"3306/tcp": []nat.PortBinding{{HostPort: tcpPort}},
+		"8080/tcp": []nat.PortBinding{{HostPort: httpPort}},
+	}
+	daemon.OnInspect = func(info *types.ContainerJSON) {
+		info.State.Health = &types.Health{Status: health}
+		health = "healthy"
+	}
+	daemon.Logs = func(info *types.ContainerJSON) string {
+		return "mysqld: ready for connections\n"
+	}
+	daemon.Exec = func(exec *dockertest.Exec) (string, string, int) {
+		defer func() { exitCode = 0 }()
+		return "ok\n", "", exitCode
+	}
+	daemon.Start()
+	defer daemon.Close()
+	dockerClient, err := client.NewClientWithOpts(client.WithHost(daemon.Host()), client.WithVersion("1.37"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	ctxClient := &CtxClient{Client: dockerClient, Context: context.Background()}
+
+	var useCases = []struct {
+		description string
+		probe       *ReadinessProbe
+		state       *types.ContainerState
+		hasError    bool
+	}{
+		{
+			description: "all checks",
+			probe:       &ReadinessProbe{Health: true, TCP: "3306", HTTP: "/health", Port: "8080", Log: "ready for connections", Exec: []string{"mysqladmin", "ping"}, SleepMs: 10},
+		},
+		{
+			description: "http status mismatch",
+			probe:       &ReadinessProbe{HTTP: httpServer.URL + "/status", TimeoutMs: 50, SleepMs: 10},
+			hasError:    true,
+		},
+		{
+			description: "log not matched",
+			probe:       &ReadinessProbe{Log: "^never$", TimeoutMs: 50, SleepMs: 10},
+			hasError:    true,
+		},
+		{
+			description: "container exited",
+			probe:       &ReadinessProbe{TCP: "3306"},
+			state:       &types.ContainerState{Status: "exited", ExitCode: 1},
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		if useCase.state != nil {
+			containerInfo.State = useCase.state
+		}
+		assert.Nil(t, useCase.probe.Init(), useCase.description)
+		assert.Nil(t, useCase.probe.Validate(), useCase.description)
+		err := useCase.probe.waitReady(ctxClient, "c1")
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		assert.Nil(t, err, useCase.description)
+	}
+	assert.NotNil(t, (&ReadinessProbe{}).Validate())
+	assert.NotNil(t, (&ReadinessProbe{HTTP: "/health"}).Validate())
+}
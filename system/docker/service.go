@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/go-errors/errors"
 	"github.com/viant/endly"
@@ -16,6 +18,7 @@ import (
 	"log"
 	"path"
 	"strings"
+	"time"
 )
 
 const (
@@ -23,6 +26,21 @@ const (
 	ServiceID = "docker"
 )
 
+const (
+	ledgerContainer = "docker/container"
+	ledgerNetwork   = "docker/network"
+	ledgerVolume    = "docker/volume"
+)
+
+//managedActions represents actions implemented by service instead of docker client proxy
+var managedActions = map[string]bool{
+	"networkCreate":  true,
+	"networkRemove":  true,
+	"networkConnect": true,
+	"volumeCreate":   true,
+	"volumeRemove":   true,
+}
+
 //no operation service
 type service struct {
 	*endly.AbstractService
@@ -107,13 +125,14 @@ func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse
 	if containerInfo != nil {
 		if request.Reuse {
 			response.ContainerID = containerInfo.ID
-			if IsContainerUp(containerInfo) {
-				return response, nil
+			if !IsContainerUp(containerInfo) {
+				if _, err := s.start(context, &StartRequest{
+					IDs: []string{containerInfo.ID},
+				}); err != nil {
+					return nil, err
+				}
 			}
-			_, err := s.start(context, &StartRequest{
-				IDs: []string{containerInfo.ID},
-			})
-			return response, err
+			return response, s.waitReady(context, request, response)
 		}
 
 		if _, err := s.remove(context, &RemoveRequest{
@@ -138,11 +157,15 @@ func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse
 		}
 	}
 	createRequest := request.CreateContainerRequest()
+	createRequest.Config.Labels = sessionLabels(context, createRequest.Config.Labels)
 	createResponse := &container.ContainerCreateCreatedBody{}
 	if err := runAdapter(context, createRequest, createResponse); err != nil {
 		return nil, err
 	}
 	response.ContainerID = createResponse.ID
+	if err := s.registerContainer(context, request.Name, createResponse.ID); err != nil {
+		return nil, err
+	}
 	startRequest := &StartRequest{IDs: []string{createResponse.ID}}
 
 	if _, err := s.start(context, startRequest); err != nil {
@@ -157,6 +180,9 @@ func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse
 		return nil, fmt.Errorf("unable to locate container %v", status.Containers[0].ID)
 	}
 	response.Status = status.Containers[0].Status
+	if err = s.waitReady(context, request, response); err != nil {
+		return nil, err
+	}
 	logRequest := &ContainerLogsRequest{Container: response.ContainerID}
 	logRequest.ShowStdout = true
 	var logReader io.ReadCloser
@@ -168,6 +194,27 @@ func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse
 	return response, nil
 }
 
+//waitReady waits for container readiness if probe was specified, on failure returns error with container logs
+func (s *service) waitReady(context *endly.Context, request *RunRequest, response *RunResponse) error {
+	if request.Readiness == nil {
+		return nil
+	}
+	client, err := GetCtxClient(context)
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
+	if err = request.Readiness.waitReady(client, response.ContainerID); err == nil {
+		response.ReadyTimeMs = int(time.Since(startTime) / time.Millisecond)
+		return nil
+	}
+	logs := ""
+	if info, inspectErr := client.Client.ContainerInspect(client.Context, response.ContainerID); inspectErr == nil {
+		logs, _ = containerLogs(client, &info)
+	}
+	return fmt.Errorf("container %v (%v) was not ready: %v, logs:\n%v", request.Name, request.Image, err, logs)
+}
+
 func (s *service) pull(context *endly.Context, request *PullRequest) (*PullResponse, error) {
 	err := request.Init()
 	if err != nil {
@@ -295,6 +342,14 @@ func (s *service) stop(context *endly.Context, request *StopRequest) (*StopRespo
 	return response, nil
 }
 
+//registerContainer registers created container in session ledger
+func (s *service) registerContainer(context *endly.Context, name, ID string) error {
+	if name == "" {
+		return context.Ledger().Register(ledgerContainer, ID, ServiceID, "remove", &RemoveRequest{IDs: []string{ID}})
+	}
+	return context.Ledger().Register(ledgerContainer, name, ServiceID, "remove", &RemoveRequest{Names: []string{name}})
+}
+
 func (s *service) remove(context *endly.Context, request *RemoveRequest) (*RemoveResponse, error) {
 	response := &RemoveResponse{
 		Containers: make([]types.Container, 0),
@@ -322,6 +377,16 @@ func (s *service) remove(context *endly.Context, request *RemoveRequest) (*Remov
 		if err != nil {
 			return nil, err
 		}
+		if err = context.Ledger().Release(ledgerContainer, containerInfo.ID); err == nil {
+			for _, name := range containerInfo.Names {
+				if err = context.Ledger().Release(ledgerContainer, strings.TrimPrefix(name, "/")); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
 		response.Containers = append(response.Containers, containerInfo)
 	}
 	return response, nil
@@ -536,6 +601,169 @@ func (s *service) logs(context *endly.Context, request *LogsRequest) (*LogsRespo
 	return response, nil
 }
 
+func (s *service) networkCreate(context *endly.Context, request *CreateNetworkRequest) (*CreateNetworkResponse, error) {
+	response := &CreateNetworkResponse{Name: request.Name}
+	listRequest := &NetworkListRequest{}
+	listRequest.Filters = filters.NewArgs(filters.Arg("name", request.Name))
+	var networks []types.NetworkResource
+	if err := runAdapter(context, listRequest, &networks); err != nil {
+		return nil, err
+	}
+	for _, candidate := range networks {
+		if candidate.Name == request.Name {
+			response.NetworkID = candidate.ID
+			response.Existing = true
+			return response, nil
+		}
+	}
+	createRequest := &NetworkCreateRequest{Name: request.Name, NetworkCreate: request.NetworkCreate}
+	createRequest.Labels = sessionLabels(context, createRequest.Labels)
+	createResponse := types.NetworkCreateResponse{}
+	if err := runAdapter(context, createRequest, &createResponse); err != nil {
+		return nil, fmt.Errorf("unable to create network %v, %v", request.Name, err)
+	}
+	response.NetworkID = createResponse.ID
+	err := context.Ledger().Register(ledgerNetwork, request.Name, ServiceID, "networkRemove", &RemoveNetworkRequest{Names: []string{request.Name}, Force: true})
+	return response, err
+}
+
+func (s *service) networkRemove(context *endly.Context, request *RemoveNetworkRequest) (*RemoveNetworkResponse, error) {
+	response := &RemoveNetworkResponse{Removed: make([]string, 0)}
+	for _, name := range request.Names {
+		resource := types.NetworkResource{}
+		if err := runAdapter(context, &NetworkInspectRequest{NetworkID: name}, &resource); err != nil {
+			if client.IsErrNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if request.Force {
+			for containerID := range resource.Containers {
+				disconnectRequest := &NetworkDisconnectRequest{NetworkID: resource.ID, ContainerID: containerID, Force: true}
+				if err := runAdapter(context, disconnectRequest, nil); err != nil {
+					return nil, fmt.Errorf("unable to disconnect %v from network %v, %v", containerID, name, err)
+				}
+			}
+		}
+		if err := runAdapter(context, &NetworkRemoveRequest{NetworkID: resource.ID}, nil); err != nil {
+			return nil, fmt.Errorf("unable to remove network %v, %v", name, err)
+		}
+		if err := context.Ledger().Release(ledgerNetwork, resource.Name); err != nil {
+			return nil, err
+		}
+		response.Removed = append(response.Removed, name)
+	}
+	return response, nil
+}
+
+func (s *service) networkConnect(context *endly.Context, request *ConnectNetworkRequest) (*ConnectNetworkResponse, error) {
+	response := &ConnectNetworkResponse{}
+	resource := types.NetworkResource{}
+	if err := runAdapter(context, &NetworkInspectRequest{NetworkID: request.Network}, &resource); err != nil {
+		return nil, err
+	}
+	containerInfo := types.ContainerJSON{}
+	if err := runAdapter(context, &ContainerInspectRequest{ContainerID: request.Container}, &containerInfo); err != nil {
+		return nil, err
+	}
+	if _, ok := resource.Containers[containerInfo.ID]; ok {
+		return response, nil
+	}
+	endpoint := &network.EndpointSettings{}
+	if request.Config != nil {
+		*endpoint = *request.Config
+	}
+	endpoint.Aliases = append(endpoint.Aliases, request.Aliases...)
+	if request.IPAddress != "" {
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: request.IPAddress}
+	}
+	connectRequest := &NetworkConnectRequest{NetworkID: resource.ID, ContainerID: containerInfo.ID, Config: endpoint}
+	if err := runAdapter(context, connectRequest, nil); err != nil {
+		return nil, fmt.Errorf("unable to connect %v to network %v, %v", request.Container, request.Network, err)
+	}
+	response.Connected = true
+	return response, nil
+}
+
+func (s *service) volumeCreate(context *endly.Context, request *CreateVolumeRequest) (*CreateVolumeResponse, error) {
+	response := &CreateVolumeResponse{Name: request.Name}
+	volume := types.Volume{}
+	err := runAdapter(context, &VolumeInspectRequest{VolumeID: request.Name}, &volume)
+	if err == nil {
+		response.Mountpoint = volume.Mountpoint
+		response.Existing = true
+		return response, nil
+	}
+	if !client.IsErrNotFound(err) {
+		return nil, err
+	}
+	createRequest := &VolumeCreateRequest{VolumeCreateBody: request.VolumeCreateBody}
+	createRequest.Labels = sessionLabels(context, createRequest.Labels)
+	if err = runAdapter(context, createRequest, &volume); err != nil {
+		return nil, fmt.Errorf("unable to create volume %v, %v", request.Name, err)
+	}
+	response.Mountpoint = volume.Mountpoint
+	err = context.Ledger().Register(ledgerVolume, request.Name, ServiceID, "volumeRemove", &RemoveVolumeRequest{Names: []string{request.Name}, Force: true})
+	return response, err
+}
+
+func (s *service) volumeRemove(context *endly.Context, request *RemoveVolumeRequest) (*RemoveVolumeResponse, error) {
+	response := &RemoveVolumeResponse{Removed: make([]string, 0)}
+	for _, name := range request.Names {
+		if err := runAdapter(context, &VolumeRemoveRequest{VolumeID: name, Force: request.Force}, nil); err != nil {
+			if client.IsErrNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to remove volume %v, %v", name, err)
+		}
+		if err := context.Ledger().Release(ledgerVolume, name); err != nil {
+			return nil, err
+		}
+		response.Removed = append(response.Removed, name)
+	}
+	return response, nil
+}
+
+func (s *service) prune(context *endly.Context, request *PruneRequest) (*PruneResponse, error) {
+	response := &PruneResponse{}
+	if request.Containers {
+		report := types.ContainersPruneReport{}
+		if err := runAdapter(context, &ContainersPruneRequest{PruneFilters: request.Filters()}, &report); err != nil {
+			return nil, fmt.Errorf("unable to prune containers, %v", err)
+		}
+		response.Containers = report.ContainersDeleted
+		response.SpaceReclaimed += report.SpaceReclaimed
+	}
+	if request.Networks {
+		report := types.NetworksPruneReport{}
+		if err := runAdapter(context, &NetworksPruneRequest{PruneFilters: request.Filters()}, &report); err != nil {
+			return nil, fmt.Errorf("unable to prune networks, %v", err)
+		}
+		response.Networks = report.NetworksDeleted
+	}
+	if request.Volumes {
+		report := types.VolumesPruneReport{}
+		if err := runAdapter(context, &VolumesPruneRequest{PruneFilters: request.Filters()}, &report); err != nil {
+			return nil, fmt.Errorf("unable to prune volumes, %v", err)
+		}
+		response.Volumes = report.VolumesDeleted
+		response.SpaceReclaimed += report.SpaceReclaimed
+	}
+	if request.Images {
+		report := types.ImagesPruneReport{}
+		if err := runAdapter(context, &ImagesPruneRequest{PruneFilters: request.Filters()}, &report); err != nil {
+			return nil, fmt.Errorf("unable to prune images, %v", err)
+		}
+		for _, item := range report.ImagesDeleted {
+			if item.Deleted != "" {
+				response.Images = append(response.Images, item.Deleted)
+			}
+		}
+		response.SpaceReclaimed += report.SpaceReclaimed
+	}
+	return response, nil
+}
+
 func (s *service) registerRoutes() {
 	dockerClient := &client.Client{}
 	routes, err := BuildRoutes(dockerClient, GetCtxClient)
@@ -544,6 +772,9 @@ func (s *service) registerRoutes() {
 		return
 	}
 	for _, route := range routes {
+		if managedActions[route.Action] {
+			continue
+		}
 		route.OnRawRequest = initClient
 		s.Register(route)
 	}
@@ -846,6 +1077,170 @@ func (s *service) registerRoutes() {
 			return nil, fmt.Errorf("unsupported request type: %T", request)
 		},
 	})
+
+	s.Register(&endly.Route{
+		Action:       "networkCreate",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "create docker network if it does not exist",
+		},
+		RequestProvider: func() interface{} {
+			return &CreateNetworkRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &CreateNetworkResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*CreateNetworkRequest); ok {
+				response, err := s.networkCreate(context, req)
+				if err == nil {
+					publishEvent(context, "networkCreate", response)
+				}
+				return response, err
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action:       "networkRemove",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "remove docker networks by name",
+		},
+		RequestProvider: func() interface{} {
+			return &RemoveNetworkRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &RemoveNetworkResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*RemoveNetworkRequest); ok {
+				response, err := s.networkRemove(context, req)
+				if err == nil {
+					publishEvent(context, "networkRemove", response)
+				}
+				return response, err
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action:       "networkConnect",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "connect container to docker network",
+		},
+		RequestProvider: func() interface{} {
+			return &ConnectNetworkRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &ConnectNetworkResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*ConnectNetworkRequest); ok {
+				response, err := s.networkConnect(context, req)
+				if err == nil {
+					publishEvent(context, "networkConnect", response)
+				}
+				return response, err
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action:       "volumeCreate",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "create docker named volume if it does not exist",
+		},
+		RequestProvider: func() interface{} {
+			return &CreateVolumeRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &CreateVolumeResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*CreateVolumeRequest); ok {
+				response, err := s.volumeCreate(context, req)
+				if err == nil {
+					publishEvent(context, "volumeCreate", response)
+				}
+				return response, err
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action:       "volumeRemove",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "remove docker volumes by name",
+		},
+		RequestProvider: func() interface{} {
+			return &RemoveVolumeRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &RemoveVolumeResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*RemoveVolumeRequest); ok {
+				response, err := s.volumeRemove(context, req)
+				if err == nil {
+					publishEvent(context, "volumeRemove", response)
+				}
+				return response, err
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action:       "prune",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "remove stopped containers, unused networks, volumes and dangling images",
+		},
+		RequestProvider: func() interface{} {
+			return &PruneRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &PruneResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*PruneRequest); ok {
+				response, err := s.prune(context, req)
+				if err == nil {
+					publishEvent(context, "prune", response)
+				}
+				return response, err
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action:       "exec",
+		OnRawRequest: initClient,
+		RequestInfo: &endly.ActionInfo{
+			Description: "run commands within running container with docker API",
+		},
+		RequestProvider: func() interface{} {
+			return &ExecRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &ExecResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*ExecRequest); ok {
+				return s.exec(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
 }
 
 //New creates a new Docker service.
@@ -2,13 +2,32 @@ package docker
 
 import (
 	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/endly"
+	"github.com/viant/endly/system/docker/dockertest"
 	"github.com/viant/toolbox"
+	"io/ioutil"
+	"log"
+	"os"
 	"path"
 	"testing"
 )
 
+//TestMain isolates session ledger from user home directory
+func TestMain(m *testing.M) {
+	directory, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		log.Fatal(err)
+	}
+	_ = os.Setenv(endly.LedgerDirectoryEnv, directory)
+	code := m.Run()
+	_ = os.RemoveAll(directory)
+	os.Exit(code)
+}
+
 func TestService_Build(t *testing.T) {
 	service := New()
 	assert.NotNil(t, service)
@@ -30,3 +49,94 @@ func TestService_Build(t *testing.T) {
 	}
 	assert.True(t, response.ImageID != "")
 }
+
+func TestService_NetworkAndVolume(t *testing.T) {
+	daemon := dockertest.New()
+	daemon.AddContainer("c1", "db", &types.ContainerState{Status: "running", Running: true})
+	daemon.AddContainer("c2", "app", &types.ContainerState{Status: "running", Running: true})
+	daemon.AddNetwork("n1", "backend", "c1")
+	daemon.AddNetwork("n2", "backend-2")
+	daemon.AddVolume("data")
+	daemon.Responses["POST /containers/prune"] = types.ContainersPruneReport{ContainersDeleted: []string{"c3"}, SpaceReclaimed: 10}
+	daemon.Responses["POST /networks/prune"] = types.NetworksPruneReport{NetworksDeleted: []string{"n4"}}
+	daemon.Responses["POST /images/prune"] = types.ImagesPruneReport{ImagesDeleted: []types.ImageDeleteResponseItem{{Untagged: "x:1"}, {Deleted: "sha256:1"}}, SpaceReclaimed: 5}
+	daemon.Start()
+	defer daemon.Close()
+
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+
+	createResponse := &CreateNetworkResponse{}
+	if assert.Nil(t, endly.Run(context, &CreateNetworkRequest{Name: "backend"}, createResponse)) {
+		assert.EqualValues(t, &CreateNetworkResponse{NetworkID: "n1", Name: "backend", Existing: true}, createResponse)
+	}
+	if assert.Nil(t, endly.Run(context, &CreateNetworkRequest{Name: "frontend"}, createResponse)) {
+		assert.EqualValues(t, &CreateNetworkResponse{NetworkID: "net-frontend", Name: "frontend"}, createResponse)
+	}
+
+	connectResponse := &ConnectNetworkResponse{}
+	if assert.Nil(t, endly.Run(context, &ConnectNetworkRequest{Network: "backend", Container: "db"}, connectResponse)) {
+		assert.False(t, connectResponse.Connected, "db is already connected")
+	}
+	if assert.Nil(t, endly.Run(context, &ConnectNetworkRequest{Network: "backend", Container: "app", Aliases: []string{"api"}}, connectResponse)) {
+		assert.True(t, connectResponse.Connected)
+	}
+	if assert.Nil(t, endly.Run(context, &ConnectNetworkRequest{Network: "backend", Container: "app"}, connectResponse)) {
+		assert.False(t, connectResponse.Connected, "app is already connected")
+	}
+	legacyConnect := &ConnectNetworkRequest{NetworkID: "frontend", ContainerID: "app", Config: &network.EndpointSettings{Aliases: []string{"web"}}}
+	if assert.Nil(t, endly.Run(context, legacyConnect, connectResponse)) {
+		assert.True(t, connectResponse.Connected)
+	}
+
+	removeNetworkResponse := &RemoveNetworkResponse{}
+	if assert.Nil(t, endly.Run(context, &RemoveNetworkRequest{Name: "backend,missing", Force: true}, removeNetworkResponse)) {
+		assert.EqualValues(t, []string{"backend"}, removeNetworkResponse.Removed)
+	}
+	if assert.Nil(t, endly.Run(context, &RemoveNetworkRequest{NetworkID: "frontend", Force: true}, removeNetworkResponse)) {
+		assert.EqualValues(t, []string{"frontend"}, removeNetworkResponse.Removed)
+	}
+	assert.EqualValues(t, 1, len(daemon.Networks))
+
+	volumeResponse := &CreateVolumeResponse{}
+	if assert.Nil(t, endly.Run(context, &CreateVolumeRequest{VolumeCreateBody: volumetypes.VolumeCreateBody{Name: "data"}}, volumeResponse)) {
+		assert.EqualValues(t, &CreateVolumeResponse{Name: "data", Mountpoint: "/var/lib/docker/volumes/data", Existing: true}, volumeResponse)
+	}
+	if assert.Nil(t, endly.Run(context, &CreateVolumeRequest{VolumeCreateBody: volumetypes.VolumeCreateBody{Name: "cache"}}, volumeResponse)) {
+		assert.EqualValues(t, &CreateVolumeResponse{Name: "cache", Mountpoint: "/var/lib/docker/volumes/cache"}, volumeResponse)
+	}
+	removeVolumeResponse := &RemoveVolumeResponse{}
+	if assert.Nil(t, endly.Run(context, &RemoveVolumeRequest{Names: []string{"data", "missing"}}, removeVolumeResponse)) {
+		assert.EqualValues(t, []string{"data"}, removeVolumeResponse.Removed)
+	}
+	if assert.Nil(t, endly.Run(context, &RemoveVolumeRequest{VolumeID: "cache"}, removeVolumeResponse)) {
+		assert.EqualValues(t, []string{"cache"}, removeVolumeResponse.Removed)
+	}
+	assert.EqualValues(t, 0, len(daemon.Volumes))
+
+	pruneResponse := &PruneResponse{}
+	if assert.Nil(t, endly.Run(context, &PruneRequest{}, pruneResponse)) {
+		assert.EqualValues(t, &PruneResponse{Containers: []string{"c3"}, Networks: []string{"n4"}, Images: []string{"sha256:1"}, SpaceReclaimed: 15}, pruneResponse)
+	}
+	assert.EqualValues(t, []string{
+		"GET /networks", "GET /networks", "POST /networks/create",
+		"GET /networks/backend", "GET /containers/db/json", "GET /networks/backend", "GET /containers/app/json", "POST /networks/n1/connect",
+		"GET /networks/backend", "GET /containers/app/json",
+		"GET /networks/frontend", "GET /containers/app/json", "POST /networks/net-frontend/connect",
+		"GET /networks/backend", "POST /networks/n1/disconnect", "POST /networks/n1/disconnect", "DELETE /networks/n1", "GET /networks/missing",
+		"GET /networks/frontend", "POST /networks/net-frontend/disconnect", "DELETE /networks/net-frontend",
+		"GET /volumes/data", "GET /volumes/cache", "POST /volumes/create", "DELETE /volumes/data", "DELETE /volumes/missing", "DELETE /volumes/cache",
+		"POST /containers/prune", "POST /networks/prune", "POST /images/prune",
+	}, daemon.Calls)
+}
+
+func TestRunRequest_Network(t *testing.T) {
+	request := &RunRequest{Image: "mysql:5.7", Name: "db", Network: "backend", Aliases: []string{"mysql"}}
+	assert.Nil(t, request.Init())
+	assert.Nil(t, request.Validate())
+	assert.EqualValues(t, "backend", request.HostConfig.NetworkMode)
+	assert.EqualValues(t, []string{"mysql"}, request.CreateContainerRequest().NetworkingConfig.EndpointsConfig["backend"].Aliases)
+	request = &RunRequest{Image: "mysql:5.7", Aliases: []string{"mysql"}}
+	assert.Nil(t, request.Init())
+	assert.NotNil(t, request.Validate())
+}
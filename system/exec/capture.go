@@ -0,0 +1,149 @@
+package exec
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/ssh"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const captureMarkerInfix = "_endly_status_"
+
+//captureSession represents SSH multi command session capturing exit code and stderr of commands requiring exit code,
+//such command is followed by marker commands printing its stderr and exit status, other commands run as is,
+//input sent while previous command still waits for it (i.e. sudo password) is passed as is and completes the pending command
+type captureSession struct {
+	ssh.MultiCommandSession
+	prefix  string
+	seq     int64
+	pending string
+	mux     sync.Mutex
+}
+
+func newCaptureSession(session ssh.MultiCommandSession) *captureSession {
+	return &captureSession{
+		MultiCommandSession: session,
+		prefix:              toolbox.AsString(time.Now().UnixNano()) + captureMarkerInfix,
+	}
+}
+
+//canWrap returns true if command can be followed by marker commands on the same line
+func canWrap(command string) bool {
+	command = strings.TrimSpace(command)
+	return command != "" && !strings.Contains(command, "\n") && !strings.Contains(command, "#")
+}
+
+//wrap returns command followed by marker commands, stderr is redirected to a temp file unless command is interactive
+func (s *captureSession) wrap(command, marker string, interactive bool) string {
+	command = strings.TrimSpace(command)
+	separator := "; "
+	if strings.HasSuffix(command, "&") && !strings.HasSuffix(command, "&&") {
+		separator = " "
+	}
+	split := len(s.prefix)
+	printStatus := fmt.Sprintf(`printf '%%s%%s:%%s\n' '%v' '%v' "$__endly_status"; (exit $__endly_status)`, marker[:split], marker[split:])
+	if interactive {
+		return fmt.Sprintf("%v%v__endly_status=$?; %v", command, separator, printStatus)
+	}
+	errFile := fmt.Sprintf(`"${TMPDIR:-/tmp}/endly%v.err"`, marker)
+	return fmt.Sprintf("{ %v%v} 2>%v; __endly_status=$?; printf '%%s%%s_err\\n' '%v' '%v'; cat %v 2>/dev/null; rm -f %v; %v",
+		command, separator, errFile, marker[:split], marker[split:], errFile, errFile, printStatus)
+}
+
+//runWithResult runs command, captured command output holds its stdout followed by its stderr,
+//command not flagged with capture runs as is with stderr streamed on terminal
+func (s *captureSession) runWithResult(command string, capture, interactive bool, listener ssh.Listener, timeoutMs int, terminators ...string) (*commandResult, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	marker := s.pending
+	input := command
+	if marker == "" && capture && canWrap(command) {
+		marker = s.prefix + toolbox.AsString(atomic.AddInt64(&s.seq, 1))
+		input = s.wrap(command, marker, interactive)
+	}
+	if listener != nil {
+		listener = s.filter(listener)
+	}
+	output, err := s.MultiCommandSession.Run(input, listener, timeoutMs, terminators...)
+	if marker == "" {
+		return &commandResult{output: output}, err
+	}
+	result, done := parseCapturedOutput(output, marker)
+	result.output = s.stripMarkers(result.output)
+	s.pending = ""
+	if !done && (interactive || strings.Contains(output, "Password")) {
+		s.pending = marker
+		result.pending = true
+	}
+	return result, err
+}
+
+//parseCapturedOutput extracts stderr and exit status printed by marker commands, done is false if status marker was not printed yet
+func parseCapturedOutput(output, marker string) (result *commandResult, done bool) {
+	result = &commandResult{output: output}
+	statusIndex := strings.Index(output, marker+":")
+	if statusIndex == -1 {
+		return result, false
+	}
+	statusLine := output[statusIndex+len(marker)+1:]
+	trailing := ""
+	if index := strings.Index(statusLine, "\n"); index != -1 {
+		statusLine, trailing = statusLine[:index], statusLine[index+1:]
+	}
+	stdout := output[:statusIndex]
+	if errIndex := strings.Index(stdout, marker+"_err"); errIndex != -1 {
+		stderr := stdout[errIndex+len(marker)+4:]
+		stdout = stdout[:errIndex]
+		result.stderr = strings.Trim(stderr, "\r\n")
+	}
+	stdout = strings.TrimRight(stdout, "\r\n")
+	if result.stderr != "" {
+		if stdout != "" {
+			stdout += "\n"
+		}
+		stdout += result.stderr
+	}
+	result.output = stdout
+	if strings.TrimSpace(trailing) != "" {
+		//pending command completed before output of the current input
+		result.output = strings.TrimRight(trailing, "\r\n")
+		result.stderr = ""
+		return result, true
+	}
+	exitCode := toolbox.AsInt(strings.TrimSpace(statusLine))
+	result.exitCode = &exitCode
+	return result, true
+}
+
+//stripMarkers removes marker lines left by previous commands
+func (s *captureSession) stripMarkers(text string) string {
+	for {
+		index := strings.Index(text, s.prefix)
+		if index == -1 {
+			return text
+		}
+		end := strings.Index(text[index:], "\n")
+		if end == -1 {
+			return strings.TrimRight(text[:index], "\r\n")
+		}
+		text = text[:index] + text[index+end+1:]
+	}
+}
+
+//filter returns listener not notified with marker lines
+func (s *captureSession) filter(listener ssh.Listener) ssh.Listener {
+	return func(stdout string, hasMore bool) {
+		listener(s.stripMarkers(stdout), hasMore)
+	}
+}
+
+//Reconnect reconnects session, pending command is abandoned
+func (s *captureSession) Reconnect() error {
+	s.mux.Lock()
+	s.pending = ""
+	s.mux.Unlock()
+	return s.MultiCommandSession.Reconnect()
+}
@@ -0,0 +1,112 @@
+package exec
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/ssh"
+	"strings"
+	"testing"
+)
+
+//scriptedSession represents multi command session replying with scripted outputs
+type scriptedSession struct {
+	ssh.MultiCommandSession
+	inputs  []string
+	outputs []string
+}
+
+func (s *scriptedSession) Run(command string, listener ssh.Listener, timeoutMs int, terminators ...string) (string, error) {
+	s.inputs = append(s.inputs, command)
+	output := s.outputs[0]
+	s.outputs = s.outputs[1:]
+	return output, nil
+}
+
+func TestCaptureSession_RunWithResult(t *testing.T) {
+	local := &localSession{shell: localShell("")}
+	if !assert.Nil(t, local.start()) {
+		return
+	}
+	defer local.Close()
+	session := newCaptureSession(local)
+
+	var useCases = []struct {
+		description string
+		command     string
+		output      string
+		stderr      string
+		exitCode    int
+	}{
+		{
+			description: "stdout and exit code",
+			command:     "echo out",
+			output:      "out",
+		},
+		{
+			description: "stderr is captured separately",
+			command:     "echo out; echo err >&2; false",
+			output:      "out\nerr",
+			stderr:      "err",
+			exitCode:    1,
+		},
+		{
+			description: "background command",
+			command:     "sleep 0 &",
+		},
+		{
+			description: "exit status is kept for the next command",
+			command:     "sh -c 'exit 7'",
+			exitCode:    7,
+		},
+		{
+			description: "exit status is kept for the next command",
+			command:     "echo $?",
+			output:      "7",
+		},
+	}
+
+	for _, useCase := range useCases {
+		result, err := session.runWithResult(useCase.command, true, false, nil, 2000)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.Equal(t, useCase.output, result.output, useCase.description)
+		assert.Equal(t, useCase.stderr, result.stderr, useCase.description)
+		if assert.NotNil(t, result.exitCode, useCase.description) {
+			assert.Equal(t, useCase.exitCode, *result.exitCode, useCase.description)
+		}
+	}
+}
+
+func TestCaptureSession_Pending(t *testing.T) {
+	scripted := &scriptedSession{}
+	session := newCaptureSession(scripted)
+	marker := session.prefix + "1"
+	scripted.outputs = []string{"[sudo] Password for endly:", "\nroot\n" + marker + ":0\n"}
+
+	result, err := session.runWithResult("sudo whoami", true, true, nil, 1000, "Password")
+	assert.Nil(t, err)
+	assert.True(t, result.pending)
+	assert.Nil(t, result.exitCode)
+	assert.True(t, strings.HasPrefix(scripted.inputs[0], "sudo whoami; __endly_status=$?"), scripted.inputs[0])
+
+	//password is sent as is and completes pending command
+	result, err = session.runWithResult("secret", false, false, nil, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", scripted.inputs[1])
+	assert.False(t, result.pending)
+	assert.Equal(t, "root", strings.TrimSpace(result.output))
+	if assert.NotNil(t, result.exitCode) {
+		assert.Equal(t, 0, *result.exitCode)
+	}
+}
+
+func TestCaptureSession_NoCapture(t *testing.T) {
+	scripted := &scriptedSession{outputs: []string{"out\nerr\n"}}
+	session := newCaptureSession(scripted)
+	result, err := session.runWithResult("echo out; echo err >&2", false, false, nil, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, "echo out; echo err >&2", scripted.inputs[0], "command without checkError or expectExitCode is not wrapped")
+	assert.Equal(t, "out\nerr\n", result.output)
+	assert.Nil(t, result.exitCode)
+	assert.Equal(t, "", result.stderr)
+}
@@ -16,16 +16,17 @@ var CommandErrors = []string{util.CommandNotFound, util.NoSuchFileOrDirectory, u
 
 //Options represents an execution options
 type Options struct {
-	SystemPaths []string          `description:"path that will be appended to the current SSH execution session the current and future commands"`                                                //path that will be added to the system paths
-	Terminators []string          `description:"fragment that helps identify that command has been completed - the best is to leave it empty, which is the detected bash prompt"`                //fragment that helps identify that command has been completed - the best is to leave it empty, which is the detected bash prompt
-	Errors      []string          `description:"fragments that will terminate execution with error if matched with standard output, in most cases leave empty"`                                  //fragments that will terminate execution with error if matched with standard output
-	TimeoutMs   int               `description:"time after command was issued for waiting for command output if expect fragment were not matched"`                                               //time after command was issued for waiting for command output if expect fragment were not matched.
-	Directory   string            `description:"directory where this command should start - if does not exists there is no exception"`                                                           //directory where command should run
-	Env         map[string]string `description:"environment variables to be set before command runs"`                                                                                            //environment variables to be set before command runs
-	SuperUser   bool              `description:"flag to run as super user, in this case sudo will be added to all individual commands unless present, and Target.Secrets password will be used"` ///flag to run it as super user
-	Secrets     secret.Secrets    `description:"secrets map see https://github.com/viant/toolbox/tree/master/secret"`
-	CheckError  bool              `description:"check after command execution if status is <> 0, then throws error"`
-	AutoSudo    bool              `description:"when this flag is set, in case of permission denied error for non root user retry command with sudo"`
+	SystemPaths    []string          `description:"path that will be appended to the current SSH execution session the current and future commands"`                                                //path that will be added to the system paths
+	Terminators    []string          `description:"fragment that helps identify that command has been completed - the best is to leave it empty, which is the detected bash prompt"`                //fragment that helps identify that command has been completed - the best is to leave it empty, which is the detected bash prompt
+	Errors         []string          `description:"fragments that will terminate execution with error if matched with standard output, in most cases leave empty"`                                  //fragments that will terminate execution with error if matched with standard output
+	TimeoutMs      int               `description:"time after command was issued for waiting for command output if expect fragment were not matched"`                                               //time after command was issued for waiting for command output if expect fragment were not matched.
+	Directory      string            `description:"directory where this command should start - if does not exists there is no exception"`                                                           //directory where command should run
+	Env            map[string]string `description:"environment variables to be set before command runs"`                                                                                            //environment variables to be set before command runs
+	SuperUser      bool              `description:"flag to run as super user, in this case sudo will be added to all individual commands unless present, and Target.Secrets password will be used"` ///flag to run it as super user
+	Secrets        secret.Secrets    `description:"secrets map see https://github.com/viant/toolbox/tree/master/secret"`
+	CheckError     bool              `description:"check after command execution if status is <> 0, then throws error"`
+	ExpectExitCode *int              `description:"expected command exit code, command with other exit code throws error"`
+	AutoSudo       bool              `description:"when this flag is set, in case of permission denied error for non root user retry command with sudo"`
 }
 
 //DefaultOptions creates a default execution options
@@ -58,13 +59,14 @@ func NewOptions(secrets, env map[string]string, terminators, path []string, supe
 
 //Extracts represents an execution instructions
 type ExtractCommand struct {
-	When        string         `description:"only run this command is criteria is matched i.e $stdout:/password/"`                                              //only run this execution is output from a previous command is matched
-	Command     string         `required:"true" description:"shell command to be executed"`                                                                     //command to be executed
-	Extract     model.Extracts `description:"stdout data extraction instruction"`                                                                               //Stdout data extraction instruction
-	Errors      []string       `description:"fragments that will terminate execution with error if matched with standard output, in most cases leave empty"`    //fragments that will terminate execution with error if matched with standard output
-	Success     []string       `description:"if specified absence of all of the these fragment will terminate execution with error, in most cases leave empty"` //if specified absence of all of the these fragment will terminate execution with error.
-	Terminators []string       `description:"terminators"`
-	TimeoutMs   int            `description:"timeoutMs stdout wait timeout "`
+	When           string         `description:"only run this command is criteria is matched i.e $stdout:/password/"`                                              //only run this execution is output from a previous command is matched
+	Command        string         `required:"true" description:"shell command to be executed"`                                                                     //command to be executed
+	Extract        model.Extracts `description:"stdout data extraction instruction"`                                                                               //Stdout data extraction instruction
+	Errors         []string       `description:"fragments that will terminate execution with error if matched with standard output, in most cases leave empty"`    //fragments that will terminate execution with error if matched with standard output
+	Success        []string       `description:"if specified absence of all of the these fragment will terminate execution with error, in most cases leave empty"` //if specified absence of all of the these fragment will terminate execution with error.
+	Terminators    []string       `description:"terminators"`
+	TimeoutMs      int            `description:"timeoutMs stdout wait timeout "`
+	ExpectExitCode *int           `description:"expected command exit code, overrides request level ExpectExitCode"`
 }
 
 func (c *ExtractCommand) Init() error {
@@ -267,9 +269,12 @@ func NewRunRequestFromURL(URL string) (*RunRequest, error) {
 
 //Log represents an executed command with Stdin, Stdout or Error
 type Log struct {
-	Stdin  string
-	Stdout string
-	Error  string
+	Stdin       string
+	Stdout      string
+	Stderr      string `description:"command stderr captured by local session, stdout keeps terminal output with stderr as SSH session does"`
+	ExitCode    *int   `description:"command exit code, captured by local session, or by SSH session with CheckError or ExpectExitCode"`
+	TimeTakenMs int
+	Error       string
 }
 
 //RunResponse represents a command response with logged commands.
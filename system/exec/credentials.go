@@ -35,14 +35,14 @@ func SetDefaultTarget(context *endly.Context, target *url.Resource) {
 }
 
 func initDefaultTarget() {
+	defaultTarget = url.NewResource(defaultTargetURL)
 	privateKeyPath := path.Join(os.Getenv("HOME"), "/.secret/id_rsa")
 	if !toolbox.FileExists(privateKeyPath) {
 		privateKeyPath = path.Join(os.Getenv("HOME"), "/.ssh/id_rsa")
 		if !toolbox.FileExists(privateKeyPath) {
-			return
+			return //no credentials, local exec session is used
 		}
 	}
-	defaultTarget = url.NewResource(defaultTargetURL)
 	if defaultTarget.Credentials == "" {
 		config := cred.Config{
 			Username:       os.Getenv("USER"),
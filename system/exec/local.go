@@ -0,0 +1,392 @@
+package exec
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/ssh"
+	"github.com/viant/toolbox/url"
+	cssh "golang.org/x/crypto/ssh"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultLocalShell       = "/bin/bash"
+	fallbackLocalShell      = "/bin/sh"
+	localDefaultTimeoutMs   = 20000
+	localTerminatorWaitMs   = 100
+	localSessionMarkerInfix = "_endly_done_"
+)
+
+//isLocalTarget returns true if target is local and can be executed without SSH, localhost with non default SSH port
+//(i.e. sshd published by a container) still uses SSH
+func isLocalTarget(context *endly.Context, target *url.Resource) bool {
+	if target == nil || target.ParsedURL == nil {
+		return false
+	}
+	if target.ParsedURL.Scheme != "file" {
+		switch target.ParsedURL.Hostname() {
+		case "localhost", "127.0.0.1", "::1":
+		default:
+			return false
+		}
+		if port := target.ParsedURL.Port(); port != "" && port != "22" {
+			return false
+		}
+	}
+	config, _ := context.Secrets.GetCredentials(target.Credentials)
+	if config == nil || config.Username == "" {
+		return true
+	}
+	current, err := user.Current()
+	return err == nil && current.Username == config.Username
+}
+
+//localService represents ssh.Service implementation running commands with a local shell process
+type localService struct{}
+
+//Client returns nil as local service does not use SSH client
+func (s *localService) Client() *cssh.Client {
+	return nil
+}
+
+//OpenMultiCommandSession opens a persistent local shell session
+func (s *localService) OpenMultiCommandSession(config *ssh.SessionConfig) (ssh.MultiCommandSession, error) {
+	shell := ""
+	var env map[string]string
+	if config != nil {
+		shell = config.Shell
+		env = config.EnvVariables
+	}
+	session := &localSession{shell: localShell(shell), env: env}
+	return session, session.start()
+}
+
+//Run runs supplied command
+func (s *localService) Run(command string) error {
+	return exec.Command(localShell(""), "-c", command).Run()
+}
+
+//Upload writes content to specified destination
+func (s *localService) Upload(destination string, mode os.FileMode, content []byte) error {
+	parent, _ := path.Split(destination)
+	if parent != "" {
+		if err := os.MkdirAll(parent, 0744); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(destination, content, mode)
+}
+
+//Download reads content from specified source.
+func (s *localService) Download(source string) ([]byte, error) {
+	return ioutil.ReadFile(source)
+}
+
+//OpenTunnel returns error as tunnel is not supported by local service
+func (s *localService) OpenTunnel(localAddress, remoteAddress string) error {
+	return fmt.Errorf("tunnel is not supported by local exec session: %v -> %v", localAddress, remoteAddress)
+}
+
+//NewSession returns error as SSH session is not supported by local service
+func (s *localService) NewSession() (*cssh.Session, error) {
+	return nil, fmt.Errorf("SSH session is not supported by local exec session")
+}
+
+//Close closes service
+func (s *localService) Close() error {
+	return nil
+}
+
+func localShell(shell string) string {
+	if shell != "" {
+		return shell
+	}
+	if toolbox.FileExists(defaultLocalShell) {
+		return defaultLocalShell
+	}
+	return fallbackLocalShell
+}
+
+//localFragment represents local shell output fragment
+type localFragment struct {
+	text   string
+	stderr bool
+}
+
+//localStream represents local shell output stream of the current command
+type localStream struct {
+	pending string
+	output  string
+	status  string
+	done    bool
+}
+
+//consume moves pending output up to command marker to stream output, output of previously abandoned commands is discarded
+func (s *localStream) consume(prefix, marker string) (accepted string, reset bool) {
+	for {
+		index := strings.Index(s.pending, prefix)
+		if index == -1 {
+			keep := partialSuffix(s.pending, prefix)
+			accepted += s.pending[:len(s.pending)-keep]
+			s.pending = s.pending[len(s.pending)-keep:]
+			break
+		}
+		end := strings.Index(s.pending[index:], "\n")
+		accepted += s.pending[:index]
+		if end == -1 {
+			s.pending = s.pending[index:]
+			break
+		}
+		current := s.pending[index : index+end]
+		s.pending = s.pending[index+end+1:]
+		status := ""
+		if statusIndex := strings.Index(current, ":"); statusIndex != -1 {
+			current, status = current[:statusIndex], current[statusIndex+1:]
+		}
+		if current == marker {
+			s.done, s.status = true, status
+			break
+		}
+		s.output, accepted, reset = "", "", true
+	}
+	s.output += accepted
+	return accepted, reset
+}
+
+//localSession represents a multi command session backed by a persistent local shell process,
+//each command is followed by marker commands which capture command exit status and stderr
+type localSession struct {
+	shell   string
+	env     map[string]string
+	prompt  string
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	output  chan *localFragment
+	running int32
+	seq     int64
+	stdout  *localStream
+	stderr  *localStream
+	mux     sync.Mutex
+}
+
+func (s *localSession) start() error {
+	s.prompt = toolbox.AsString(time.Now().UnixNano()) + "$"
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	s.cmd = exec.Command(s.shell)
+	s.cmd.Env = os.Environ()
+	if os.Getenv("USER") == "" {
+		if current, err := user.Current(); err == nil {
+			s.cmd.Env = append(s.cmd.Env, "USER="+current.Username)
+		}
+	}
+	for k, v := range s.env {
+		s.cmd.Env = append(s.cmd.Env, k+"="+v)
+	}
+	s.cmd.Stdout = stdoutWriter
+	s.cmd.Stderr = stderrWriter
+	if s.stdin, err = s.cmd.StdinPipe(); err != nil {
+		return err
+	}
+	if err = s.cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %v shell, %v", s.shell, err)
+	}
+	_ = stdoutWriter.Close()
+	_ = stderrWriter.Close()
+	s.output = make(chan *localFragment, 64)
+	s.stdout, s.stderr = &localStream{}, &localStream{}
+	atomic.StoreInt32(&s.running, 1)
+	group := &sync.WaitGroup{}
+	group.Add(2)
+	go s.read(stdoutReader, false, s.output, group)
+	go s.read(stderrReader, true, s.output, group)
+	go func(cmd *exec.Cmd, output chan *localFragment) {
+		group.Wait()
+		_ = cmd.Wait()
+		close(output)
+	}(s.cmd, s.output)
+	return nil
+}
+
+func (s *localSession) read(reader io.ReadCloser, stderr bool, output chan *localFragment, group *sync.WaitGroup) {
+	defer group.Done()
+	defer reader.Close()
+	buf := make([]byte, 128*1024)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			output <- &localFragment{text: string(buf[:n]), stderr: stderr}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *localSession) marker(seq int64) string {
+	return s.prompt + localSessionMarkerInfix + toolbox.AsString(seq)
+}
+
+//Run runs command and returns its combined stdout and stderr
+func (s *localSession) Run(command string, listener ssh.Listener, timeoutMs int, terminators ...string) (string, error) {
+	result, err := s.runWithResult(command, false, false, listener, timeoutMs, terminators...)
+	if result == nil {
+		return "", err
+	}
+	return result.output, err
+}
+
+//runWithResult runs command, it returns once command completed, a terminator was matched with no more output,
+//or no output was produced within timeoutMs, exit code is only set for completed command, stderr is always read from its own pipe,
+//so exit code and stderr are captured regardless of capture flag
+func (s *localSession) runWithResult(command string, capture, interactive bool, listener ssh.Listener, timeoutMs int, terminators ...string) (*commandResult, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if atomic.LoadInt32(&s.running) == 0 {
+		return nil, ssh.ErrTerminated
+	}
+	if timeoutMs == 0 {
+		timeoutMs = localDefaultTimeoutMs
+	}
+	seq := atomic.AddInt64(&s.seq, 1)
+	marker := s.marker(seq)
+	command = strings.TrimRight(command, "\n")
+	input := command + "\n" + fmt.Sprintf("__endly_status=$?; printf '%%s\\n' '%v' >&2; printf '%%s:%%s\\n' '%v' \"$__endly_status\"; (exit $__endly_status)\n", marker, marker)
+	if _, err := s.stdin.Write([]byte(input)); err != nil {
+		return nil, fmt.Errorf("failed to execute command: %v, err: %v", command, err)
+	}
+	s.stdout.output, s.stdout.done, s.stdout.status = "", false, ""
+	s.stderr.output, s.stderr.done = "", false
+	prefix := s.prompt + localSessionMarkerInfix
+	var output = ""
+	var notified = 0
+	notify := func(hasMore bool) {
+		if listener == nil {
+			return
+		}
+		if fragment := output[notified:]; fragment != "" || !hasMore {
+			notified = len(output)
+			listener(fragment, hasMore)
+		}
+	}
+	timeout := time.Duration(timeoutMs) * time.Millisecond
+	for {
+		wait := timeout
+		if hasLocalTerminator(output, terminators) {
+			wait = localTerminatorWaitMs * time.Millisecond
+		}
+		select {
+		case fragment, ok := <-s.output:
+			if !ok {
+				notify(false)
+				return s.result(output), ssh.ErrTerminated
+			}
+			stream := s.stdout
+			if fragment.stderr {
+				stream = s.stderr
+			}
+			stream.pending += fragment.text
+			accepted, reset := stream.consume(prefix, marker)
+			if reset {
+				output, notified = s.stdout.output+s.stderr.output, 0
+			} else {
+				output += accepted
+			}
+			if s.stdout.done && s.stderr.done {
+				notify(false)
+				result := s.result(output)
+				exitCode := toolbox.AsInt(s.stdout.status)
+				result.exitCode = &exitCode
+				return result, nil
+			}
+			notify(true)
+		case <-time.After(wait):
+			notify(false)
+			return s.result(output), nil
+		}
+	}
+}
+
+func (s *localSession) result(output string) *commandResult {
+	return &commandResult{
+		output: strings.TrimRight(output, "\n"),
+		stderr: strings.TrimRight(s.stderr.output, "\n"),
+	}
+}
+
+//partialSuffix returns length of text suffix matching prefix beginning
+func partialSuffix(text, prefix string) int {
+	for i := len(prefix); i > 0; i-- {
+		if strings.HasSuffix(text, prefix[:i]) {
+			return i
+		}
+	}
+	return 0
+}
+
+func hasLocalTerminator(output string, terminators []string) bool {
+	if output == "" {
+		return false
+	}
+	output = strings.Trim(output, "\n\r\t ")
+	for _, candidate := range terminators {
+		candidateLen := len(candidate)
+		if candidateLen == 0 {
+			continue
+		}
+		if candidate[0:1] == "^" && strings.HasPrefix(output, candidate[1:]) {
+			return true
+		}
+		if candidate[candidateLen-1:] == "$" && strings.HasSuffix(output, candidate[:candidateLen-1]) {
+			return true
+		}
+		if strings.Contains(output, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+//ShellPrompt returns session unique shell prompt
+func (s *localSession) ShellPrompt() string {
+	return s.prompt
+}
+
+//System returns a system name
+func (s *localSession) System() string {
+	return runtime.GOOS
+}
+
+//Reconnect restarts local shell process
+func (s *localSession) Reconnect() error {
+	s.Close()
+	return s.start()
+}
+
+//Close terminates local shell process
+func (s *localSession) Close() {
+	atomic.StoreInt32(&s.running, 0)
+	if s.stdin != nil {
+		_ = s.stdin.Close()
+	}
+	if s.cmd != nil && s.cmd.Process != nil {
+		_ = s.cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,144 @@
+package exec_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/system/exec"
+	"github.com/viant/toolbox/cred"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestLocalSession(t *testing.T) {
+	directory, err := ioutil.TempDir("", "local_exec")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+
+	manager := endly.New()
+	context := manager.NewContext(nil)
+	defer context.Close()
+	target := url.NewResource("file:///")
+
+	{ //env, directory and extraction
+		request := exec.NewExtractRequest(target, exec.DefaultOptions(),
+			exec.NewExtractCommand("export APP_NAME=local", "", nil, nil),
+			exec.NewExtractCommand("cd "+directory, "", nil, nil),
+			exec.NewExtractCommand("echo $APP_NAME > app.txt && pwd && cat app.txt", "", nil, nil,
+				&model.Extract{Key: "name", RegExpr: "^(local)$"}),
+		)
+		response := &exec.RunResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		assert.True(t, strings.Contains(response.Output, directory), response.Output)
+		assert.Equal(t, "local", response.Data.GetString("name"))
+		content, err := ioutil.ReadFile(path.Join(directory, "app.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "local\n", string(content))
+	}
+
+	{ //session state is kept between requests
+		response := &exec.RunResponse{}
+		if !assert.Nil(t, endly.Run(context, exec.NewRunRequest(target, false, "echo $APP_NAME:$(pwd)"), response)) {
+			return
+		}
+		assert.Equal(t, "local:"+directory, strings.TrimSpace(response.Output))
+	}
+
+	{ //stderr is part of output
+		request := exec.NewExtractRequest(target, exec.DefaultOptions(),
+			exec.NewExtractCommand("ls /endly/no/such/dir", "", nil, []string{"No such file or directory"}))
+		err := endly.Run(context, request, &exec.RunResponse{})
+		assert.NotNil(t, err)
+	}
+
+	{ //check error with exit code
+		request := exec.NewRunRequest(target, false, "sh -c 'exit 3'")
+		request.CheckError = true
+		err := endly.Run(context, request, &exec.RunResponse{})
+		if assert.NotNil(t, err) {
+			assert.True(t, strings.Contains(err.Error(), "exit code: 3"), err.Error())
+		}
+		request = exec.NewRunRequest(target, false, "true")
+		request.CheckError = true
+		assert.Nil(t, endly.Run(context, request, &exec.RunResponse{}))
+	}
+
+	{ //exit code, stderr and expected exit code
+		request := exec.NewExtractRequest(target, exec.DefaultOptions(),
+			exec.NewExtractCommand("echo out; echo err >&2; sh -c 'exit 2'", "", nil, nil))
+		exitCode := 2
+		request.Commands[0].ExpectExitCode = &exitCode
+		response := &exec.RunResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		log := response.Cmd[0]
+		assert.True(t, strings.Contains(log.Stdout, "out"), log.Stdout)
+		assert.True(t, strings.Contains(log.Stdout, "err"), log.Stdout)
+		assert.Equal(t, "err", log.Stderr)
+		if assert.NotNil(t, log.ExitCode) {
+			assert.Equal(t, 2, *log.ExitCode)
+		}
+		assert.True(t, log.TimeTakenMs >= 0)
+
+		exitCode = 0
+		request = exec.NewExtractRequest(target, exec.DefaultOptions(),
+			exec.NewExtractCommand("echo failed >&2; false", "", nil, nil))
+		request.ExpectExitCode = &exitCode
+		err := endly.Run(context, request, &exec.RunResponse{})
+		if assert.NotNil(t, err) {
+			assert.True(t, strings.Contains(err.Error(), "expected exit code: 0, but had: 1"), err.Error())
+			assert.True(t, strings.Contains(err.Error(), "failed"), err.Error())
+		}
+	}
+
+	{ //terminator completes long running command
+		request := exec.NewExtractRequest(target, exec.DefaultOptions(),
+			&exec.ExtractCommand{Command: "echo started; sleep 1; echo finished", Terminators: []string{"started"}},
+			&exec.ExtractCommand{Command: "echo next"},
+		)
+		response := &exec.RunResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		assert.Equal(t, "started", strings.TrimSpace(response.Stdout(0)))
+		assert.Equal(t, "next", strings.TrimSpace(response.Stdout(1)))
+	}
+}
+
+func TestLocalSession_NonDefaultPort(t *testing.T) {
+	directory, err := ioutil.TempDir("", "local_exec")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	current, err := user.Current()
+	if !assert.Nil(t, err) {
+		return
+	}
+	credentials := path.Join(directory, "localhost.json")
+	assert.Nil(t, (&cred.Config{Username: current.Username, Password: "***"}).Save(credentials))
+
+	manager := endly.New()
+	context := manager.NewContext(nil)
+	defer context.Close()
+	//localhost with non default SSH port (i.e. sshd published by a container) has to use SSH
+	request := exec.NewOpenSessionRequest(url.NewResource("ssh://127.0.0.1:2222", credentials), nil, nil, true, "")
+	err = endly.Run(context, request, &exec.OpenSessionResponse{})
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "127.0.0.1:2222"), err.Error())
+	}
+	for _, URL := range []string{"ssh://127.0.0.1:22", "ssh://localhost", "file:///"} {
+		response := &exec.OpenSessionResponse{}
+		assert.Nil(t, endly.Run(context, exec.NewOpenSessionRequest(url.NewResource(URL, credentials), nil, nil, false, ""), response), URL)
+	}
+}
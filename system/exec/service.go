@@ -15,6 +15,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 )
 
 //ServiceID represent system executor service id
@@ -46,6 +47,9 @@ func (s *execService) openSSHService(context *endly.Context, request *OpenSessio
 	if err != nil {
 		return nil, err
 	}
+	if isLocalTarget(context, target) {
+		return &localService{}, nil
+	}
 	authConfig, err := context.Secrets.GetOrCreate(target.Credentials)
 	if err != nil {
 		return nil, err
@@ -120,6 +124,9 @@ func (s *execService) openSession(context *endly.Context, request *OpenSessionRe
 	if err != nil {
 		return nil, err
 	}
+	if _, native := SSHSession.MultiCommandSession.(resultRunner); !native && request.ReplayService == nil && replayCommands == nil {
+		SSHSession.MultiCommandSession = newCaptureSession(SSHSession.MultiCommandSession)
+	}
 	if !request.Transient {
 		context.Deffer(func() {
 			_, _ = s.closeSession(context, &CloseSessionRequest{
@@ -202,14 +209,45 @@ func (s *execService) changeDirectory(context *endly.Context, session *model.Ses
 	return result, err
 }
 
-func (s *execService) run(context *endly.Context, session *model.Session, command string, listener ssh.Listener, timeoutMs int, terminators ...string) (stdout string, err error) {
-	if stdout, err = session.Run(command, listener, timeoutMs, terminators...); err == nil {
-		return stdout, err
+//commandResult represents command result, stderr and exit code are only captured by sessions implementing resultRunner
+type commandResult struct {
+	output   string //stdout and stderr as shown by terminal
+	stderr   string
+	exitCode *int
+	pending  bool //command waits for further input
+}
+
+//resultRunner represents session capturing command stderr and exit code natively, capture flags command
+//that requires exit code (checkError or expectExitCode), interactive command may prompt on stderr, so its stderr is kept on terminal
+type resultRunner interface {
+	runWithResult(command string, capture, interactive bool, listener ssh.Listener, timeoutMs int, terminators ...string) (*commandResult, error)
+}
+
+func sessionRun(session *model.Session, command string, capture, interactive bool, listener ssh.Listener, timeoutMs int, terminators ...string) (*commandResult, error) {
+	if runner, ok := session.MultiCommandSession.(resultRunner); ok {
+		result, err := runner.runWithResult(command, capture, interactive, listener, timeoutMs, terminators...)
+		if result == nil {
+			result = &commandResult{}
+		}
+		return result, err
+	}
+	output, err := session.Run(command, listener, timeoutMs, terminators...)
+	return &commandResult{output: output}, err
+}
+
+func (s *execService) run(context *endly.Context, session *model.Session, command string, listener ssh.Listener, timeoutMs int, terminators ...string) (string, error) {
+	result, err := s.runWithResult(context, session, command, false, false, listener, timeoutMs, terminators...)
+	return result.output, err
+}
+
+func (s *execService) runWithResult(context *endly.Context, session *model.Session, command string, capture, interactive bool, listener ssh.Listener, timeoutMs int, terminators ...string) (result *commandResult, err error) {
+	if result, err = sessionRun(session, command, capture, interactive, listener, timeoutMs, terminators...); err == nil {
+		return result, err
 	}
 	if err == ssh.ErrTerminated {
 		err := session.Reconnect()
 		if err != nil {
-			return "", err
+			return &commandResult{}, err
 		}
 		currentDirectory := session.CurrentDirectory
 		env := session.EnvVariables
@@ -220,9 +258,9 @@ func (s *execService) run(context *endly.Context, session *model.Session, comman
 		}
 		runResponse := &RunResponse{}
 		_, _ = s.changeDirectory(context, session, runResponse, currentDirectory)
-		return session.Run(command, listener, timeoutMs, terminators...)
+		return sessionRun(session, command, capture, interactive, listener, timeoutMs, terminators...)
 	}
-	return stdout, err
+	return result, err
 }
 
 func (s *execService) rumCommandTemplate(context *endly.Context, session *model.Session, commandTemplate string, arguments ...interface{}) (string, error) {
@@ -306,6 +344,36 @@ func (s *execService) authSuperUserIfNeeded(stdout string, context *endly.Contex
 	return err
 }
 
+//authLocalSuperUserIfNeeded authenticates sudo for local session, local shell has no terminal to prompt for password,
+//so sudo credentials are validated with password read from stdin, subsequent sudo commands use cached credentials
+func (s *execService) authLocalSuperUserIfNeeded(context *endly.Context, session *model.Session, request *ExtractRequest) error {
+	if _, ok := session.MultiCommandSession.(*localSession); !ok || session.SuperUSerAuth || session.Username == "root" {
+		return nil
+	}
+	result, err := s.runWithResult(context, session, "sudo -n true", true, false, nil, request.Options.TimeoutMs)
+	if err == nil && result.exitCode != nil && *result.exitCode == 0 {
+		session.SuperUSerAuth = true
+		return nil
+	}
+	secrets := request.Secrets
+	if len(secrets) == 0 {
+		secrets = secret.NewSecrets(nil)
+		secrets[SudoCredentialKey] = secret.Secret(request.Target.Credentials)
+	}
+	command, err := context.Secrets.Expand(fmt.Sprintf("printf '%%s\\n' '%v' | sudo -S -p '' -v", SudoCredentialKey), secrets)
+	if err != nil {
+		return err
+	}
+	if result, err = s.runWithResult(context, session, command, true, false, nil, request.Options.TimeoutMs); err != nil {
+		return err
+	}
+	if result.exitCode == nil || *result.exitCode != 0 {
+		return fmt.Errorf("failed to authenticate sudo for %v: %v", session.Username, result.stderr)
+	}
+	session.SuperUSerAuth = true
+	return nil
+}
+
 func (s *execService) buildExecutionState(response *RunResponse, context *endly.Context) data.Map {
 	var state = context.State()
 	var result = state.Clone()
@@ -314,6 +382,10 @@ func (s *execService) buildExecutionState(response *RunResponse, context *endly.
 		var cmd = data.NewMap()
 		cmd.Put("stdin", log.Stdin)
 		cmd.Put("stdout", log.Stdout)
+		cmd.Put("stderr", log.Stderr)
+		if log.ExitCode != nil {
+			cmd.Put("exitCode", *log.ExitCode)
+		}
 		commands.Push(cmd)
 	}
 	result.Put("cmd", commands)
@@ -360,6 +432,9 @@ func (s *execService) executeCommand(context *endly.Context, session *model.Sess
 	}
 
 	if isSuperUserCmd {
+		if err = s.authLocalSuperUserIfNeeded(context, session, request); err != nil {
+			return err
+		}
 		if !session.SuperUSerAuth {
 			terminators = append(terminators, "Password")
 		}
@@ -394,7 +469,15 @@ func (s *execService) executeCommand(context *endly.Context, session *model.Sess
 	if extractCommand.TimeoutMs > 0 {
 		timeoutMs = extractCommand.TimeoutMs
 	}
-	stdout, err := s.run(context, session, insecureCommand, listener, timeoutMs, terminators...)
+	startTime := time.Now()
+	expectExitCode := options.ExpectExitCode
+	if extractCommand.ExpectExitCode != nil {
+		expectExitCode = extractCommand.ExpectExitCode
+	}
+	capture := request.CheckError || expectExitCode != nil
+	interactive := len(extractCommand.Terminators) > 0 || len(options.Terminators) > 0 || (isSuperUserCmd && !session.SuperUSerAuth)
+	result, err := s.runWithResult(context, session, insecureCommand, capture, interactive, listener, timeoutMs, terminators...)
+	stdout := result.output
 	if len(response.Output) > 0 {
 		if !strings.HasSuffix(response.Output, "\n") {
 			response.Output += "\n"
@@ -404,7 +487,11 @@ func (s *execService) executeCommand(context *endly.Context, session *model.Sess
 	if request.AutoSudo && !util.IsPermitted(stdout) {
 		commandRetry = true
 		if session.Username != "root" && !strings.HasPrefix(securedCommand, "sudo") {
-			stdout, err = s.retryWithSudo(context, session, insecureCommand, listener, options.TimeoutMs, terminators...)
+			if err = s.authLocalSuperUserIfNeeded(context, session, request); err != nil {
+				return err
+			}
+			result, err = s.retryWithSudo(context, session, insecureCommand, capture, listener, options.TimeoutMs, terminators...)
+			stdout = result.output
 			isSuperUserCmd = true
 		}
 	}
@@ -417,16 +504,26 @@ func (s *execService) executeCommand(context *endly.Context, session *model.Sess
 	}
 	response.Output += stdout
 
-	if request.CheckError && !hasTerminator(stdout, terminators) {
+	if result.exitCode == nil && !result.pending && capture && !hasTerminator(stdout, terminators) {
 		if errorCode, err := s.run(context, session, "echo $?", nil, options.TimeoutMs, terminators...); err == nil {
 			exitStatus := toolbox.AsInt(strings.TrimSpace(errorCode))
-			if exitStatus != 0 {
-				return fmt.Errorf("exit code: %v, command: %v", exitStatus, securedCommand)
-			}
+			result.exitCode = &exitStatus
+		}
+	}
+	if result.exitCode != nil {
+		if request.CheckError && *result.exitCode != 0 {
+			return fmt.Errorf("exit code: %v, command: %v", *result.exitCode, securedCommand)
+		}
+		if expectExitCode != nil && *result.exitCode != *expectExitCode {
+			return fmt.Errorf("expected exit code: %v, but had: %v, command: %v, stderr: %v", *expectExitCode, *result.exitCode, securedCommand, result.stderr)
 		}
 	}
 
-	response.Add(NewCommandLog(securedCommand, stdout, err))
+	log := NewCommandLog(securedCommand, stdout, err)
+	log.Stderr = result.stderr
+	log.ExitCode = result.exitCode
+	log.TimeTakenMs = int(time.Since(startTime) / time.Millisecond)
+	response.Add(log)
 	if err != nil {
 		return err
 	}
@@ -438,10 +535,10 @@ func (s *execService) executeCommand(context *endly.Context, session *model.Sess
 	return extractCommand.Extract.Extract(context, response.Data, strings.Split(stdout, "\n")...)
 }
 
-func (s *execService) retryWithSudo(context *endly.Context, session *model.Session, command string, listener ssh.Listener, timeoutMs int, terminators ...string) (string, error) {
+func (s *execService) retryWithSudo(context *endly.Context, session *model.Session, command string, capture bool, listener ssh.Listener, timeoutMs int, terminators ...string) (*commandResult, error) {
 	terminators = append(terminators, "Password")
 	command = s.commandAsSuperUser(session, command)
-	return s.run(context, session, command, listener, timeoutMs, terminators...)
+	return s.runWithResult(context, session, command, capture, true, listener, timeoutMs, terminators...)
 }
 
 func getTerminators(options *Options, session *model.Session, execution *ExtractCommand) []string {
@@ -556,9 +653,9 @@ func (s *execService) extractOsPath(session *model.Session, os *model.OperatingS
 	if err != nil {
 		return err
 	}
-	lines := strings.Split(output, "\r\n")
+	lines := strings.Split(output, "\n")
 	for i := 0; i < len(lines); i++ {
-		var line = lines[i]
+		var line = strings.TrimSpace(lines[i])
 		if !strings.Contains(line, ":") || !strings.Contains(line, "/") {
 			continue
 		}
@@ -593,9 +690,9 @@ func (s *execService) detectOperatingSystem(session *model.Session) (*model.Oper
 		return nil, err
 	}
 
-	lines := strings.Split(output, "\r\n")
+	lines := strings.Split(output, "\n")
 	for i := 0; i < len(lines); i++ {
-		line := lines[i]
+		line := strings.TrimSpace(lines[i])
 		if isAmd64Architecture(line) {
 			operatingSystem.Architecture = "amd64"
 		}
@@ -0,0 +1,75 @@
+package exec
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox/cred"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+//fakeSudo represents sudo requiring password on stdin and caching credentials once authenticated
+const fakeSudo = `#!/bin/sh
+auth="$(dirname "$0")/auth"
+case "$1" in
+-n) [ -f "$auth" ] && exit 0; exit 1;;
+-S) read password; if [ "$password" = "secret" ]; then touch "$auth"; exit 0; fi; echo "Sorry, try again." >&2; exit 1;;
+esac
+[ -f "$auth" ] || { echo "sudo: a terminal is required to read the password" >&2; exit 1; }
+"$@"
+`
+
+func TestExecService_AuthLocalSuperUser(t *testing.T) {
+	directory, err := ioutil.TempDir("", "local_sudo")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	if !assert.Nil(t, ioutil.WriteFile(path.Join(directory, "sudo"), []byte(fakeSudo), 0755)) {
+		return
+	}
+	local := &localSession{shell: localShell(""), env: map[string]string{"PATH": directory + ":" + os.Getenv("PATH")}}
+	if !assert.Nil(t, local.start()) {
+		return
+	}
+	defer local.Close()
+	session, _ := model.NewSession("local", &localService{})
+	session.MultiCommandSession = local
+	session.Username = "endly"
+
+	service := New().(*execService)
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+
+	for _, useCase := range []struct {
+		password string
+		hasError bool
+	}{
+		{password: "invalid", hasError: true},
+		{password: "secret"},
+	} {
+		credentials := path.Join(directory, useCase.password+".json")
+		assert.Nil(t, (&cred.Config{Username: "endly", Password: useCase.password}).Save(credentials))
+		request := &ExtractRequest{Target: url.NewResource("file:///", credentials), Options: DefaultOptions()}
+		err = service.authLocalSuperUserIfNeeded(context, session, request)
+		if useCase.hasError {
+			if assert.NotNil(t, err) {
+				assert.True(t, strings.Contains(err.Error(), "Sorry, try again."), err.Error())
+			}
+			assert.False(t, session.SuperUSerAuth)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.True(t, session.SuperUSerAuth)
+	}
+
+	result, err := service.runWithResult(context, session, "sudo echo root", false, false, nil, 2000)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "root", result.output)
+	}
+}
@@ -45,6 +45,7 @@ type CreateResponse ResourceInfoResponse
 //DeleteRequest represents delete response
 type DeleteRequest struct {
 	Name            string
+	Namespace       string `description:"resource namespace, default current namespace"`
 	LabelSelector   string
 	metav1.TypeMeta `json:",inline"`
 	*url.Resource
@@ -10,6 +10,7 @@ import (
 	"github.com/viant/toolbox"
 	"io"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	"log"
@@ -97,9 +98,42 @@ func (s *service) createResource(context *endly.Context, createRequest interface
 	createResponse.Name = meta.Metadata.Name
 	createResponse.Labels = meta.Metadata.Labels
 	response.Items = append(response.Items, createResponse)
+	if err = registerResource(context, meta); err != nil {
+		return err
+	}
 	return waitUntilReady(watcher)
 }
 
+//registerResource registers created resource in session ledger, teardown request is bound to resource namespace
+func registerResource(context *endly.Context, meta *ResourceMeta) error {
+	namespace, err := resourceNamespace(context, &meta.Metadata)
+	if err != nil {
+		return err
+	}
+	deleteRequest := &DeleteRequest{Name: meta.Metadata.Name, Namespace: namespace, TypeMeta: meta.TypeMeta}
+	return context.Ledger().Register(ledgerKind(meta.Kind), ledgerName(namespace, meta.Metadata.Name), ServiceID, "delete", deleteRequest)
+}
+
+//resourceNamespace returns resource namespace or current client namespace if resource does not define one
+func resourceNamespace(context *endly.Context, meta *metav1.ObjectMeta) (string, error) {
+	if meta.Namespace != "" {
+		return meta.Namespace, nil
+	}
+	ctxClient, err := shared.GetCtxClient(context)
+	if err != nil {
+		return "", err
+	}
+	return ctxClient.Namespace, nil
+}
+
+func ledgerKind(kind string) string {
+	return ServiceID + "/" + kind
+}
+
+func ledgerName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
 //Apply create or apply patch for specified resources
 func (s *service) Apply(context *endly.Context, request *ApplyRequest) (*ApplyResponse, error) {
 	response := &ApplyResponse{
@@ -150,7 +184,7 @@ func (s *service) appleResource(context *endly.Context, request *ApplyRequest, r
 			return err
 		}
 		response.Items = append(response.Items, createResponse)
-		return nil
+		return registerResource(context, meta)
 	}
 
 	pathData, err := NewResourcePatch(meta, getResponse, createRequest)
@@ -255,6 +289,13 @@ func (s *service) deleteResource(context *endly.Context, request *DeleteRequest,
 	if err = endly.RunWithoutLogging(context, deleteRequest, nil); err != nil {
 		return err
 	}
+	namespace, err := resourceNamespace(context, &item.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	if err = context.Ledger().Release(ledgerKind(item.Kind), ledgerName(namespace, item.Name)); err != nil {
+		return err
+	}
 	return s.waitForNotFound(context, getRequest, request.TimeoutMs)
 }
 
@@ -0,0 +1,157 @@
+package proxy
+
+import (
+	"fmt"
+)
+
+const defaultListen = "127.0.0.1:0"
+
+//Conditions represents simulated network conditions, changes apply to open connections
+type Conditions struct {
+	LatencyMs    int     `description:"delay added to each data chunk in both directions, it does not limit throughput"`
+	JitterMs     int     `description:"random latency variation in range [-jitterMs, jitterMs]"`
+	BandwidthKB  int     `description:"bandwidth limit in KB/s per connection and direction, 0 - unlimited"`
+	DropRate     float64 `description:"probability in range [0, 1] of resetting a new connection"`
+	LossRate     float64 `description:"probability in range [0, 1] of losing a data chunk, lost chunk is delivered after retransmitMs stalling following data, data is never discarded"`
+	RetransmitMs int     `description:"delay added to a lost chunk to simulate its retransmission, default 200"`
+	Blackhole    bool    `description:"if set, data is silently discarded while connections stay open, i.e. to simulate packet loss or unresponsive dependency"`
+}
+
+//Validate checks if conditions are valid
+func (c *Conditions) Validate() error {
+	if c.LatencyMs < 0 || c.JitterMs < 0 || c.BandwidthKB < 0 || c.RetransmitMs < 0 {
+		return fmt.Errorf("latencyMs, jitterMs, bandwidthKB and retransmitMs can not be negative")
+	}
+	if c.DropRate < 0 || c.DropRate > 1 {
+		return fmt.Errorf("invalid dropRate: %v, expected value in range [0, 1]", c.DropRate)
+	}
+	if c.LossRate < 0 || c.LossRate > 1 {
+		return fmt.Errorf("invalid lossRate: %v, expected value in range [0, 1]", c.LossRate)
+	}
+	return nil
+}
+
+//Info represents proxy info
+type Info struct {
+	Name          string
+	Address       string `description:"proxy listening address"`
+	Upstream      string
+	Down          bool
+	Conditions    *Conditions
+	Active        int   `description:"active connections"`
+	Accepted      int64 `description:"accepted connections"`
+	Dropped       int64 `description:"dropped connections"`
+	LostChunks    int64 `description:"data chunks delayed by simulated loss"`
+	SentBytes     int64 `description:"bytes sent to upstream"`
+	ReceivedBytes int64 `description:"bytes received from upstream"`
+}
+
+//StartRequest represents start proxy request
+type StartRequest struct {
+	Name       string `description:"proxy name, default upstream address"`
+	Listen     string `description:"local [host]:[port] proxy address, default 127.0.0.1:0 (any free port)"`
+	Upstream   string `required:"true" description:"dependency [host]:[port] address"`
+	Conditions *Conditions
+}
+
+//Init initializes request
+func (r *StartRequest) Init() error {
+	if r.Listen == "" {
+		r.Listen = defaultListen
+	}
+	if r.Name == "" {
+		r.Name = r.Upstream
+	}
+	if r.Conditions == nil {
+		r.Conditions = &Conditions{}
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *StartRequest) Validate() error {
+	if r.Upstream == "" {
+		return fmt.Errorf("upstream was empty")
+	}
+	return r.Conditions.Validate()
+}
+
+//StartResponse represents start proxy response
+type StartResponse Info
+
+//SetRequest represents update proxy conditions request
+type SetRequest struct {
+	Name       string `required:"true"`
+	Conditions *Conditions
+}
+
+//Init initializes request
+func (r *SetRequest) Init() error {
+	if r.Conditions == nil {
+		r.Conditions = &Conditions{}
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *SetRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name was empty")
+	}
+	return r.Conditions.Validate()
+}
+
+//SetResponse represents update proxy conditions response
+type SetResponse Info
+
+//DownRequest represents link down request, open connections are closed and new connections are refused
+type DownRequest struct {
+	Name string `required:"true"`
+}
+
+//Validate checks if request is valid
+func (r *DownRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name was empty")
+	}
+	return nil
+}
+
+//DownResponse represents link down response
+type DownResponse Info
+
+//UpRequest represents link up request
+type UpRequest struct {
+	Name string `required:"true"`
+}
+
+//Validate checks if request is valid
+func (r *UpRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name was empty")
+	}
+	return nil
+}
+
+//UpResponse represents link up response
+type UpResponse Info
+
+//StatusRequest represents proxy status request
+type StatusRequest struct {
+	Names []string `description:"proxy names, if empty all proxies are returned"`
+}
+
+//StatusResponse represents proxy status response
+type StatusResponse struct {
+	Proxies []*Info
+}
+
+//StopRequest represents stop proxy request
+type StopRequest struct {
+	Names []string `description:"proxy names, if empty all proxies are stopped"`
+}
+
+//StopResponse represents stop proxy response
+type StopResponse struct {
+	Stopped []string
+}
@@ -0,0 +1,312 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	dialTimeout     = 5 * time.Second
+	bufferSize      = 32 * 1024
+	bandwidthWindow = 100 * time.Millisecond
+	queueSize       = 256
+	//defaultRetransmitMs represents default lost chunk retransmission delay, i.e. TCP minimum retransmission timeout
+	defaultRetransmitMs = 200
+)
+
+//Proxy represents a TCP proxy simulating network conditions between client and upstream
+type Proxy struct {
+	name       string
+	upstream   string
+	listener   net.Listener
+	conditions *Conditions
+	conns      map[net.Conn]bool
+	down       int32
+	closed     int32
+	accepted   int64
+	dropped    int64
+	lost       int64
+	sent       int64
+	received   int64
+	random     *rand.Rand
+	mux        sync.Mutex
+}
+
+//Conditions returns current conditions
+func (p *Proxy) Conditions() *Conditions {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.conditions
+}
+
+//SetConditions sets conditions, open connections use them for subsequent data
+func (p *Proxy) SetConditions(conditions *Conditions) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.conditions = conditions
+}
+
+//Down closes open connections and resets new connections till link is up
+func (p *Proxy) Down() {
+	atomic.StoreInt32(&p.down, 1)
+	p.closeConnections()
+}
+
+//Up restores link
+func (p *Proxy) Up() {
+	atomic.StoreInt32(&p.down, 0)
+}
+
+//Info returns proxy info
+func (p *Proxy) Info() *Info {
+	p.mux.Lock()
+	conditions := *p.conditions
+	active := len(p.conns) / 2
+	p.mux.Unlock()
+	return &Info{
+		Name:          p.name,
+		Address:       p.listener.Addr().String(),
+		Upstream:      p.upstream,
+		Down:          atomic.LoadInt32(&p.down) == 1,
+		Conditions:    &conditions,
+		Active:        active,
+		Accepted:      atomic.LoadInt64(&p.accepted),
+		Dropped:       atomic.LoadInt64(&p.dropped),
+		LostChunks:    atomic.LoadInt64(&p.lost),
+		SentBytes:     atomic.LoadInt64(&p.sent),
+		ReceivedBytes: atomic.LoadInt64(&p.received),
+	}
+}
+
+func (p *Proxy) shouldDrop() bool {
+	if atomic.LoadInt32(&p.down) == 1 {
+		return true
+	}
+	conditions := p.Conditions()
+	if conditions.DropRate <= 0 {
+		return false
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.random.Float64() < conditions.DropRate
+}
+
+func (p *Proxy) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		atomic.AddInt64(&p.accepted, 1)
+		if p.shouldDrop() {
+			atomic.AddInt64(&p.dropped, 1)
+			reset(conn)
+			continue
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *Proxy) handle(conn net.Conn) {
+	upstream, err := net.DialTimeout("tcp", p.upstream, dialTimeout)
+	if err != nil {
+		atomic.AddInt64(&p.dropped, 1)
+		reset(conn)
+		return
+	}
+	p.mux.Lock()
+	if atomic.LoadInt32(&p.closed) == 1 || atomic.LoadInt32(&p.down) == 1 {
+		p.mux.Unlock()
+		reset(conn)
+		_ = upstream.Close()
+		return
+	}
+	p.conns[conn] = true
+	p.conns[upstream] = true
+	p.mux.Unlock()
+	defer func() {
+		_ = conn.Close()
+		_ = upstream.Close()
+		p.mux.Lock()
+		delete(p.conns, conn)
+		delete(p.conns, upstream)
+		p.mux.Unlock()
+	}()
+	completed := make(chan error, 2)
+	go func() {
+		completed <- p.transfer(upstream, conn, &p.sent)
+	}()
+	go func() {
+		completed <- p.transfer(conn, upstream, &p.received)
+	}()
+	if err := <-completed; err == nil {
+		//peer closed its side, the other direction runs till the other peer closes too
+		<-completed
+	}
+}
+
+//chunk represents data chunk scheduled for delivery
+type chunk struct {
+	data []byte
+	due  time.Time
+}
+
+//transfer copies data applying current conditions to each chunk, chunks are queued with delivery time,
+//so that latency delays data without limiting throughput, once source is closed queued chunks are delivered
+//before target write side is closed, it returns nil if source was closed or an error if connection failed
+func (p *Proxy) transfer(target, source net.Conn, counter *int64) error {
+	queue := make(chan *chunk, queueSize)
+	done := make(chan error, 1)
+	go p.deliver(target, queue, done, counter)
+	var paced time.Time
+	var err error
+	delivered := false
+	for err == nil {
+		conditions := p.Conditions()
+		size := bufferSize
+		if limit := conditions.BandwidthKB * 1024 * int(bandwidthWindow) / int(time.Second); limit > 0 && limit < size {
+			size = limit
+		}
+		buf := make([]byte, size)
+		var n int
+		n, err = source.Read(buf)
+		if conditions = p.Conditions(); n > 0 && !conditions.Blackhole {
+			now := time.Now()
+			if paced.Before(now) {
+				paced = now
+			}
+			if conditions.BandwidthKB > 0 {
+				paced = paced.Add(time.Duration(n) * time.Second / time.Duration(conditions.BandwidthKB*1024))
+			}
+			if p.shouldLose(conditions) {
+				//lost chunk stalls the stream till it is retransmitted
+				paced = paced.Add(retransmitDelay(conditions))
+			}
+			select {
+			case queue <- &chunk{data: buf[:n], due: paced.Add(p.latency(conditions))}:
+			case err = <-done:
+				delivered = true
+			}
+		}
+	}
+	close(queue)
+	if !delivered {
+		if deliveryErr := <-done; deliveryErr != nil {
+			return deliveryErr
+		}
+	}
+	if err != io.EOF {
+		return err
+	}
+	closeWrite(target)
+	return nil
+}
+
+//deliver writes queued chunks to target once they are due, it reports write error or nil once queue is drained
+func (p *Proxy) deliver(target net.Conn, queue chan *chunk, done chan error, counter *int64) {
+	for item := range queue {
+		if wait := time.Until(item.due); wait > 0 {
+			time.Sleep(wait)
+		}
+		if _, err := target.Write(item.data); err != nil {
+			done <- err
+			return
+		}
+		atomic.AddInt64(counter, int64(len(item.data)))
+	}
+	done <- nil
+}
+
+func retransmitDelay(conditions *Conditions) time.Duration {
+	if conditions.RetransmitMs > 0 {
+		return time.Duration(conditions.RetransmitMs) * time.Millisecond
+	}
+	return defaultRetransmitMs * time.Millisecond
+}
+
+//shouldLose returns true if data chunk is lost and has to be retransmitted
+func (p *Proxy) shouldLose(conditions *Conditions) bool {
+	if conditions.LossRate <= 0 {
+		return false
+	}
+	p.mux.Lock()
+	lost := p.random.Float64() < conditions.LossRate
+	p.mux.Unlock()
+	if lost {
+		atomic.AddInt64(&p.lost, 1)
+	}
+	return lost
+}
+
+//latency returns latency with jitter
+func (p *Proxy) latency(conditions *Conditions) time.Duration {
+	latency := time.Duration(conditions.LatencyMs) * time.Millisecond
+	if conditions.JitterMs > 0 {
+		p.mux.Lock()
+		jitter := p.random.Intn(2*conditions.JitterMs+1) - conditions.JitterMs
+		p.mux.Unlock()
+		latency += time.Duration(jitter) * time.Millisecond
+	}
+	return latency
+}
+
+func (p *Proxy) closeConnections() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for conn := range p.conns {
+		reset(conn)
+	}
+}
+
+//Close stops proxy and closes open connections
+func (p *Proxy) Close() error {
+	if !atomic.CompareAndSwapInt32(&p.closed, 0, 1) {
+		return nil
+	}
+	err := p.listener.Close()
+	p.closeConnections()
+	return err
+}
+
+//closeWrite closes connection write side so that peer reads EOF while it can still send data
+func closeWrite(conn net.Conn) {
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		_ = tcpConn.CloseWrite()
+		return
+	}
+	_ = conn.Close()
+}
+
+//reset closes connection discarding unsent data so that peer receives connection reset
+func reset(conn net.Conn) {
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+//NewProxy starts a new proxy listening on supplied address
+func NewProxy(name, listen, upstream string, conditions *Conditions) (*Proxy, error) {
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %v, %v", listen, err)
+	}
+	if conditions == nil {
+		conditions = &Conditions{}
+	}
+	result := &Proxy{
+		name:       name,
+		upstream:   upstream,
+		listener:   listener,
+		conditions: conditions,
+		conns:      make(map[net.Conn]bool),
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	go result.serve()
+	return result, nil
+}
@@ -0,0 +1,322 @@
+package proxy
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"sort"
+	"sync"
+)
+
+const (
+	//ServiceID represents network proxy service id
+	ServiceID = "network/proxy"
+)
+
+//service represents network proxy service, that simulates network conditions between system under test and its dependency
+type service struct {
+	*endly.AbstractService
+	proxies map[string]*Proxy
+	mux     *sync.Mutex
+}
+
+func (s *service) start(context *endly.Context, request *StartRequest) (*StartResponse, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if proxy, ok := s.proxies[request.Name]; ok {
+		return nil, fmt.Errorf("proxy %v has been already started at %v", request.Name, proxy.listener.Addr())
+	}
+	proxy, err := NewProxy(request.Name, request.Listen, request.Upstream, request.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	s.proxies[request.Name] = proxy
+	context.Deffer(func() {
+		s.stopProxy(proxy)
+	})
+	return (*StartResponse)(proxy.Info()), nil
+}
+
+func (s *service) lookup(name string) (*Proxy, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	proxy, ok := s.proxies[name]
+	if !ok {
+		return nil, fmt.Errorf("proxy %v not found", name)
+	}
+	return proxy, nil
+}
+
+func (s *service) set(context *endly.Context, request *SetRequest) (*SetResponse, error) {
+	proxy, err := s.lookup(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	proxy.SetConditions(request.Conditions)
+	return (*SetResponse)(proxy.Info()), nil
+}
+
+func (s *service) down(context *endly.Context, request *DownRequest) (*DownResponse, error) {
+	proxy, err := s.lookup(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	proxy.Down()
+	return (*DownResponse)(proxy.Info()), nil
+}
+
+func (s *service) up(context *endly.Context, request *UpRequest) (*UpResponse, error) {
+	proxy, err := s.lookup(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	proxy.Up()
+	return (*UpResponse)(proxy.Info()), nil
+}
+
+//selected returns proxies matching supplied names, or all proxies if names are empty
+func (s *service) selected(names []string) []*Proxy {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var result = make([]*Proxy, 0)
+	if len(names) == 0 {
+		for _, proxy := range s.proxies {
+			result = append(result, proxy)
+		}
+	}
+	for _, name := range names {
+		if proxy, ok := s.proxies[name]; ok {
+			result = append(result, proxy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+func (s *service) status(context *endly.Context, request *StatusRequest) (*StatusResponse, error) {
+	var response = &StatusResponse{
+		Proxies: make([]*Info, 0),
+	}
+	for _, proxy := range s.selected(request.Names) {
+		response.Proxies = append(response.Proxies, proxy.Info())
+	}
+	return response, nil
+}
+
+func (s *service) stopProxy(proxy *Proxy) bool {
+	s.mux.Lock()
+	current, has := s.proxies[proxy.name]
+	if has && current == proxy {
+		delete(s.proxies, proxy.name)
+	}
+	s.mux.Unlock()
+	_ = proxy.Close()
+	return has && current == proxy
+}
+
+func (s *service) stop(context *endly.Context, request *StopRequest) (*StopResponse, error) {
+	var response = &StopResponse{
+		Stopped: make([]string, 0),
+	}
+	for _, proxy := range s.selected(request.Names) {
+		if s.stopProxy(proxy) {
+			response.Stopped = append(response.Stopped, proxy.name)
+		}
+	}
+	return response, nil
+}
+
+const proxyStartRequestExample = `{
+	"Name": "db",
+	"Listen": "127.0.0.1:3307",
+	"Upstream": "127.0.0.1:3306",
+	"Conditions": {
+		"LatencyMs": 100,
+		"JitterMs": 20,
+		"BandwidthKB": 512
+	}
+}
+`
+
+const proxySetRequestExample = `{
+	"Name": "db",
+	"Conditions": {
+		"DropRate": 0.3
+	}
+}
+`
+
+const proxyNameRequestExample = `{
+	"Name": "db"
+}
+`
+
+const proxyNamesRequestExample = `{
+	"Names": ["db"]
+}
+`
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "start",
+		RequestInfo: &endly.ActionInfo{
+			Description: "start TCP proxy to upstream dependency with simulated network conditions",
+			Examples: []*endly.UseCase{
+				{
+					Description: "start",
+					Data:        proxyStartRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &StartRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &StartResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*StartRequest); ok {
+				return s.start(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "set",
+		RequestInfo: &endly.ActionInfo{
+			Description: "replace proxy network conditions",
+			Examples: []*endly.UseCase{
+				{
+					Description: "set",
+					Data:        proxySetRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &SetRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &SetResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*SetRequest); ok {
+				return s.set(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "down",
+		RequestInfo: &endly.ActionInfo{
+			Description: "take link down: reset open connections and refuse new ones",
+			Examples: []*endly.UseCase{
+				{
+					Description: "down",
+					Data:        proxyNameRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &DownRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &DownResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*DownRequest); ok {
+				return s.down(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "up",
+		RequestInfo: &endly.ActionInfo{
+			Description: "bring link up",
+			Examples: []*endly.UseCase{
+				{
+					Description: "up",
+					Data:        proxyNameRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &UpRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &UpResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*UpRequest); ok {
+				return s.up(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "status",
+		RequestInfo: &endly.ActionInfo{
+			Description: "return proxy conditions and traffic stats",
+			Examples: []*endly.UseCase{
+				{
+					Description: "status",
+					Data:        proxyNamesRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &StatusRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &StatusResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*StatusRequest); ok {
+				return s.status(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "stop",
+		RequestInfo: &endly.ActionInfo{
+			Description: "stop proxies",
+			Examples: []*endly.UseCase{
+				{
+					Description: "stop",
+					Data:        proxyNamesRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &StopRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &StopResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*StopRequest); ok {
+				return s.stop(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+//New creates a new network proxy service.
+func New() endly.Service {
+	var result = &service{
+		AbstractService: endly.NewAbstractService(ServiceID),
+		proxies:         make(map[string]*Proxy),
+		mux:             &sync.Mutex{},
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
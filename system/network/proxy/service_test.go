@@ -0,0 +1,190 @@
+package proxy_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/network/proxy"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startEchoServer(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener
+}
+
+func roundTrip(conn net.Conn, message string, timeout time.Duration) (string, error) {
+	if _, err := conn.Write([]byte(message)); err != nil {
+		return "", err
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	buf := make([]byte, len(message))
+	_, err := io.ReadFull(conn, buf)
+	return string(buf), err
+}
+
+func dialRoundTrip(address, message string, timeout time.Duration) (string, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	return roundTrip(conn, message, timeout)
+}
+
+func TestService_Proxy(t *testing.T) {
+	upstream := startEchoServer(t)
+	defer upstream.Close()
+	manager := endly.New()
+	context := manager.NewContext(nil)
+	defer context.Close()
+
+	response := &proxy.StartResponse{}
+	err := endly.Run(context, &proxy.StartRequest{
+		Name:       "echo",
+		Upstream:   upstream.Addr().String(),
+		Conditions: &proxy.Conditions{LatencyMs: 100},
+	}, response)
+	if !assert.Nil(t, err) {
+		return
+	}
+	address := response.Address
+	assert.True(t, strings.HasPrefix(address, "127.0.0.1:"), address)
+	assert.NotNil(t, endly.Run(context, &proxy.StartRequest{Name: "echo", Upstream: upstream.Addr().String()}, &proxy.StartResponse{}))
+
+	{ //latency applies in both directions
+		started := time.Now()
+		echo, err := dialRoundTrip(address, "ping", time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, "ping", echo)
+		assert.True(t, time.Since(started) >= 200*time.Millisecond, time.Since(started))
+	}
+
+	conn, err := net.Dial("tcp", address)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+
+	{ //latency delays chunks without limiting throughput
+		started := time.Now()
+		for i := 0; i < 10; i++ {
+			_, err := conn.Write([]byte("x"))
+			assert.Nil(t, err)
+			time.Sleep(10 * time.Millisecond)
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		buf := make([]byte, 10)
+		_, err := io.ReadFull(conn, buf)
+		assert.Nil(t, err)
+		elapsed := time.Since(started)
+		assert.True(t, elapsed >= 200*time.Millisecond && elapsed < 700*time.Millisecond, elapsed)
+	}
+
+	{ //bandwidth limit, changes apply to open connection
+		assert.Nil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{BandwidthKB: 8}}, &proxy.SetResponse{}))
+		message := strings.Repeat("x", 4096)
+		started := time.Now()
+		echo, err := roundTrip(conn, message, 3*time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, message, echo)
+		assert.True(t, time.Since(started) >= 450*time.Millisecond, time.Since(started))
+	}
+
+	{ //link down resets open and new connections
+		assert.Nil(t, endly.Run(context, &proxy.SetRequest{Name: "echo"}, &proxy.SetResponse{}))
+		echo, err := roundTrip(conn, "ping", time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, "ping", echo)
+		downResponse := &proxy.DownResponse{}
+		assert.Nil(t, endly.Run(context, &proxy.DownRequest{Name: "echo"}, downResponse))
+		assert.True(t, downResponse.Down)
+		_, err = roundTrip(conn, "ping", time.Second)
+		assert.NotNil(t, err)
+		_, err = dialRoundTrip(address, "ping", time.Second)
+		assert.NotNil(t, err)
+
+		assert.Nil(t, endly.Run(context, &proxy.UpRequest{Name: "echo"}, &proxy.UpResponse{}))
+		echo, err = dialRoundTrip(address, "pong", time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, "pong", echo)
+	}
+
+	{ //connection drop
+		assert.Nil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{DropRate: 1}}, &proxy.SetResponse{}))
+		_, err := dialRoundTrip(address, "ping", time.Second)
+		assert.NotNil(t, err)
+		assert.NotNil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{DropRate: 2}}, &proxy.SetResponse{}))
+	}
+
+	{ //packet loss delays data chunks till retransmitted without discarding them
+		assert.Nil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{LossRate: 1, RetransmitMs: 150}}, &proxy.SetResponse{}))
+		started := time.Now()
+		echo, err := dialRoundTrip(address, "ping", 2*time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, "ping", echo)
+		assert.True(t, time.Since(started) >= 300*time.Millisecond, time.Since(started))
+		assert.NotNil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{LossRate: -1}}, &proxy.SetResponse{}))
+		assert.NotNil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{RetransmitMs: -1}}, &proxy.SetResponse{}))
+	}
+
+	{ //queued chunks are delivered after client closes its write side
+		assert.Nil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{LatencyMs: 200}}, &proxy.SetResponse{}))
+		conn, err := net.Dial("tcp", address)
+		if assert.Nil(t, err) {
+			_, err = conn.Write([]byte("last words"))
+			assert.Nil(t, err)
+			assert.Nil(t, conn.(*net.TCPConn).CloseWrite())
+			_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+			echo, err := io.ReadAll(conn)
+			assert.Nil(t, err)
+			assert.Equal(t, "last words", string(echo))
+			_ = conn.Close()
+		}
+	}
+
+	{ //blackhole keeps connection open but discards data
+		assert.Nil(t, endly.Run(context, &proxy.SetRequest{Name: "echo", Conditions: &proxy.Conditions{Blackhole: true}}, &proxy.SetResponse{}))
+		_, err := dialRoundTrip(address, "ping", 300*time.Millisecond)
+		if assert.NotNil(t, err) {
+			netErr, ok := err.(net.Error)
+			assert.True(t, ok && netErr.Timeout(), err.Error())
+		}
+	}
+
+	{ //status and stop
+		statusResponse := &proxy.StatusResponse{}
+		assert.Nil(t, endly.Run(context, &proxy.StatusRequest{}, statusResponse))
+		if assert.Equal(t, 1, len(statusResponse.Proxies)) {
+			info := statusResponse.Proxies[0]
+			assert.Equal(t, "echo", info.Name)
+			assert.True(t, info.Conditions.Blackhole)
+			assert.True(t, info.Dropped >= 2, info.Dropped)
+			assert.True(t, info.LostChunks >= 1, info.LostChunks)
+			assert.True(t, info.ReceivedBytes >= 4096, info.ReceivedBytes)
+		}
+		stopResponse := &proxy.StopResponse{}
+		assert.Nil(t, endly.Run(context, &proxy.StopRequest{Names: []string{"echo"}}, stopResponse))
+		assert.Equal(t, []string{"echo"}, stopResponse.Stopped)
+		_, err := net.Dial("tcp", address)
+		assert.NotNil(t, err)
+	}
+}
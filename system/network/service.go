@@ -3,7 +3,8 @@ package network
 import (
 	"fmt"
 	"github.com/viant/endly"
-	"github.com/viant/toolbox/ssh"
+	"sort"
+	"sync"
 )
 
 const (
@@ -12,16 +13,18 @@ const (
 
 	//NetworkServiceTunnelAction represents opening ssh tunnel action
 	NetworkServiceTunnelAction = "tunnel"
+
+	//NetworkServiceCloseAction represents closing ssh tunnel action
+	NetworkServiceCloseAction = "close"
 )
 
 type service struct {
 	*endly.AbstractService
+	tunnels map[string]*tunnelSession
+	mux     *sync.Mutex
 }
 
 func (s *service) tunnel(context *endly.Context, request *TunnelRequest) (*TunnelResponse, error) {
-	var response = &TunnelResponse{
-		Forwards: make([]*NetworkTunnel, 0),
-	}
 	var target, err = context.ExpandResource(request.Target)
 	if err != nil {
 		return nil, err
@@ -31,41 +34,154 @@ func (s *service) tunnel(context *endly.Context, request *TunnelRequest) (*Tunne
 		return nil, err
 	}
 	hostname, port := s.GetHostAndSSHPort(target)
-	sshClient, err := ssh.NewService(hostname, port, authConfig)
+	var tunnels = make([]*NetworkTunnel, 0)
+	for _, tunnel := range request.Tunnels {
+		tunnels = append(tunnels, &NetworkTunnel{Local: context.Expand(tunnel.Local), Remote: context.Expand(tunnel.Remote), Reverse: tunnel.Reverse})
+	}
+	expanded := &TunnelRequest{Tunnels: tunnels, Socks: context.Expand(request.Socks), KeepAliveMs: request.KeepAliveMs}
+	session := newTunnelSession(s.tunnelName(context.Expand(request.Name), hostname), hostname, port, authConfig, expanded)
+	if err = session.connect(); err == nil {
+		err = session.listenLocal()
+	}
 	if err != nil {
+		session.Close()
 		return nil, err
 	}
+	s.mux.Lock()
+	s.tunnels[session.name] = session
+	s.mux.Unlock()
 	context.Deffer(func() {
-		sshClient.Close()
+		s.closeTunnel(session)
 	})
-	for _, tunnel := range request.Tunnels {
-		var local = context.Expand(tunnel.Local)
-		var remote = context.Expand(tunnel.Remote)
-		err = sshClient.OpenTunnel(local, remote)
-		if err != nil {
-			return nil, err
+	go session.monitor(context)
+	var response = &TunnelResponse{
+		Name:     session.name,
+		Forwards: session.tunnels,
+	}
+	if session.socks != "" {
+		response.Socks = session.socks
+		response.ProxyURL = "socks5://" + session.socks
+	}
+	return response, nil
+}
+
+//tunnelName returns unique tunnel name, default to target hostname
+func (s *service) tunnelName(name, hostname string) string {
+	if name == "" {
+		name = hostname
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	candidate := name
+	for i := 1; ; i++ {
+		if _, has := s.tunnels[candidate]; !has {
+			return candidate
 		}
-		response.Forwards = append(response.Forwards, &NetworkTunnel{Local: local, Remote: remote})
+		candidate = fmt.Sprintf("%v-%v", name, i)
 	}
+}
+
+func (s *service) closeTunnel(session *tunnelSession) bool {
+	s.mux.Lock()
+	current, has := s.tunnels[session.name]
+	if has && current == session {
+		delete(s.tunnels, session.name)
+	}
+	s.mux.Unlock()
+	session.Close()
+	return has && current == session
+}
+
+func (s *service) close(context *endly.Context, request *CloseRequest) (*CloseResponse, error) {
+	var response = &CloseResponse{
+		Closed: make([]string, 0),
+	}
+	var sessions = make([]*tunnelSession, 0)
+	s.mux.Lock()
+	if len(request.Names) == 0 {
+		for _, session := range s.tunnels {
+			sessions = append(sessions, session)
+		}
+	}
+	for _, name := range request.Names {
+		if session, ok := s.tunnels[context.Expand(name)]; ok {
+			sessions = append(sessions, session)
+		}
+	}
+	s.mux.Unlock()
+	for _, session := range sessions {
+		if s.closeTunnel(session) {
+			response.Closed = append(response.Closed, session.name)
+		}
+	}
+	sort.Strings(response.Closed)
 	return response, nil
 }
 
 const networkTunnelRequestExample = `{
-	"Local":"127.0.0.1:8080",
-	"Remote":"127.0.0.1:8080"
+	"Target": {
+		"URL": "ssh://127.0.0.1/",
+		"Credentials": "localhost"
+	},
+	"Tunnels": [
+		{
+			"Local":"127.0.0.1:8080",
+			"Remote":"127.0.0.1:8080"
+		}
+	]
+}
+`
+
+const networkReverseTunnelRequestExample = `{
+	"Target": {
+		"URL": "ssh://10.0.0.12/",
+		"Credentials": "dev"
+	},
+	"Name": "stub",
+	"Tunnels": [
+		{
+			"Local":"127.0.0.1:8081",
+			"Remote":"127.0.0.1:9081",
+			"Reverse": true
+		}
+	]
+}
+`
+
+const networkSocksRequestExample = `{
+	"Target": {
+		"URL": "ssh://10.0.0.12/",
+		"Credentials": "dev"
+	},
+	"Name": "proxy",
+	"Socks": "127.0.0.1:1080",
+	"KeepAliveMs": 3000
+}
+`
+
+const networkCloseRequestExample = `{
+	"Names": ["proxy"]
 }
 `
 
 func (s *service) registerRoutes() {
 	s.Register(&endly.Route{
-		Action: "tunnel",
+		Action: NetworkServiceTunnelAction,
 		RequestInfo: &endly.ActionInfo{
-			Description: "tunnel tcp ports",
+			Description: "tunnel tcp ports, reverse tunnels and SOCKS5 proxy via SSH, broken SSH connection is re-established",
 			Examples: []*endly.UseCase{
 				{
 					Description: "tunnel",
 					Data:        networkTunnelRequestExample,
 				},
+				{
+					Description: "reverse tunnel exposing local endpoint to remote host",
+					Data:        networkReverseTunnelRequestExample,
+				},
+				{
+					Description: "SOCKS5 proxy",
+					Data:        networkSocksRequestExample,
+				},
 			},
 		},
 		RequestProvider: func() interface{} {
@@ -81,12 +197,39 @@ func (s *service) registerRoutes() {
 			return nil, fmt.Errorf("unsupported request type: %T", request)
 		},
 	})
+
+	s.Register(&endly.Route{
+		Action: NetworkServiceCloseAction,
+		RequestInfo: &endly.ActionInfo{
+			Description: "close tunnels",
+			Examples: []*endly.UseCase{
+				{
+					Description: "close",
+					Data:        networkCloseRequestExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &CloseRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &CloseResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*CloseRequest); ok {
+				return s.close(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
 }
 
 //New creates a new network service.
 func New() endly.Service {
 	var result = &service{
 		AbstractService: endly.NewAbstractService(NetworkServiceID),
+		tunnels:         make(map[string]*tunnelSession),
+		mux:             &sync.Mutex{},
 	}
 	result.AbstractService.Service = result
 	result.registerRoutes()
@@ -0,0 +1,293 @@
+package network
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox/cred"
+	"github.com/viant/toolbox/ssh"
+	cssh "golang.org/x/crypto/ssh"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//dialer represents connection dialer
+type dialer func(network, address string) (net.Conn, error)
+
+//tunnelSession represents SSH connection with its forwards, reverse tunnels and SOCKS proxy
+type tunnelSession struct {
+	name       string
+	hostname   string
+	port       int
+	authConfig *cred.Config
+	tunnels    []*NetworkTunnel
+	socks      string
+	keepAlive  time.Duration
+	client     ssh.Service
+	listeners  []net.Listener
+	remote     []net.Listener
+	conns      map[net.Conn]bool
+	reconnects int
+	closed     int32
+	done       chan bool
+	mux        sync.Mutex
+}
+
+//connect opens SSH connection and reverse tunnels, connection opened after session was closed is discarded
+func (s *tunnelSession) connect() error {
+	client, err := ssh.NewService(s.hostname, s.port, s.authConfig)
+	if err != nil {
+		return err
+	}
+	s.mux.Lock()
+	if atomic.LoadInt32(&s.closed) == 1 {
+		s.mux.Unlock()
+		_ = client.Close()
+		return s.closedError()
+	}
+	s.client = client
+	s.mux.Unlock()
+	return s.listenRemote()
+}
+
+func (s *tunnelSession) closedError() error {
+	return fmt.Errorf("tunnel %v was closed", s.name)
+}
+
+func (s *tunnelSession) sshClient() *cssh.Client {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Client()
+}
+
+//dial opens connection from target host with the current SSH connection
+func (s *tunnelSession) dial(network, address string) (net.Conn, error) {
+	client := s.sshClient()
+	if client == nil {
+		return nil, fmt.Errorf("tunnel %v is not connected", s.name)
+	}
+	return client.Dial(network, address)
+}
+
+//listenLocal opens local listeners for forwards and SOCKS proxy, they are kept across reconnects
+func (s *tunnelSession) listenLocal() error {
+	for _, tunnel := range s.tunnels {
+		if tunnel.Reverse {
+			continue
+		}
+		listener, err := net.Listen("tcp", tunnel.Local)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %v, %v", tunnel.Local, err)
+		}
+		if !s.track(listener, &s.listeners) {
+			return s.closedError()
+		}
+		if strings.HasSuffix(tunnel.Local, ":0") {
+			tunnel.Local = listener.Addr().String()
+		}
+		remote := tunnel.Remote
+		go s.serve(listener, func() (net.Conn, error) {
+			return s.dial("tcp", remote)
+		})
+	}
+	if s.socks == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", s.socks)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %v, %v", s.socks, err)
+	}
+	if !s.track(listener, &s.listeners) {
+		return s.closedError()
+	}
+	s.socks = listener.Addr().String()
+	go serveSocks(listener, s.dial, s.pipe)
+	return nil
+}
+
+//listenRemote opens target host listeners for reverse tunnels, they have to be re-opened after reconnect
+func (s *tunnelSession) listenRemote() error {
+	s.mux.Lock()
+	for _, listener := range s.remote {
+		_ = listener.Close()
+	}
+	s.remote = nil
+	s.mux.Unlock()
+	client := s.sshClient()
+	if client == nil {
+		return fmt.Errorf("tunnel %v is not connected", s.name)
+	}
+	for _, tunnel := range s.tunnels {
+		if !tunnel.Reverse {
+			continue
+		}
+		listener, err := client.Listen("tcp", tunnel.Remote)
+		if err != nil {
+			return fmt.Errorf("failed to listen on remote %v, %v", tunnel.Remote, err)
+		}
+		if !s.track(listener, &s.remote) {
+			return s.closedError()
+		}
+		local := tunnel.Local
+		go s.serve(listener, func() (net.Conn, error) {
+			return net.Dial("tcp", local)
+		})
+	}
+	return nil
+}
+
+//track registers listener to be closed with the session, listener is closed right away if session was already closed
+func (s *tunnelSession) track(listener net.Listener, listeners *[]net.Listener) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if atomic.LoadInt32(&s.closed) == 1 {
+		_ = listener.Close()
+		return false
+	}
+	*listeners = append(*listeners, listener)
+	return true
+}
+
+//serve accepts listener connections and pipes them with dialed connections
+func (s *tunnelSession) serve(listener net.Listener, dial func() (net.Conn, error)) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn) {
+			target, err := dial()
+			if err != nil {
+				_ = conn.Close()
+				return
+			}
+			s.pipe(conn, target)
+		}(conn)
+	}
+}
+
+//pipe copies traffic between connections till one of them is closed
+func (s *tunnelSession) pipe(source, target net.Conn) {
+	s.mux.Lock()
+	s.conns[source] = true
+	s.conns[target] = true
+	s.mux.Unlock()
+	defer func() {
+		_ = source.Close()
+		_ = target.Close()
+		s.mux.Lock()
+		delete(s.conns, source)
+		delete(s.conns, target)
+		s.mux.Unlock()
+	}()
+	completed := make(chan bool, 2)
+	go func() {
+		_, _ = io.Copy(source, target)
+		completed <- true
+	}()
+	go func() {
+		_, _ = io.Copy(target, source)
+		completed <- true
+	}()
+	<-completed
+}
+
+//isHealthy sends keep alive request to check SSH connection
+func (s *tunnelSession) isHealthy() bool {
+	client := s.sshClient()
+	if client == nil {
+		return false
+	}
+	result := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		result <- err
+	}()
+	select {
+	case err := <-result:
+		return err == nil
+	case <-time.After(s.keepAlive):
+		return false
+	}
+}
+
+//reconnect re-establishes SSH connection with reverse tunnels
+func (s *tunnelSession) reconnect() error {
+	s.mux.Lock()
+	if s.client != nil {
+		_ = s.client.Close()
+		s.client = nil
+	}
+	s.reconnects++
+	s.mux.Unlock()
+	return s.connect()
+}
+
+//monitor checks SSH connection health and reconnects broken connection till session is closed
+func (s *tunnelSession) monitor(context *endly.Context) {
+	ticker := time.NewTicker(s.keepAlive)
+	defer ticker.Stop()
+	var healthy = true
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		if s.isHealthy() {
+			continue
+		}
+		if atomic.LoadInt32(&s.closed) == 1 {
+			return
+		}
+		if healthy {
+			context.Publish(msg.NewStdoutEvent(NetworkServiceID, fmt.Sprintf("tunnel %v connection to %v lost, reconnecting", s.name, s.hostname)))
+		}
+		if err := s.reconnect(); err != nil {
+			healthy = false
+			continue
+		}
+		healthy = true
+		context.Publish(msg.NewStdoutEvent(NetworkServiceID, fmt.Sprintf("tunnel %v reconnected to %v, reconnects: %v", s.name, s.hostname, s.reconnects)))
+	}
+}
+
+//Close closes listeners, connections and SSH connection
+func (s *tunnelSession) Close() {
+	if !atomic.CompareAndSwapInt32(&s.closed, 0, 1) {
+		return
+	}
+	close(s.done)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, listener := range append(s.listeners, s.remote...) {
+		_ = listener.Close()
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+}
+
+func newTunnelSession(name, hostname string, port int, authConfig *cred.Config, request *TunnelRequest) *tunnelSession {
+	return &tunnelSession{
+		name:       name,
+		hostname:   hostname,
+		port:       port,
+		authConfig: authConfig,
+		tunnels:    request.Tunnels,
+		socks:      request.Socks,
+		keepAlive:  time.Duration(request.KeepAliveMs) * time.Millisecond,
+		conns:      make(map[net.Conn]bool),
+		done:       make(chan bool),
+	}
+}
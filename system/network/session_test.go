@@ -0,0 +1,28 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+func TestTunnelSession_Track(t *testing.T) {
+	session := newTunnelSession("test", "127.0.0.1", 22, nil, &TunnelRequest{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, session.track(listener, &session.remote))
+	session.Close()
+	_, err = listener.Accept()
+	assert.NotNil(t, err, "listener should be closed with session")
+
+	late, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, session.track(late, &session.remote), "listener tracked after close")
+	assert.Equal(t, 1, len(session.remote))
+	_, err = late.Accept()
+	assert.NotNil(t, err, "listener tracked after close should be closed")
+}
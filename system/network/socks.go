@@ -0,0 +1,122 @@
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+const (
+	socksVersion          = 0x05
+	socksNoAuth           = 0x00
+	socksNoAcceptable     = 0xff
+	socksConnect          = 0x01
+	socksAddressIPv4      = 0x01
+	socksAddressDomain    = 0x03
+	socksAddressIPv6      = 0x04
+	socksSucceeded        = 0x00
+	socksHostUnreachable  = 0x04
+	socksNotSupported     = 0x07
+	socksAddressTypeError = 0x08
+)
+
+//serveSocks accepts SOCKS5 proxy connections, CONNECT command is dialed with supplied dialer
+func serveSocks(listener net.Listener, dial dialer, pipe func(source, target net.Conn)) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn) {
+			target, err := socksHandshake(conn, dial)
+			if err != nil {
+				_ = conn.Close()
+				return
+			}
+			pipe(conn, target)
+		}(conn)
+	}
+}
+
+//socksHandshake negotiates no authentication method and handles CONNECT request
+func socksHandshake(conn net.Conn, dial dialer) (net.Conn, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	if header[0] != socksVersion {
+		return nil, fmt.Errorf("unsupported SOCKS version: %v", header[0])
+	}
+	methods := make([]byte, int(header[1]))
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return nil, err
+	}
+	var method byte = socksNoAcceptable
+	for _, candidate := range methods {
+		if candidate == socksNoAuth {
+			method = socksNoAuth
+		}
+	}
+	if _, err := conn.Write([]byte{socksVersion, method}); err != nil {
+		return nil, err
+	}
+	if method == socksNoAcceptable {
+		return nil, fmt.Errorf("no acceptable SOCKS authentication method")
+	}
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return nil, err
+	}
+	if request[1] != socksConnect {
+		_ = socksReply(conn, socksNotSupported)
+		return nil, fmt.Errorf("unsupported SOCKS command: %v", request[1])
+	}
+	var host string
+	switch request[3] {
+	case socksAddressIPv4, socksAddressIPv6:
+		size := net.IPv4len
+		if request[3] == socksAddressIPv6 {
+			size = net.IPv6len
+		}
+		address := make([]byte, size)
+		if _, err := io.ReadFull(conn, address); err != nil {
+			return nil, err
+		}
+		host = net.IP(address).String()
+	case socksAddressDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return nil, err
+		}
+		domain := make([]byte, int(size[0]))
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return nil, err
+		}
+		host = string(domain)
+	default:
+		_ = socksReply(conn, socksAddressTypeError)
+		return nil, fmt.Errorf("unsupported SOCKS address type: %v", request[3])
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return nil, err
+	}
+	address := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+	target, err := dial("tcp", address)
+	if err != nil {
+		_ = socksReply(conn, socksHostUnreachable)
+		return nil, fmt.Errorf("failed to connect to %v, %v", address, err)
+	}
+	if err = socksReply(conn, socksSucceeded); err != nil {
+		_ = target.Close()
+		return nil, err
+	}
+	return target, nil
+}
+
+func socksReply(conn net.Conn, status byte) error {
+	_, err := conn.Write([]byte{socksVersion, status, 0x00, socksAddressIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestServeSocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = fmt.Fprintf(writer, "hello %v", request.URL.Path)
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	var dialed = make([]string, 0)
+	session := newTunnelSession("test", "127.0.0.1", 22, nil, &TunnelRequest{})
+	defer session.Close()
+	session.listeners = append(session.listeners, listener)
+	go serveSocks(listener, func(network, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+		return net.Dial(network, address)
+	}, session.pipe)
+
+	{ //http client with socks5 proxy
+		proxyURL, _ := url.Parse("socks5://" + listener.Addr().String())
+		client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+		response, err := client.Get(server.URL + "/endly")
+		if !assert.Nil(t, err) {
+			return
+		}
+		body, _ := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		assert.Equal(t, "hello /endly", string(body))
+		assert.Equal(t, []string{server.Listener.Addr().String()}, dialed)
+	}
+
+	{ //unsupported command
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte{socksVersion, 1, socksNoAuth})
+		reply := make([]byte, 2)
+		_, err = conn.Read(reply)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{socksVersion, socksNoAuth}, reply)
+		_, _ = conn.Write([]byte{socksVersion, 0x02, 0x00, socksAddressIPv4, 127, 0, 0, 1, 0, 80})
+		reply = make([]byte, 10)
+		_, err = conn.Read(reply)
+		assert.Nil(t, err)
+		assert.Equal(t, byte(socksNotSupported), reply[1])
+	}
+}
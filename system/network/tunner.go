@@ -1,20 +1,66 @@
 package network
 
-import "github.com/viant/toolbox/url"
+import (
+	"fmt"
+	"github.com/viant/toolbox/url"
+)
+
+const defaultKeepAliveMs = 5000
 
 //NetworkTunnel represents network link, both local and remove needs to be in [host]:[port] format
 type NetworkTunnel struct {
-	Local  string `required:"true" description:"local [host]:[port]"`
-	Remote string `required:"true" description:"remote [host]:[port]" `
+	Local   string `required:"true" description:"local [host]:[port]"`
+	Remote  string `required:"true" description:"remote [host]:[port]" `
+	Reverse bool   `description:"if set, remote address listens on target host and forwards connections to local address"`
 }
 
 //TunnelRequest represents SSH tunnel request
 type TunnelRequest struct {
-	Target  *url.Resource
-	Tunnels []*NetworkTunnel
+	Target      *url.Resource
+	Name        string `description:"tunnel name used by close action, default target host"`
+	Tunnels     []*NetworkTunnel
+	Socks       string `description:"local [host]:[port] of dynamic SOCKS5 proxy forwarding connections via target host"`
+	KeepAliveMs int    `description:"SSH connection health check interval, broken connection is re-established with all tunnels, default 5000"`
+}
+
+//Init initializes request
+func (r *TunnelRequest) Init() error {
+	if r.KeepAliveMs == 0 {
+		r.KeepAliveMs = defaultKeepAliveMs
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *TunnelRequest) Validate() error {
+	if r.Target == nil {
+		return fmt.Errorf("target was empty")
+	}
+	if len(r.Tunnels) == 0 && r.Socks == "" {
+		return fmt.Errorf("tunnels and socks were empty")
+	}
+	for _, tunnel := range r.Tunnels {
+		if tunnel.Local == "" || tunnel.Remote == "" {
+			return fmt.Errorf("tunnel local and remote address are required")
+		}
+	}
+	return nil
 }
 
 //TunnelResponse represents expanded net tunnel rule
 type TunnelResponse struct {
+	Name     string
 	Forwards []*NetworkTunnel
+	Socks    string `description:"SOCKS5 proxy listening address"`
+	ProxyURL string `description:"SOCKS5 proxy URL, i.e. to be used as http/runner Proxy option"`
+}
+
+//CloseRequest represents close tunnel request
+type CloseRequest struct {
+	Names []string `description:"tunnel names to close, if empty all open tunnels are closed"`
+}
+
+//CloseResponse represents close tunnel response
+type CloseResponse struct {
+	Closed []string
 }
@@ -1,8 +1,28 @@
 package process
 
 import (
+	"fmt"
 	"github.com/viant/endly/system/exec"
 	"github.com/viant/toolbox/url"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	//RestartNo never restarts process
+	RestartNo = "no"
+	//RestartOnFailure restarts process exited with non zero code
+	RestartOnFailure = "on-failure"
+	//RestartAlways restarts process whenever it exits
+	RestartAlways = "always"
+
+	defaultBackoffMs          = 1000
+	defaultMaxBackoffMs       = 30000
+	defaultStopTimeoutMs      = 5000
+	defaultReadinessTimeoutMs = 60000
+	defaultReadinessSleepMs   = 500
 )
 
 //StartRequest represents a start request
@@ -12,8 +32,33 @@ type StartRequest struct {
 	*exec.Options
 	Arguments       []string
 	AsSuperUser     bool
-	ImmuneToHangups bool `description:"start process as nohup"`
-	Watch           bool `description:"watch command output, work with nohup mode"`
+	ImmuneToHangups bool            `description:"start process as nohup"`
+	Watch           bool            `description:"watch command output, work with nohup mode"`
+	Name            string          `description:"process name used for captured output file names, command base name by default"`
+	LogDirectory    string          `description:"directory where process stdout and stderr are captured to <name>.out and <name>.err files, <directory>/logs for supervised process by default"`
+	Supervise       bool            `description:"run process under supervisor: capture output, apply restart policy and kill process tree at workflow end"`
+	Restart         *RestartPolicy  `description:"restart on exit policy, enables supervision"`
+	Readiness       *ReadinessCheck `description:"readiness check, start waits till process is ready"`
+	StopTimeoutMs   int             `description:"graceful stop timeout used when started process is killed at workflow end"`
+}
+
+//RestartPolicy represents supervised process restart policy
+type RestartPolicy struct {
+	Policy       string `description:"no, on-failure or always" example:"on-failure"`
+	MaxRestarts  int    `description:"max number of restarts, 0 - unlimited"`
+	BackoffMs    int    `description:"initial restart delay doubled with each restart, default 1000"`
+	MaxBackoffMs int    `description:"max restart delay, default 30000"`
+}
+
+//ReadinessCheck represents process readiness check, all defined checks have to pass
+type ReadinessCheck struct {
+	Port       int    `description:"port on target host expected to accept connection"`
+	HTTP       string `description:"URL expected to return Status"`
+	Status     int    `description:"expected HTTP status code, default 200"`
+	Log        string `description:"regular expression expected to match captured process output"`
+	TimeoutMs  int    `description:"readiness timeout, default 60000"`
+	SleepMs    int    `description:"sleep between checks, default 500"`
+	logPattern *regexp.Regexp
 }
 
 //NewStartRequestFromURL creates a new request from URL
@@ -25,10 +70,13 @@ func NewStartRequestFromURL(URL string) (*StartRequest, error) {
 
 //StartResponse represents a start response
 type StartResponse struct {
-	Command string
-	Info    []*Info
-	Pid     int
-	Stdout  string
+	Command     string
+	Info        []*Info
+	Pid         int
+	Stdout      string
+	StdoutFile  string `description:"captured stdout file"`
+	StderrFile  string `description:"captured stderr file"`
+	ReadyTimeMs int    `description:"time taken for process to become ready"`
 }
 
 //StatusRequest represents a status check request
@@ -56,9 +104,11 @@ type Info struct {
 
 //StopRequest represents a stop request
 type StopRequest struct {
-	Target *url.Resource
-	Pid    int
-	Input  string `description:"if specified, matches all process PID to stop"`
+	Target    *url.Resource
+	Pid       int
+	Input     string `description:"if specified, matches all process PID to stop"`
+	TimeoutMs int    `description:"time to wait after SIGTERM before process tree is killed with SIGKILL, default 5000"`
+	StartTime string `description:"if specified, process is stopped only if its start time matches, so that a process which reused PID is not killed"`
 }
 
 //StopResponse represents a stop response
@@ -68,12 +118,108 @@ type StopResponse struct {
 
 func (r *StartRequest) Init() error {
 	r.Target = exec.GetServiceTarget(r.Target)
+	if r.Options == nil {
+		r.Options = exec.DefaultOptions()
+	}
+	if r.Name == "" {
+		fields := strings.Fields(r.Command)
+		if len(fields) > 0 {
+			_, r.Name = path.Split(fields[0])
+		}
+	}
+	if r.Restart != nil {
+		r.Supervise = true
+		r.Restart.Init()
+	}
+	if r.Supervise && r.LogDirectory == "" {
+		r.LogDirectory = path.Join(r.Directory, "logs")
+	}
+	if r.StopTimeoutMs == 0 {
+		r.StopTimeoutMs = defaultStopTimeoutMs
+	}
+	if r.Readiness != nil {
+		return r.Readiness.Init()
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *StartRequest) Validate() error {
+	if r.Command == "" {
+		return fmt.Errorf("command was empty")
+	}
+	if r.Readiness != nil && r.Readiness.Log != "" && r.LogDirectory == "" && !r.ImmuneToHangups {
+		return fmt.Errorf("readiness log check requires captured output, set logDirectory, supervise or immuneToHangups")
+	}
+	if r.Restart != nil {
+		return r.Restart.Validate()
+	}
+	return nil
+}
+
+//Init initialises policy
+func (p *RestartPolicy) Init() {
+	if p.Policy == "" {
+		p.Policy = RestartOnFailure
+	}
+	if p.BackoffMs == 0 {
+		p.BackoffMs = defaultBackoffMs
+	}
+	if p.MaxBackoffMs == 0 {
+		p.MaxBackoffMs = defaultMaxBackoffMs
+	}
+}
+
+//Validate checks if policy is valid
+func (p *RestartPolicy) Validate() error {
+	switch p.Policy {
+	case RestartNo, RestartOnFailure, RestartAlways:
+		return nil
+	}
+	return fmt.Errorf("unsupported restart policy: %v, supported: %v, %v, %v", p.Policy, RestartNo, RestartOnFailure, RestartAlways)
+}
+
+//ShouldRestart returns true if process exited with supplied code should be restarted
+func (p *RestartPolicy) ShouldRestart(exitCode int) bool {
+	switch p.Policy {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitCode != 0
+	}
+	return false
+}
+
+//Init initialises check
+func (c *ReadinessCheck) Init() (err error) {
+	if c.TimeoutMs == 0 {
+		c.TimeoutMs = defaultReadinessTimeoutMs
+	}
+	if c.SleepMs == 0 {
+		c.SleepMs = defaultReadinessSleepMs
+	}
+	if c.HTTP != "" && c.Status == 0 {
+		c.Status = http.StatusOK
+	}
+	if c.Log != "" {
+		if c.logPattern, err = regexp.Compile(c.Log); err != nil {
+			return fmt.Errorf("invalid readiness log pattern: %v, %v", c.Log, err)
+		}
+	}
+	return nil
+}
+
+//Init initialises request
+func (r *StopRequest) Init() error {
+	if r.TimeoutMs == 0 {
+		r.TimeoutMs = defaultStopTimeoutMs
+	}
 	return nil
 }
 
 //NewStopRequest creates a stop request
 func NewStopRequest(pid int, target *url.Resource) *StopRequest {
-	return &StopRequest{Target: target, Pid: pid}
+	return &StopRequest{Target: target, Pid: pid, TimeoutMs: defaultStopTimeoutMs}
 }
 
 func NewStatusRequest(command string, target *url.Resource) *StatusRequest {
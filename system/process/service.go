@@ -12,14 +12,19 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 )
 
 //ServiceID represents a system process service id
 const ServiceID = "process"
 
+const ledgerProcess = "process"
+
 type service struct {
 	*endly.AbstractService
+	processes map[int]*supervisedProcess
+	mux       *sync.Mutex
 }
 
 func (s *service) stopAllProcesses(context *endly.Context, request *StopRequest) (*StopResponse, error) {
@@ -36,8 +41,9 @@ func (s *service) stopAllProcesses(context *endly.Context, request *StopRequest)
 	var response = &StopResponse{}
 	for _, info := range status.Processes {
 		commandResponse, err := s.stopProcess(context, &StopRequest{
-			Target: target,
-			Pid:    info.Pid,
+			Target:    target,
+			Pid:       info.Pid,
+			TimeoutMs: request.TimeoutMs,
 		})
 		if err != nil {
 			return nil, err
@@ -71,8 +77,8 @@ func (s *service) checkProcess(context *endly.Context, request *StatusRequest) (
 		actualCommand = string(actualCommand[index+5:])
 	}
 
-	for _, line := range strings.Split(runResponse.Stdout(), "\r\n") {
-		line = vtclean.Clean(line, false)
+	for _, line := range strings.Split(runResponse.Stdout(), "\n") {
+		line = vtclean.Clean(strings.TrimRight(line, "\r"), false)
 		if strings.Contains(line, "grep") {
 			continue
 		}
@@ -133,14 +139,32 @@ func (s *service) stopProcess(context *endly.Context, request *StopRequest) (*St
 		return s.stopAllProcesses(context, request)
 	}
 	target := exec.GetServiceTarget(request.Target)
-	var extractRequest = exec.NewExtractRequest(target, exec.DefaultOptions(), exec.NewExtractCommand(fmt.Sprintf("kill -9 %v", request.Pid), "", nil, nil))
-	extractRequest.AutoSudo = true
-	var runResponse = &exec.RunResponse{}
-	if err := endly.Run(context, extractRequest, runResponse); err != nil {
+	if request.StartTime != "" {
+		startTime, err := processStartTime(context, target, request.Pid)
+		if err != nil {
+			return nil, err
+		}
+		if startTime != request.StartTime {
+			if err := context.Ledger().Release(ledgerProcess, toolbox.AsString(request.Pid)); err != nil {
+				return nil, err
+			}
+			return &StopResponse{Stdout: fmt.Sprintf("process %v started at %v is no longer running", request.Pid, request.StartTime)}, nil
+		}
+	}
+	if process := s.supervised(request.Pid); process != nil {
+		s.unregister(process)
+		process.mux.Lock()
+		defer process.mux.Unlock()
+	}
+	stdout, err := s.killTree(context, target, request.Pid, request.TimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	if err := context.Ledger().Release(ledgerProcess, toolbox.AsString(request.Pid)); err != nil {
 		return nil, err
 	}
 	return &StopResponse{
-		Stdout: runResponse.Stdout(),
+		Stdout: stdout,
 	}, nil
 
 }
@@ -185,6 +209,9 @@ func (s *service) startProcess(context *endly.Context, request *StartRequest) (*
 	if err != nil {
 		return nil, err
 	}
+	if request.LogDirectory != "" {
+		return s.startCaptured(context, request)
+	}
 	outputFile := path.Join(request.Directory, "nohup.out")
 	startProcessRequest := s.buildStartProcessCommand(request)
 	startProcessResponse := &exec.RunResponse{}
@@ -200,6 +227,12 @@ func (s *service) startProcess(context *endly.Context, request *StartRequest) (*
 	}
 	response.Info = status.Processes
 	response.Pid = status.Pid
+	if response.Pid > 0 {
+		s.trackStarted(context, request, response.Pid)
+		if err = registerTeardown(context, request.Target, response.Pid); err != nil {
+			return nil, err
+		}
+	}
 
 	if request.ImmuneToHangups {
 		stdout, err := s.readOutput(outputFile)
@@ -211,7 +244,43 @@ func (s *service) startProcess(context *endly.Context, request *StartRequest) (*
 			go s.watchOutput(context, outputFile, len(stdout))
 		}
 	}
+	if request.Readiness != nil && response.Pid > 0 {
+		if response.ReadyTimeMs, err = s.waitReady(context, request, response.Pid); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
 
+//startCaptured starts process with output captured to log directory, supervised if requested
+func (s *service) startCaptured(context *endly.Context, request *StartRequest) (response *StartResponse, err error) {
+	response = &StartResponse{Command: request.Command}
+	response.StdoutFile, response.StderrFile, _ = outputFiles(request)
+	if request.Supervise {
+		var process *supervisedProcess
+		if process, err = s.startSupervised(context, request); err == nil {
+			response.Pid = s.processPid(process)
+		}
+	} else if response.Pid, err = s.launch(context, request); err == nil {
+		s.trackStarted(context, request, response.Pid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = registerTeardown(context, request.Target, response.Pid); err != nil {
+		return nil, err
+	}
+	if request.Readiness != nil {
+		if response.ReadyTimeMs, err = s.waitReady(context, request, response.Pid); err != nil {
+			return nil, err
+		}
+	}
+	if status, err := s.checkProcess(context, NewStatusRequest(request.Command, request.Target)); err == nil {
+		response.Info = status.Processes
+	}
+	if request.Watch {
+		go s.watchOutput(context, response.StdoutFile, 0)
+	}
 	return response, nil
 }
 
@@ -306,6 +375,8 @@ func (s *service) registerRoutes() {
 func New() endly.Service {
 	var result = &service{
 		AbstractService: endly.NewAbstractService(ServiceID),
+		processes:       make(map[int]*supervisedProcess),
+		mux:             &sync.Mutex{},
 	}
 	result.AbstractService.Service = result
 	result.registerRoutes()
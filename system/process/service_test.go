@@ -8,9 +8,24 @@ import (
 	"github.com/viant/endly/system/process"
 	"github.com/viant/endly/util"
 	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"log"
+	"os"
 	"testing"
 )
 
+//TestMain isolates session ledger from user home directory
+func TestMain(m *testing.M) {
+	directory, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		log.Fatal(err)
+	}
+	_ = os.Setenv(endly.LedgerDirectoryEnv, directory)
+	code := m.Run()
+	_ = os.RemoveAll(directory)
+	os.Exit(code)
+}
+
 func TestProcessService_Status(t *testing.T) {
 
 	var credentialFile, err = util.GetDummyCredential()
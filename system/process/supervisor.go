@@ -0,0 +1,396 @@
+package process
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/endly/system/exec"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"net"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	supervisorIntervalMs = 500
+	stopCheckIntervalMs  = 200
+	processRunning       = "running"
+	processKilled        = "killed"
+)
+
+var pidExpr = regexp.MustCompile(`pid:(\d+)`)
+var startedExpr = regexp.MustCompile(`started:([^\r\n]*)`)
+
+//supervisedProcess represents a started process killed at workflow end, optionally monitored by supervisor
+type supervisedProcess struct {
+	request  *StartRequest
+	pid      int
+	restarts int
+	stopped  int32
+	mux      *sync.Mutex
+}
+
+func (p *supervisedProcess) isStopped() bool {
+	return atomic.LoadInt32(&p.stopped) == 1
+}
+
+func outputFiles(request *StartRequest) (stdout, stderr, exitCode string) {
+	return path.Join(request.LogDirectory, request.Name+".out"), path.Join(request.LogDirectory, request.Name+".err"), path.Join(request.LogDirectory, request.Name+".exit")
+}
+
+//runCommands runs shell commands on target, returns combined output
+func runCommands(context *endly.Context, target *url.Resource, options *exec.Options, commands ...string) (string, error) {
+	var extractCommands = make([]*exec.ExtractCommand, 0)
+	for _, command := range commands {
+		extractCommands = append(extractCommands, exec.NewExtractCommand(command, "", nil, nil))
+	}
+	if options == nil {
+		options = exec.DefaultOptions()
+	}
+	request := exec.NewExtractRequest(target, options, extractCommands...)
+	response := &exec.RunResponse{}
+	if err := endly.Run(context, request, response); err != nil {
+		return "", err
+	}
+	return response.Output, nil
+}
+
+//launch starts process in the background with output captured to log directory and exit code recorded in <name>.exit file
+func (s *service) launch(context *endly.Context, request *StartRequest) (int, error) {
+	stdoutFile, stderrFile, exitFile := outputFiles(request)
+	command := strings.TrimSpace(request.Command + " " + strings.Join(request.Arguments, " "))
+	wrapped := strings.Replace(fmt.Sprintf("%v; echo $? > %v", command, exitFile), "'", `'\''`, -1)
+	options := *request.Options
+	options.CheckError = true
+	var commands = make([]string, 0)
+	if request.Directory != "" {
+		commands = append(commands, "cd "+request.Directory)
+	}
+	commands = append(commands,
+		fmt.Sprintf("mkdir -p %v && rm -f %v", request.LogDirectory, exitFile),
+		fmt.Sprintf("nohup sh -c '%v' >> %v 2>> %v < /dev/null & echo \"pid:$!\"", wrapped, stdoutFile, stderrFile))
+	output, err := runCommands(context, request.Target, &options, commands...)
+	if err != nil {
+		return 0, err
+	}
+	matched := pidExpr.FindStringSubmatch(output)
+	if len(matched) != 2 {
+		return 0, fmt.Errorf("failed to start %v: %v", command, output)
+	}
+	return toolbox.AsInt(matched[1]), nil
+}
+
+//exitStatus returns true if process is running, otherwise process exit code, -1 if process was killed
+func (s *service) exitStatus(context *endly.Context, target *url.Resource, pid int, exitFile string) (bool, int, error) {
+	command := fmt.Sprintf("if ps -p %v -o stat= 2>/dev/null | grep -qv Z; then echo %v; else echo %v; fi", pid, processRunning, processKilled)
+	if exitFile != "" {
+		command = fmt.Sprintf("if [ -f %v ]; then cat %v; el%v", exitFile, exitFile, command)
+	}
+	output, err := runCommands(context, target, nil, command)
+	if err != nil {
+		return false, 0, err
+	}
+	output = strings.TrimSpace(output)
+	switch {
+	case strings.HasSuffix(output, processRunning):
+		return true, 0, nil
+	case strings.HasSuffix(output, processKilled):
+		return false, -1, nil
+	}
+	return false, toolbox.AsInt(output), nil
+}
+
+//processStartTime returns process start time, empty if process is not running
+func processStartTime(context *endly.Context, target *url.Resource, pid int) (string, error) {
+	output, err := runCommands(context, target, nil, fmt.Sprintf("echo \"started:$(ps -o lstart= -p %v 2>/dev/null)\"", pid))
+	if err != nil {
+		return "", err
+	}
+	matched := startedExpr.FindStringSubmatch(output)
+	if len(matched) != 2 {
+		return "", nil
+	}
+	return strings.Join(strings.Fields(matched[1]), " "), nil
+}
+
+//registerTeardown registers started process in session ledger with its start time, so that a later cleanup does not kill a process which reused PID
+func registerTeardown(context *endly.Context, target *url.Resource, pid int) error {
+	stopRequest := NewStopRequest(pid, target)
+	var err error
+	if stopRequest.StartTime, err = processStartTime(context, target, pid); err != nil {
+		return err
+	}
+	return context.Ledger().Register(ledgerProcess, toolbox.AsString(pid), ServiceID, "stop", stopRequest)
+}
+
+//killTree sends SIGTERM to process with all its descendants, processes still running after timeoutMs are killed with SIGKILL
+func (s *service) killTree(context *endly.Context, target *url.Resource, pid int, timeoutMs int) (string, error) {
+	output, err := runCommands(context, target, nil, fmt.Sprintf("_endly_tree() { for _c in `pgrep -P $1 2>/dev/null`; do _endly_tree $_c; done; echo $1; }; _endly_tree %v", pid))
+	if err != nil {
+		return "", err
+	}
+	pids := extractPids(output)
+	if len(pids) == 0 {
+		pids = []string{toolbox.AsString(pid)}
+	}
+	options := exec.DefaultOptions()
+	options.AutoSudo = true
+	stdout, err := runCommands(context, target, options, "kill -TERM "+strings.Join(pids, " "))
+	if err != nil {
+		return stdout, err
+	}
+	deadline := time.Now().Add(time.Duration(timeoutMs) * time.Millisecond)
+	for len(pids) > 0 && time.Now().Before(deadline) {
+		time.Sleep(stopCheckIntervalMs * time.Millisecond)
+		output, err := runCommands(context, target, nil, fmt.Sprintf("ps -o pid= -o stat= -p %v 2>/dev/null | grep -v Z", strings.Join(pids, ",")))
+		if err != nil {
+			return stdout, err
+		}
+		pids = extractPids(output)
+	}
+	if len(pids) == 0 {
+		return stdout, nil
+	}
+	killed, err := runCommands(context, target, options, "kill -9 "+strings.Join(pids, " "))
+	return stdout + killed, err
+}
+
+func extractPids(output string) []string {
+	var result = make([]string, 0)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if pid := toolbox.AsInt(fields[0]); pid > 0 && toolbox.AsString(pid) == fields[0] {
+			result = append(result, fields[0])
+		}
+	}
+	return result
+}
+
+//waitReady waits till process passes readiness check
+func (s *service) waitReady(context *endly.Context, request *StartRequest, pid int) (int, error) {
+	check := request.Readiness
+	startTime := time.Now()
+	deadline := startTime.Add(time.Duration(check.TimeoutMs) * time.Millisecond)
+	for {
+		ready, err := s.checkReady(context, request, pid)
+		if err != nil {
+			return 0, err
+		}
+		if ready {
+			return int(time.Since(startTime) / time.Millisecond), nil
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("%v was not ready within %v ms%v", request.Name, check.TimeoutMs, s.logTail(context, request))
+		}
+		time.Sleep(time.Duration(check.SleepMs) * time.Millisecond)
+	}
+}
+
+func (s *service) checkReady(context *endly.Context, request *StartRequest, pid int) (bool, error) {
+	check := request.Readiness
+	exitFile := ""
+	if request.LogDirectory != "" {
+		_, _, exitFile = outputFiles(request)
+	}
+	running, exitCode, err := s.exitStatus(context, request.Target, pid, exitFile)
+	if err != nil {
+		return false, err
+	}
+	if !running {
+		if request.Restart != nil && request.Restart.ShouldRestart(exitCode) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%v (pid: %v) exited with code: %v%v", request.Name, pid, exitCode, s.logTail(context, request))
+	}
+	host := request.Target.ParsedURL.Hostname()
+	if host == "" || request.Target.ParsedURL.Scheme == "file" {
+		host = "127.0.0.1"
+	}
+	if check.Port > 0 {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, toolbox.AsString(check.Port)), time.Second)
+		if err != nil {
+			return false, nil
+		}
+		_ = conn.Close()
+	}
+	if check.HTTP != "" {
+		client := &http.Client{Timeout: time.Duration(check.SleepMs+1000) * time.Millisecond}
+		response, err := client.Get(check.HTTP)
+		if err != nil {
+			return false, nil
+		}
+		_ = response.Body.Close()
+		if response.StatusCode != check.Status {
+			return false, nil
+		}
+	}
+	if check.Log != "" {
+		output, err := s.readLogs(context, request)
+		if err != nil {
+			return false, err
+		}
+		if !check.logPattern.MatchString(output) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+//readLogs returns captured process output
+func (s *service) readLogs(context *endly.Context, request *StartRequest) (string, error) {
+	files := []string{path.Join(request.Directory, "nohup.out")}
+	if request.LogDirectory != "" {
+		stdoutFile, stderrFile, _ := outputFiles(request)
+		files = []string{stdoutFile, stderrFile}
+	}
+	return runCommands(context, request.Target, nil, fmt.Sprintf("cat %v 2>/dev/null; true", strings.Join(files, " ")))
+}
+
+func (s *service) logTail(context *endly.Context, request *StartRequest) string {
+	if request.LogDirectory == "" && !request.ImmuneToHangups {
+		return ""
+	}
+	output, err := s.readLogs(context, request)
+	if err != nil || strings.TrimSpace(output) == "" {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) > 20 {
+		lines = lines[len(lines)-20:]
+	}
+	return ", output:\n" + strings.Join(lines, "\n")
+}
+
+//track returns process killed with all its descendants when the workflow context closes unless it was stopped earlier,
+//process is tracked with its own context, so that it does not share terminal session with the workflow
+func (s *service) track(context *endly.Context, request *StartRequest) (*endly.Context, *supervisedProcess) {
+	owner := context.Clone()
+	owner.MakeAsyncSafe()
+	owner.Listener = context.Listener
+	process := &supervisedProcess{request: request, mux: &sync.Mutex{}}
+	owner.Deffer(func() {
+		if pid := s.unregister(process); pid > 0 {
+			process.mux.Lock()
+			defer process.mux.Unlock()
+			//context close runs deferred functions registered before it started, sessions opened to kill process are closed here
+			deferred := len(owner.Deffer())
+			_, _ = s.killTree(owner, request.Target, pid, request.StopTimeoutMs)
+			_ = owner.Ledger().Release(ledgerProcess, toolbox.AsString(pid))
+			for _, function := range owner.Deffer()[deferred:] {
+				function()
+			}
+		}
+	})
+	return owner, process
+}
+
+//trackStarted registers started process to be killed at workflow end
+func (s *service) trackStarted(context *endly.Context, request *StartRequest, pid int) {
+	_, process := s.track(context, request)
+	s.register(process, pid)
+}
+
+//startSupervised starts process under supervisor which uses its own context, so that it does not share terminal session with the workflow
+func (s *service) startSupervised(context *endly.Context, request *StartRequest) (*supervisedProcess, error) {
+	supervisor, process := s.track(context, request)
+	pid, err := s.launch(supervisor, request)
+	if err != nil {
+		return nil, err
+	}
+	s.register(process, pid)
+	go s.supervise(supervisor, process)
+	return process, nil
+}
+
+func (s *service) supervise(context *endly.Context, process *supervisedProcess) {
+	request := process.request
+	_, _, exitFile := outputFiles(request)
+	restart := request.Restart
+	backoffMs := 0
+	if restart != nil {
+		backoffMs = restart.BackoffMs
+	}
+	for {
+		time.Sleep(supervisorIntervalMs * time.Millisecond)
+		if context.IsClosed() || process.isStopped() {
+			return
+		}
+		pid := s.processPid(process)
+		process.mux.Lock()
+		running, exitCode, err := s.exitStatus(context, request.Target, pid, exitFile)
+		process.mux.Unlock()
+		if err != nil || running || process.isStopped() {
+			continue
+		}
+		if restart == nil || !restart.ShouldRestart(exitCode) || (restart.MaxRestarts > 0 && process.restarts >= restart.MaxRestarts) {
+			context.Publish(msg.NewStdoutEvent(ServiceID, fmt.Sprintf("%v (pid: %v) exited with code: %v, restarts: %v", request.Name, pid, exitCode, process.restarts)))
+			s.unregister(process)
+			_ = context.Ledger().Release(ledgerProcess, toolbox.AsString(pid))
+			return
+		}
+		for waited := 0; waited < backoffMs && !process.isStopped() && !context.IsClosed(); waited += supervisorIntervalMs {
+			time.Sleep(supervisorIntervalMs * time.Millisecond)
+		}
+		if process.isStopped() || context.IsClosed() {
+			return
+		}
+		process.mux.Lock()
+		newPid, err := s.launch(context, request)
+		process.mux.Unlock()
+		if err != nil {
+			context.Publish(msg.NewStdoutEvent(ServiceID, fmt.Sprintf("failed to restart %v: %v", request.Name, err)))
+			s.unregister(process)
+			return
+		}
+		process.restarts++
+		if backoffMs *= 2; backoffMs > restart.MaxBackoffMs {
+			backoffMs = restart.MaxBackoffMs
+		}
+		s.register(process, newPid)
+		_ = context.Ledger().Release(ledgerProcess, toolbox.AsString(pid))
+		_ = registerTeardown(context, request.Target, newPid)
+		context.Publish(msg.NewStdoutEvent(ServiceID, fmt.Sprintf("%v exited with code: %v, restarted with pid: %v, restarts: %v", request.Name, exitCode, newPid, process.restarts)))
+	}
+}
+
+func (s *service) register(process *supervisedProcess, pid int) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.processes, process.pid)
+	process.pid = pid
+	s.processes[pid] = process
+}
+
+//unregister marks process as stopped, returns its last pid
+func (s *service) unregister(process *supervisedProcess) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	atomic.StoreInt32(&process.stopped, 1)
+	if _, ok := s.processes[process.pid]; !ok {
+		return 0
+	}
+	delete(s.processes, process.pid)
+	return process.pid
+}
+
+func (s *service) processPid(process *supervisedProcess) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return process.pid
+}
+
+//supervised returns supervised process for supplied pid
+func (s *service) supervised(pid int) *supervisedProcess {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.processes[pid]
+}
@@ -0,0 +1,181 @@
+package process_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/process"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//isAlive returns true if process is running, killed process may stay a zombie till its parent reaps it
+func isAlive(pid int) bool {
+	if pid <= 0 || syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	stat, _ := exec.Command("ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	return !strings.HasPrefix(strings.TrimSpace(string(stat)), "Z")
+}
+
+func TestService_Supervise(t *testing.T) {
+	directory, err := ioutil.TempDir("", "supervise")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	target := url.NewResource("file:///")
+	assert.Nil(t, ioutil.WriteFile(path.Join(directory, "crash.sh"), []byte("echo ready\nsleep 0.2\necho failed >&2\nexit 3\n"), 0755))
+	assert.Nil(t, ioutil.WriteFile(path.Join(directory, "server.sh"), []byte("echo listening\nsleep 60 &\nwait\n"), 0755))
+	assert.Nil(t, ioutil.WriteFile(path.Join(directory, "daemon.sh"), []byte("sleep 61 &\nwait\n"), 0755))
+
+	manager := endly.New()
+	{ //restart on failure with max restarts
+		context := manager.NewContext(nil)
+		request := &process.StartRequest{
+			Target:    target,
+			Command:   "./crash.sh",
+			Restart:   &process.RestartPolicy{Policy: process.RestartOnFailure, MaxRestarts: 2, BackoffMs: 100},
+			Readiness: &process.ReadinessCheck{Log: "ready", TimeoutMs: 5000, SleepMs: 100},
+		}
+		request.Init()
+		request.Directory = directory
+		request.LogDirectory = path.Join(directory, "logs")
+		response := &process.StartResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		assert.True(t, response.Pid > 0)
+		assert.Equal(t, path.Join(directory, "logs", "crash.sh.out"), response.StdoutFile)
+
+		var stdout, exitCode []byte
+		for i := 0; i < 40; i++ {
+			time.Sleep(250 * time.Millisecond)
+			stdout, _ = ioutil.ReadFile(response.StdoutFile)
+			exitCode, _ = ioutil.ReadFile(path.Join(directory, "logs", "crash.sh.exit"))
+			if strings.Count(string(stdout), "ready") == 3 && len(exitCode) > 0 {
+				break
+			}
+		}
+		assert.Equal(t, 3, strings.Count(string(stdout), "ready"), string(stdout))
+		stderr, _ := ioutil.ReadFile(response.StderrFile)
+		assert.True(t, strings.Contains(string(stderr), "failed"))
+		assert.Equal(t, "3", strings.TrimSpace(string(exitCode)))
+		context.Close()
+	}
+
+	{ //graceful stop kills process tree
+		context := manager.NewContext(nil)
+		request := &process.StartRequest{Target: target, Command: "./server.sh", Supervise: true}
+		request.Init()
+		request.Directory = directory
+		request.LogDirectory = path.Join(directory, "logs")
+		request.Readiness = &process.ReadinessCheck{Log: "listening"}
+		assert.Nil(t, request.Readiness.Init())
+		response := &process.StartResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		assert.True(t, isAlive(response.Pid))
+		stopResponse := &process.StopResponse{}
+		assert.Nil(t, endly.Run(context, &process.StopRequest{Target: target, Pid: response.Pid, TimeoutMs: 2000}, stopResponse))
+		time.Sleep(100 * time.Millisecond)
+		assert.False(t, isAlive(response.Pid))
+		assert.Equal(t, 0, len(context.Ledger().Entries))
+		context.Close()
+	}
+
+	{ //teardown skips process which reused PID
+		context := manager.NewContext(nil)
+		request := &process.StartRequest{Target: target, Command: "./server.sh", LogDirectory: path.Join(directory, "logs")}
+		request.Init()
+		request.Directory = directory
+		response := &process.StartResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		if assert.Equal(t, 1, len(context.Ledger().Entries)) {
+			assert.NotEqual(t, "", context.Ledger().Entries[0].Request["StartTime"])
+		}
+		stopResponse := &process.StopResponse{}
+		assert.Nil(t, endly.Run(context, &process.StopRequest{Target: target, Pid: response.Pid, StartTime: "Thu Jan 1 00:00:00 1970"}, stopResponse))
+		assert.True(t, isAlive(response.Pid))
+		assert.Equal(t, 0, len(context.Ledger().Entries))
+		context.Close()
+		time.Sleep(100 * time.Millisecond)
+		assert.False(t, isAlive(response.Pid))
+	}
+
+	{ //supervised process is killed at workflow end
+		context := manager.NewContext(nil)
+		request := &process.StartRequest{Target: target, Command: "./server.sh", Supervise: true}
+		request.Init()
+		request.Directory = directory
+		request.LogDirectory = path.Join(directory, "logs")
+		response := &process.StartResponse{}
+		if !assert.Nil(t, endly.Run(context, request, response)) {
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+		assert.True(t, isAlive(response.Pid))
+		context.Close()
+		time.Sleep(100 * time.Millisecond)
+		assert.False(t, isAlive(response.Pid))
+	}
+
+	{ //plain and nohup processes are killed with descendants at workflow end
+		context := manager.NewContext(nil)
+		var pids = make([]int, 0)
+		for _, request := range []*process.StartRequest{
+			{Target: target, Command: "./server.sh", LogDirectory: path.Join(directory, "logs")},
+			{Target: target, Command: "./daemon.sh", ImmuneToHangups: true},
+		} {
+			request.Init()
+			request.Directory = directory
+			response := &process.StartResponse{}
+			if !assert.Nil(t, endly.Run(context, request, response)) {
+				return
+			}
+			assert.True(t, response.Pid > 0)
+			pids = append(pids, response.Pid)
+		}
+		time.Sleep(200 * time.Millisecond)
+		children, _ := exec.Command("pgrep", "-P", strconv.Itoa(pids[0])).Output()
+		assert.True(t, len(strings.TrimSpace(string(children))) > 0)
+		context.Close()
+		time.Sleep(100 * time.Millisecond)
+		for _, pid := range pids {
+			assert.False(t, isAlive(pid), pid)
+		}
+		for _, child := range strings.Fields(string(children)) {
+			childPid, _ := strconv.Atoi(child)
+			assert.False(t, isAlive(childPid), child)
+		}
+	}
+}
+
+func TestRestartPolicy_ShouldRestart(t *testing.T) {
+	var useCases = []struct {
+		policy   string
+		exitCode int
+		expect   bool
+	}{
+		{process.RestartNo, 1, false},
+		{process.RestartOnFailure, 0, false},
+		{process.RestartOnFailure, 1, true},
+		{process.RestartOnFailure, -1, true},
+		{process.RestartAlways, 0, true},
+	}
+	for _, useCase := range useCases {
+		policy := &process.RestartPolicy{Policy: useCase.policy}
+		assert.Equal(t, useCase.expect, policy.ShouldRestart(useCase.exitCode), useCase.policy)
+	}
+	assert.NotNil(t, (&process.RestartPolicy{Policy: "sometimes"}).Validate())
+}
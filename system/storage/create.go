@@ -49,7 +49,11 @@ func (s *service) create(context *endly.Context, request *CreateRequest, respons
 		return err
 	}
 	response.URL = dest.URL
-	return fs.Create(context.Background(), dest.URL, os.FileMode(request.Mode), request.IsDir, storageOpts...)
+	if err = fs.Create(context.Background(), dest.URL, os.FileMode(request.Mode), request.IsDir, storageOpts...); err != nil {
+		return err
+	}
+	removeRequest := NewRemoveRequest(url.NewResource(dest.URL, request.Dest.Credentials))
+	return context.Ledger().Register(ledgerAsset, dest.URL, ServiceID, "remove", removeRequest)
 }
 
 func gerReaderOption(request *CreateRequest, context *endly.Context, response *CreateResponse) []storage.Option {
@@ -34,6 +34,9 @@ func (s *service) remove(context *endly.Context, request *RemoveRequest, respons
 	for _, resource := range request.Assets {
 		resource, _ = removeResource(context, resource, fs)
 		response.Removed = append(response.Removed, resource.URL)
+		if err = context.Ledger().Release(ledgerAsset, resource.URL); err != nil {
+			return err
+		}
 	}
 	return nil
 }
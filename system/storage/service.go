@@ -9,6 +9,8 @@ const (
 	//ServiceID represents transfer service id
 	ServiceID = "storage"
 
+	ledgerAsset = "storage/asset"
+
 	//useMemoryService flag in the context to ignore
 	useMemoryService = "useMemoryService"
 	//compressionTimeoutMs max SSH execution time before timing out
@@ -0,0 +1,179 @@
+package dsunit
+
+import (
+	"regexp"
+	"strings"
+)
+
+//sqliteTypeRule represents a column type translation rule
+type sqliteTypeRule struct {
+	expr        *regexp.Regexp
+	replacement string
+}
+
+//sqliteTypeRules translates leading column type, the first matching rule is used
+var sqliteTypeRules = []*sqliteTypeRule{
+	{regexp.MustCompile(`(?i)^\s*(tiny|small|medium|big)?int(eger)?\s*(\(\d+\))?(\s+unsigned)?(\s+zerofill)?\b`), "INTEGER"},
+	{regexp.MustCompile(`(?i)^\s*(big|small)?serial\b`), "INTEGER"},
+	{regexp.MustCompile(`(?i)^\s*(double(\s+precision)?|float\d?|real)\s*(\(\d+(\s*,\s*\d+)?\))?(\s+unsigned)?`), "REAL"},
+	{regexp.MustCompile(`(?i)^\s*(decimal|numeric)\s*(\(\d+(\s*,\s*\d+)?\))?(\s+unsigned)?`), "NUMERIC"},
+	{regexp.MustCompile(`(?i)^\s*(bool|boolean|bit\s*(\(\d+\))?)\b`), "BOOLEAN"},
+	{regexp.MustCompile(`(?i)^\s*timestamp\s*(\(\d+\))?\s+with(out)?\s+time\s+zone\b`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^\s*(datetime|timestamp)\s*\(\d+\)`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^\s*time\s*\(\d+\)`), "TIME"},
+	{regexp.MustCompile(`(?i)^\s*(enum|set)\s*\([^)]*\)`), "TEXT"},
+	{regexp.MustCompile(`(?i)^\s*(character\s+varying|n?varchar|n?char(acter)?)\s*(\(\d+\))?`), "TEXT"},
+	{regexp.MustCompile(`(?i)^\s*(tiny|medium|long)?text\b`), "TEXT"},
+	{regexp.MustCompile(`(?i)^\s*(jsonb?|uuid|inet|citext|xml)\b`), "TEXT"},
+	{regexp.MustCompile(`(?i)^\s*((tiny|medium|long)?blob|bytea|(var)?binary\s*(\(\d+\))?)`), "BLOB"},
+}
+
+var (
+	sqliteAutoIncrementExpr = regexp.MustCompile(`(?i)\s+auto_increment\b`)
+	sqliteSerialExpr        = regexp.MustCompile(`(?i)\b(big|small)?serial\b`)
+	sqlitePrimaryKeyExpr    = regexp.MustCompile(`(?i)\bprimary\s+key\b`)
+	sqliteTablePrimaryKey   = regexp.MustCompile("(?i)^\\s*(constraint\\s+\\S+\\s+)?primary\\s+key\\s*\\(\\s*[`\"]?(\\w+)[`\"]?\\s*\\)\\s*$")
+	sqliteIndexDefinition   = regexp.MustCompile(`(?i)^\s*(fulltext\s+|spatial\s+)?(key|index)\b`)
+	sqliteUniqueKeyExpr     = regexp.MustCompile("(?i)^\\s*unique\\s+(key|index)\\s*([`\"]?\\w+[`\"]?)?\\s*\\(")
+	sqliteForeignKeyExpr    = regexp.MustCompile(`(?i)^\s*(constraint\s+\S+\s+)?foreign\s+key\b`)
+	sqliteColumnOptionsExpr = regexp.MustCompile(`(?i)\s+(character\s+set\s+\w+|collate\s+\w+|comment\s+'(?:[^']|'')*'|on\s+update\s+current_timestamp(\(\d*\))?|after\s+\S+\s*$|first\s*$)`)
+	sqliteCastExpr          = regexp.MustCompile(`::\w+(\[\])?`)
+	sqliteNowExpr           = regexp.MustCompile(`(?i)\b(now\(\)|current_timestamp\(\d*\)|localtimestamp)`)
+	sqliteColumnNameExpr    = regexp.MustCompile("^\\s*[`\"]?(\\w+)[`\"]?")
+	sqliteCascadeExpr       = regexp.MustCompile(`(?i)\s+(cascade|restrict)\s*$`)
+	sqliteIndexUsingExpr    = regexp.MustCompile(`(?i)\s+using\s+\w+`)
+	sqliteUnsupportedExpr   = regexp.MustCompile(`(?i)^\s*(set\s|lock\s+tables|unlock\s+tables|create\s+(database|schema|extension|sequence|type|function|trigger)|use\s|alter\s+sequence|comment\s+on|grant\s|drop\s+(database|schema|sequence|type|extension))`)
+	sqliteCreateTableExpr   = regexp.MustCompile(`(?i)^\s*create\s+(temporary\s+)?table\b`)
+	sqliteAlterTableExpr    = regexp.MustCompile(`(?i)^(\s*alter\s+table\s+\S+\s+)(add\s+(column\s+)?|rename\s+)(.*)$`)
+	sqliteCommentExpr       = regexp.MustCompile(`(?s)/\*.*?\*/|(?m)^\s*--.*$`)
+)
+
+//translateToSQLite translates common MySQL and PostgreSQL DDL into SQLite DDL, unsupported statements are skipped
+func translateToSQLite(DDL string) string {
+	var result = make([]string, 0)
+	DDL = sqliteCommentExpr.ReplaceAllString(DDL, "")
+	for _, statement := range splitSQLStatements(DDL) {
+		statement = strings.TrimSpace(statement)
+		if statement == "" || sqliteUnsupportedExpr.MatchString(statement) {
+			continue
+		}
+		switch {
+		case sqliteCreateTableExpr.MatchString(statement):
+			statement = translateCreateTable(statement)
+		case strings.HasPrefix(strings.ToLower(statement), "alter"):
+			//only add column and rename are supported by SQLite
+			matched := sqliteAlterTableExpr.FindStringSubmatch(statement)
+			if len(matched) == 0 || sqliteForeignKeyExpr.MatchString(matched[4]) || strings.HasPrefix(strings.ToLower(matched[4]), "constraint") ||
+				sqliteIndexDefinition.MatchString(matched[4]) || sqliteUniqueKeyExpr.MatchString(matched[4]) || sqlitePrimaryKeyExpr.MatchString(matched[4]) {
+				continue
+			}
+			if strings.HasPrefix(strings.ToLower(matched[2]), "add") {
+				statement = matched[1] + "ADD COLUMN " + translateColumn(matched[4])
+			}
+		default:
+			statement = sqliteIndexUsingExpr.ReplaceAllString(statement, "")
+			statement = sqliteCascadeExpr.ReplaceAllString(statement, "")
+		}
+		result = append(result, statement+";")
+	}
+	return strings.Join(result, "\n\n")
+}
+
+//translateCreateTable translates create table column definitions and removes table options
+func translateCreateTable(statement string) string {
+	begin := strings.Index(statement, "(")
+	end := strings.LastIndex(statement, ")")
+	if begin == -1 || end < begin {
+		return statement
+	}
+	definitions := splitDefinitions(statement[begin+1 : end])
+	autoIncrementColumn := ""
+	var translated = make([]string, 0)
+	for _, definition := range definitions {
+		definition = strings.TrimSpace(definition)
+		switch {
+		case definition == "", sqliteIndexDefinition.MatchString(definition):
+			continue
+		case sqliteUniqueKeyExpr.MatchString(definition):
+			definition = sqliteUniqueKeyExpr.ReplaceAllString(definition, "UNIQUE (")
+		case sqliteTablePrimaryKey.MatchString(definition), sqliteForeignKeyExpr.MatchString(definition),
+			strings.HasPrefix(strings.ToLower(definition), "constraint"), strings.HasPrefix(strings.ToLower(definition), "check"):
+		default:
+			isAutoIncrement := sqliteAutoIncrementExpr.MatchString(definition) || sqliteSerialExpr.MatchString(definition)
+			definition = translateColumn(definition)
+			if isAutoIncrement && autoIncrementColumn == "" {
+				if matched := sqliteColumnNameExpr.FindStringSubmatch(definition); len(matched) > 1 {
+					autoIncrementColumn = matched[1]
+				}
+				if !sqlitePrimaryKeyExpr.MatchString(definition) {
+					definition += " PRIMARY KEY"
+				}
+				definition = sqlitePrimaryKeyExpr.ReplaceAllString(definition, "PRIMARY KEY AUTOINCREMENT")
+			}
+		}
+		translated = append(translated, definition)
+	}
+	if autoIncrementColumn != "" {
+		var filtered = make([]string, 0)
+		for _, definition := range translated {
+			if matched := sqliteTablePrimaryKey.FindStringSubmatch(definition); len(matched) > 2 && strings.EqualFold(matched[2], autoIncrementColumn) {
+				continue
+			}
+			filtered = append(filtered, definition)
+		}
+		translated = filtered
+	}
+	return statement[:begin] + "(\n  " + strings.Join(translated, ",\n  ") + "\n)"
+}
+
+//translateColumn translates column type, default and options
+func translateColumn(definition string) string {
+	definition = sqliteAutoIncrementExpr.ReplaceAllString(definition, "")
+	definition = sqliteColumnOptionsExpr.ReplaceAllString(definition, "")
+	definition = sqliteCastExpr.ReplaceAllString(definition, "")
+	definition = sqliteNowExpr.ReplaceAllString(definition, "CURRENT_TIMESTAMP")
+	name := sqliteColumnNameExpr.FindString(definition)
+	columnType := definition[len(name):]
+	for _, rule := range sqliteTypeRules {
+		if matched := strings.TrimRight(rule.expr.FindString(columnType), " \t\r\n"); matched != "" {
+			columnType = " " + rule.replacement + columnType[len(matched):]
+			break
+		}
+	}
+	return name + columnType
+}
+
+//splitSQLStatements splits SQL by semicolon outside quotes
+func splitSQLStatements(SQL string) []string {
+	return splitOutsideQuotes(SQL, ';', false)
+}
+
+//splitDefinitions splits create table body by top level comma
+func splitDefinitions(body string) []string {
+	return splitOutsideQuotes(body, ',', true)
+}
+
+func splitOutsideQuotes(text string, separator rune, topLevelOnly bool) []string {
+	var result = make([]string, 0)
+	var quote rune
+	depth := 0
+	start := 0
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == separator && (!topLevelOnly || depth == 0):
+			result = append(result, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(result, text[start:])
+}
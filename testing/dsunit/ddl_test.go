@@ -0,0 +1,71 @@
+package dsunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestTranslateToSQLite(t *testing.T) {
+	var useCases = []struct {
+		description string
+		DDL         string
+		expect      []string
+		notExpect   []string
+	}{
+		{
+			description: "mysql table",
+			DDL: "SET NAMES utf8;\n/* comment; with semicolon */\nDROP TABLE IF EXISTS `dummy`;\n" +
+				"CREATE TABLE `dummy` (\n  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n  `name` varchar(255) COLLATE utf8_bin DEFAULT NULL COMMENT 'name, value',\n" +
+				"  `status` enum('a','b') DEFAULT 'a',\n  `amount` decimal(7,2) DEFAULT NULL,\n  `modified` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`),\n  UNIQUE KEY `uk_name` (`name`),\n  KEY `idx_status` (`status`)\n) ENGINE=InnoDB AUTO_INCREMENT=10 DEFAULT CHARSET=utf8;",
+			expect: []string{
+				"DROP TABLE IF EXISTS `dummy`;",
+				"`id` INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT",
+				"`name` TEXT DEFAULT NULL",
+				"`status` TEXT DEFAULT 'a'",
+				"`amount` NUMERIC DEFAULT NULL",
+				"`modified` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,",
+				"UNIQUE (`name`)",
+			},
+			notExpect: []string{"SET NAMES", "ENGINE", "PRIMARY KEY (`id`)", "idx_status", "COMMENT"},
+		},
+		{
+			description: "inline auto increment primary key",
+			DDL:         "CREATE TABLE dummy (\n  id INT AUTO_INCREMENT PRIMARY KEY,\n  type_id INT NOT NULL\n);",
+			expect:      []string{"id INTEGER PRIMARY KEY AUTOINCREMENT,", "type_id INTEGER NOT NULL"},
+			notExpect:   []string{"AUTO_INCREMENT"},
+		},
+		{
+			description: "alter table",
+			DDL:         "ALTER TABLE users ADD COLUMN name varchar(255) NOT NULL DEFAULT '' AFTER email;\nALTER TABLE users ADD CONSTRAINT fk_account FOREIGN KEY (account_id) REFERENCES account(id);\nALTER TABLE users MODIFY name TEXT;",
+			expect:      []string{"ALTER TABLE users ADD COLUMN name TEXT NOT NULL DEFAULT ''"},
+			notExpect:   []string{"fk_account", "MODIFY", "AFTER"},
+		},
+		{
+			description: "postgres table",
+			DDL: "CREATE EXTENSION IF NOT EXISTS citext;\nDROP TABLE IF EXISTS users CASCADE;\n" +
+				"CREATE TABLE users (\n  id BIGSERIAL PRIMARY KEY,\n  email CHARACTER VARYING(64) NOT NULL,\n  active BOOLEAN DEFAULT true,\n" +
+				"  attrs JSONB DEFAULT '{}'::jsonb,\n  created TIMESTAMP WITH TIME ZONE DEFAULT NOW()\n);\nCREATE INDEX users_email ON users USING btree (email);",
+			expect: []string{
+				"DROP TABLE IF EXISTS users;",
+				"id INTEGER PRIMARY KEY AUTOINCREMENT",
+				"email TEXT NOT NULL",
+				"active BOOLEAN DEFAULT true",
+				"attrs TEXT DEFAULT '{}'",
+				"created TIMESTAMP DEFAULT CURRENT_TIMESTAMP",
+				"CREATE INDEX users_email ON users (email);",
+			},
+			notExpect: []string{"EXTENSION", "CASCADE", "::"},
+		},
+	}
+	for _, useCase := range useCases {
+		actual := translateToSQLite(useCase.DDL)
+		for _, expect := range useCase.expect {
+			assert.True(t, strings.Contains(actual, expect), useCase.description+": "+expect+"\n"+actual)
+		}
+		for _, notExpect := range useCase.notExpect {
+			assert.False(t, strings.Contains(actual, notExpect), useCase.description+": "+notExpect+"\n"+actual)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package dsunit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/viant/assertly"
+	"github.com/viant/dsc"
+	"github.com/viant/dsunit"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"html"
+	"sort"
+	"strings"
+)
+
+const (
+	//RowDiffMissing represents expected row missing in actual data
+	RowDiffMissing = "missing"
+	//RowDiffUnexpected represents actual row not present in expected data
+	RowDiffUnexpected = "unexpected"
+	//RowDiffChanged represents row with different column values
+	RowDiffChanged = "changed"
+)
+
+//maxDiffTableRows represents max row diffs printed by CLI, artifacts contain all row diffs
+const maxDiffTableRows = 50
+
+//ColumnDiff represents a column difference
+type ColumnDiff struct {
+	Column   string
+	Expected interface{}
+	Actual   interface{}
+}
+
+//RowDiff represents a row difference identified by key
+type RowDiff struct {
+	Key     string
+	Status  string
+	Columns []*ColumnDiff
+}
+
+//DatasetDiff represents a keyed dataset difference
+type DatasetDiff struct {
+	Dataset    string
+	KeyColumns []string
+	Missing    int
+	Unexpected int
+	Changed    int
+	Rows       []*RowDiff
+}
+
+//DiffEvent represents a keyed row diff report event
+type DiffEvent struct {
+	Action    string
+	Datasets  []*DatasetDiff
+	artifacts []*msg.Artifact
+}
+
+//add adds row diff
+func (d *DatasetDiff) add(row *RowDiff) {
+	switch row.Status {
+	case RowDiffMissing:
+		d.Missing++
+	case RowDiffUnexpected:
+		d.Unexpected++
+	case RowDiffChanged:
+		d.Changed++
+	}
+	d.Rows = append(d.Rows, row)
+}
+
+//Summary returns diff summary
+func (d *DatasetDiff) Summary() string {
+	return fmt.Sprintf("%v diff (missing: %v, unexpected: %v, changed: %v)", d.Dataset, d.Missing, d.Unexpected, d.Changed)
+}
+
+//records returns diff as rows of key, status, column, expected, actual
+func (d *DatasetDiff) records() [][]string {
+	var result = make([][]string, 0)
+	for _, row := range d.Rows {
+		for _, column := range row.Columns {
+			result = append(result, []string{row.Key, row.Status, column.Column, asDiffText(column.Expected), asDiffText(column.Actual)})
+		}
+	}
+	return result
+}
+
+var diffHeaders = []string{"key", "status", "column", "expected", "actual"}
+
+//Tables returns diff tables
+func (e *DiffEvent) Tables() []*msg.Table {
+	var result = make([]*msg.Table, 0)
+	for _, dataset := range e.Datasets {
+		records := dataset.records()
+		caption := dataset.Summary()
+		if len(records) > maxDiffTableRows {
+			caption += fmt.Sprintf(", first %v of %v differences", maxDiffTableRows, len(records))
+			records = records[:maxDiffTableRows]
+		}
+		result = append(result, &msg.Table{Caption: caption, Headers: diffHeaders, Rows: records})
+	}
+	return result
+}
+
+//Artifacts returns CSV report per dataset and HTML report, artifacts are built once so that stored locations are retained
+func (e *DiffEvent) Artifacts() []*msg.Artifact {
+	if e.artifacts != nil {
+		return e.artifacts
+	}
+	var result = make([]*msg.Artifact, 0)
+	report := new(bytes.Buffer)
+	report.WriteString("<html><head><title>" + html.EscapeString(e.Action) + " diff</title></head><body>\n")
+	for _, dataset := range e.Datasets {
+		records := dataset.records()
+		content := new(bytes.Buffer)
+		writer := csv.NewWriter(content)
+		_ = writer.Write(diffHeaders)
+		_ = writer.WriteAll(records)
+		result = append(result, &msg.Artifact{Name: strings.Replace(dataset.Dataset, "/", "_", -1) + "_diff.csv", Content: content.Bytes()})
+
+		report.WriteString("<h3>" + html.EscapeString(dataset.Summary()) + "</h3>\n<table border=\"1\" cellspacing=\"0\" cellpadding=\"3\">\n<tr>")
+		for _, header := range diffHeaders {
+			report.WriteString("<th>" + header + "</th>")
+		}
+		report.WriteString("</tr>\n")
+		for _, record := range records {
+			report.WriteString("<tr>")
+			for _, cell := range record {
+				report.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+			}
+			report.WriteString("</tr>\n")
+		}
+		report.WriteString("</table>\n")
+	}
+	report.WriteString("</body></html>\n")
+	result = append(result, &msg.Artifact{Name: e.Action + "_diff.html", Content: report.Bytes()})
+	e.artifacts = result
+	return result
+}
+
+func asDiffText(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if toolbox.IsMap(value) || toolbox.IsSlice(value) {
+		if text, err := toolbox.AsJSONText(value); err == nil {
+			return strings.TrimSpace(text)
+		}
+	}
+	return toolbox.AsString(value)
+}
+
+//asDiffRecords converts dataset records to maps, directive records are removed, key columns are taken from @indexBy@ directive
+func asDiffRecords(records interface{}) ([]map[string]interface{}, []string) {
+	var result = make([]map[string]interface{}, 0)
+	var keyColumns []string
+	if records == nil || !toolbox.IsSlice(records) {
+		return result, keyColumns
+	}
+	for _, item := range toolbox.AsSlice(records) {
+		if item == nil || !toolbox.IsMap(item) {
+			continue
+		}
+		record := make(map[string]interface{})
+		for k, v := range toolbox.AsMap(item) {
+			if k == assertly.IndexByDirective {
+				keyColumns = make([]string, 0)
+				if toolbox.IsSlice(v) {
+					toolbox.CopySliceElements(v, &keyColumns)
+				} else {
+					keyColumns = strings.Split(toolbox.AsString(v), ",")
+				}
+			}
+			if strings.HasPrefix(k, "@") {
+				continue
+			}
+			record[k] = v
+		}
+		if len(record) > 0 {
+			result = append(result, record)
+		}
+	}
+	return result, keyColumns
+}
+
+//diffRecordValue returns record column value, column name is matched case insensitively
+func diffRecordValue(record map[string]interface{}, column string) interface{} {
+	if value, ok := record[column]; ok {
+		return value
+	}
+	for k, v := range record {
+		if strings.EqualFold(k, column) {
+			return v
+		}
+	}
+	return nil
+}
+
+func diffRecordKey(record map[string]interface{}, keyColumns []string, index int) string {
+	if len(keyColumns) == 0 {
+		return fmt.Sprintf("[%d]", index)
+	}
+	var fragments = make([]string, len(keyColumns))
+	for i, column := range keyColumns {
+		fragments[i] = column + ":" + toolbox.AsString(diffRecordValue(record, column))
+	}
+	return strings.Join(fragments, ", ")
+}
+
+func recordColumnDiffs(record map[string]interface{}, expected bool) []*ColumnDiff {
+	var columns = make([]string, 0, len(record))
+	for column := range record {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	var result = make([]*ColumnDiff, 0)
+	for _, column := range columns {
+		diff := &ColumnDiff{Column: column}
+		if expected {
+			diff.Expected = record[column]
+		} else {
+			diff.Actual = record[column]
+		}
+		result = append(result, diff)
+	}
+	return result
+}
+
+//tableKeyColumns returns table primary key columns from datastore metadata or nil if not available
+func tableKeyColumns(manager dsc.Manager, table string) []string {
+	if manager == nil || table == "" {
+		return nil
+	}
+	descriptor := manager.TableDescriptorRegistry().Get(table)
+	if descriptor == nil {
+		return nil
+	}
+	return descriptor.PkColumns
+}
+
+//newDatasetDiff computes keyed row diff for dataset validation, rows are keyed by table primary key columns,
+//or if not supplied by @indexBy@ columns, id column or position
+func newDatasetDiff(validation *dsunit.DatasetValidation, keyColumns []string) (*DatasetDiff, error) {
+	expected, indexBy := asDiffRecords(validation.Expected)
+	actual, _ := asDiffRecords(validation.Actual)
+	if len(keyColumns) == 0 {
+		keyColumns = indexBy
+	}
+	if len(keyColumns) == 0 && len(expected) > 0 {
+		for column := range expected[0] {
+			if strings.EqualFold(column, "id") {
+				keyColumns = []string{column}
+			}
+		}
+	}
+	result := &DatasetDiff{Dataset: validation.Dataset, KeyColumns: keyColumns, Rows: make([]*RowDiff, 0)}
+	var indexed = make(map[string]map[string]interface{})
+	var actualKeys = make([]string, 0)
+	for i, record := range actual {
+		key := diffRecordKey(record, keyColumns, i)
+		indexed[key] = record
+		actualKeys = append(actualKeys, key)
+	}
+	var matched = make(map[string]bool)
+	for i, record := range expected {
+		key := diffRecordKey(record, keyColumns, i)
+		actualRecord, ok := indexed[key]
+		if !ok {
+			result.add(&RowDiff{Key: key, Status: RowDiffMissing, Columns: recordColumnDiffs(record, true)})
+			continue
+		}
+		matched[key] = true
+		var aligned = make(map[string]interface{})
+		for column := range record {
+			aligned[column] = diffRecordValue(actualRecord, column)
+		}
+		rowValidation, err := assertly.Assert(record, aligned, assertly.NewDataPath(key))
+		if err != nil {
+			return nil, err
+		}
+		if !rowValidation.HasFailure() {
+			continue
+		}
+		row := &RowDiff{Key: key, Status: RowDiffChanged}
+		for _, failure := range rowValidation.Failures {
+			column := failure.LeafKey()
+			if column == "" {
+				column = failure.Path
+			}
+			row.Columns = append(row.Columns, &ColumnDiff{Column: column, Expected: failure.Expected, Actual: failure.Actual})
+		}
+		result.add(row)
+	}
+	for _, key := range actualKeys {
+		if !matched[key] {
+			result.add(&RowDiff{Key: key, Status: RowDiffUnexpected, Columns: recordColumnDiffs(indexed[key], false)})
+		}
+	}
+	return result, nil
+}
+
+//newExpectDiffEvent returns diff event for failed expect datasets or nil, manager is used to resolve table primary keys
+func newExpectDiffEvent(manager dsc.Manager, response *dsunit.ExpectResponse) (*DiffEvent, error) {
+	var result = &DiffEvent{Action: "expect", Datasets: make([]*DatasetDiff, 0)}
+	for _, validation := range response.Validation {
+		if validation.Validation == nil || !validation.HasFailure() {
+			continue
+		}
+		diff, err := newDatasetDiff(validation, tableKeyColumns(manager, validation.Dataset))
+		if err != nil {
+			return nil, err
+		}
+		if len(diff.Rows) > 0 {
+			result.Datasets = append(result.Datasets, diff)
+		}
+	}
+	if len(result.Datasets) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+//newCompareDiffEvent returns diff event built from compare failures or nil, failure paths are keyed by compare indexBy columns,
+//record mismatch reports source1 and source2 paths, the lower path is the row missing in the other source
+func newCompareDiffEvent(request *dsunit.CompareRequest, response *dsunit.CompareResponse) *DiffEvent {
+	if response.Validation == nil || !response.HasFailure() {
+		return nil
+	}
+	dataset := &DatasetDiff{Dataset: request.Source1.Datastore + "_" + request.Source2.Datastore, KeyColumns: request.IndexBy(), Rows: make([]*RowDiff, 0)}
+	var rows = make(map[string]*RowDiff)
+	for _, failure := range response.Failures {
+		if strings.HasPrefix(failure.Reason, "record mismatch") {
+			source1Path, source2Path := toolbox.AsString(failure.Expected), toolbox.AsString(failure.Actual)
+			if source2Path > source1Path {
+				dataset.add(&RowDiff{Key: source1Path, Status: RowDiffMissing, Columns: []*ColumnDiff{{Column: "*", Expected: source1Path}}})
+			} else {
+				dataset.add(&RowDiff{Key: source2Path, Status: RowDiffUnexpected, Columns: []*ColumnDiff{{Column: "*", Actual: source2Path}}})
+			}
+			continue
+		}
+		column := failure.LeafKey()
+		key := strings.TrimSuffix(failure.Path, "."+column)
+		if column == "" {
+			column = failure.Reason
+		}
+		row, ok := rows[key]
+		if !ok {
+			row = &RowDiff{Key: key, Status: RowDiffChanged}
+			rows[key] = row
+			dataset.add(row)
+		}
+		row.Columns = append(row.Columns, &ColumnDiff{Column: column, Expected: failure.Expected, Actual: failure.Actual})
+	}
+	return &DiffEvent{Action: "compare", Datasets: []*DatasetDiff{dataset}}
+}
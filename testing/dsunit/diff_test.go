@@ -0,0 +1,121 @@
+package dsunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/dsunit"
+	"strings"
+	"testing"
+)
+
+func TestNewDatasetDiff(t *testing.T) {
+	validation := &dsunit.DatasetValidation{
+		Dataset: "users",
+		Expected: []interface{}{
+			map[string]interface{}{"@indexBy@": "id"},
+			map[string]interface{}{"id": 1, "name": "Bob", "email": "bob@domain.com"},
+			map[string]interface{}{"id": 2, "name": "Alice"},
+			map[string]interface{}{"id": 3, "name": "Eve"},
+		},
+		Actual: []interface{}{
+			map[string]interface{}{"ID": 1, "NAME": "Bob", "EMAIL": "bob@other.com"},
+			map[string]interface{}{"ID": 2, "NAME": "Alice"},
+			map[string]interface{}{"ID": 4, "NAME": "Mallory"},
+		},
+	}
+	diff, err := newDatasetDiff(validation, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, []string{"id"}, diff.KeyColumns)
+	assert.EqualValues(t, 1, diff.Missing)
+	assert.EqualValues(t, 1, diff.Unexpected)
+	assert.EqualValues(t, 1, diff.Changed)
+	var statuses = make(map[string]string)
+	for _, row := range diff.Rows {
+		statuses[row.Key] = row.Status
+		if row.Status == RowDiffChanged {
+			if assert.EqualValues(t, 1, len(row.Columns)) {
+				assert.EqualValues(t, "bob@domain.com", row.Columns[0].Expected)
+				assert.EqualValues(t, "bob@other.com", row.Columns[0].Actual)
+			}
+		}
+	}
+	assert.EqualValues(t, map[string]string{
+		"id:1": RowDiffChanged,
+		"id:3": RowDiffMissing,
+		"id:4": RowDiffUnexpected,
+	}, statuses)
+
+	event := &DiffEvent{Action: "expect", Datasets: []*DatasetDiff{diff}}
+	tables := event.Tables()
+	if assert.EqualValues(t, 1, len(tables)) {
+		assert.True(t, strings.HasPrefix(tables[0].Caption, "users diff"))
+		assert.EqualValues(t, diffHeaders, tables[0].Headers)
+	}
+	artifacts := event.Artifacts()
+	if assert.EqualValues(t, 2, len(artifacts)) {
+		assert.EqualValues(t, "users_diff.csv", artifacts[0].Name)
+		assert.True(t, strings.Contains(string(artifacts[0].Content), "id:3,missing,name,Eve,"))
+		assert.EqualValues(t, "expect_diff.html", artifacts[1].Name)
+	}
+}
+
+func TestNewDatasetDiff_KeyColumns(t *testing.T) {
+	validation := &dsunit.DatasetValidation{
+		Dataset: "accounts",
+		Expected: []interface{}{
+			map[string]interface{}{"@indexBy@": "id"},
+			map[string]interface{}{"id": 1, "code": "a1", "name": "Bob"},
+			map[string]interface{}{"id": 2, "code": "a2", "name": "Alice"},
+		},
+		Actual: []interface{}{
+			map[string]interface{}{"ID": 10, "CODE": "a1", "NAME": "Bob"},
+			map[string]interface{}{"ID": 20, "CODE": "a3", "NAME": "Alice"},
+		},
+	}
+	diff, err := newDatasetDiff(validation, []string{"CODE"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, []string{"CODE"}, diff.KeyColumns)
+	var statuses = make(map[string]string)
+	for _, row := range diff.Rows {
+		statuses[row.Key] = row.Status
+	}
+	assert.EqualValues(t, map[string]string{
+		"CODE:a1": RowDiffChanged,
+		"CODE:a2": RowDiffMissing,
+		"CODE:a3": RowDiffUnexpected,
+	}, statuses)
+}
+
+func TestNewCompareDiffEvent(t *testing.T) {
+	request := &dsunit.CompareRequest{
+		Source1:    &dsunit.DatastoreSQL{Datastore: "db1"},
+		Source2:    &dsunit.DatastoreSQL{Datastore: "db2"},
+		Directives: map[string]interface{}{assertly.IndexByDirective: "id"},
+	}
+	response := &dsunit.CompareResponse{Validation: &assertly.Validation{}}
+	response.AddFailure(assertly.NewFailure("", "[2]", "record mismatch with [3]", "[2]", "[3]"))
+	response.AddFailure(assertly.NewFailure("", "[5]", "record mismatch with [4]", "[5]", "[4]"))
+	response.AddFailure(assertly.NewFailure("", "[1].name", assertly.EqualViolation, "Bob", "Alice"))
+	event := newCompareDiffEvent(request, response)
+	if !assert.NotNil(t, event) {
+		return
+	}
+	dataset := event.Datasets[0]
+	assert.EqualValues(t, "db1_db2", dataset.Dataset)
+	assert.EqualValues(t, 1, dataset.Missing)
+	assert.EqualValues(t, 1, dataset.Unexpected)
+	assert.EqualValues(t, 1, dataset.Changed)
+	var statuses = make(map[string]string)
+	for _, row := range dataset.Rows {
+		statuses[row.Key] = row.Status
+	}
+	assert.EqualValues(t, map[string]string{
+		"[2]": RowDiffMissing,
+		"[4]": RowDiffUnexpected,
+		"[1]": RowDiffChanged,
+	}, statuses)
+}
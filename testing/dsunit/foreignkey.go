@@ -0,0 +1,127 @@
+package dsunit
+
+import (
+	"github.com/pkg/errors"
+	"github.com/viant/dsc"
+	"github.com/viant/toolbox"
+	"strings"
+)
+
+//foreignKeyQuery represents driver specific foreign key discovery SQL returning col, ref_table, ref_column, table (and datastore for mysql) are passed as parameters
+type foreignKeyQuery struct {
+	SQL           string
+	withDatastore bool
+}
+
+var foreignKeyQueries = map[string]*foreignKeyQuery{
+	sqliteDriver: {
+		SQL: `SELECT "from" AS col, "table" AS ref_table, "to" AS ref_column FROM pragma_foreign_key_list(?)`,
+	},
+	"mysql": {
+		SQL: `SELECT column_name AS col, referenced_table_name AS ref_table, referenced_column_name AS ref_column
+FROM information_schema.key_column_usage
+WHERE table_schema = ? AND table_name = ? AND referenced_table_name IS NOT NULL`,
+		withDatastore: true,
+	},
+	"postgres": {
+		SQL: `SELECT kcu.column_name AS col, ccu.table_name AS ref_table, ccu.column_name AS ref_column
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+JOIN information_schema.constraint_column_usage ccu ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema() AND tc.table_name = $1`,
+	},
+}
+
+//ForeignKeys returns table foreign keys as lower case column to referenced table.column map, unsupported drivers return no foreign keys
+func ForeignKeys(manager dsc.Manager, datastore, table string) (map[string]string, error) {
+	var result = make(map[string]string)
+	query, ok := foreignKeyQueries[manager.Config().DriverName]
+	if !ok {
+		return result, nil
+	}
+	var parameters = []interface{}{table}
+	if query.withDatastore {
+		parameters = []interface{}{datastore, table}
+	}
+	var records = make([]map[string]interface{}, 0)
+	if err := manager.ReadAll(&records, query.SQL, parameters, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %v foreign keys", table)
+	}
+	for _, record := range records {
+		var normalized = make(map[string]string)
+		for key, value := range record {
+			if value != nil {
+				normalized[strings.ToLower(key)] = toolbox.AsString(value)
+			}
+		}
+		column, refTable, refColumn := normalized["col"], normalized["ref_table"], normalized["ref_column"]
+		if column != "" && refTable != "" && refColumn != "" {
+			result[strings.ToLower(column)] = refTable + "." + refColumn
+		}
+	}
+	return result, nil
+}
+
+//sortByDependency returns tables ordered so that referenced tables come before tables referencing them,
+//tables with circular references keep their original order
+func sortByDependency(manager dsc.Manager, tables []string) ([]string, error) {
+	dialect := dsc.GetDatastoreDialect(manager.Config().DriverName)
+	datastore, err := dialect.GetCurrentDatastore(manager)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies = make(map[string][]string)
+	for _, table := range tables {
+		foreignKeys, err := ForeignKeys(manager, datastore, table)
+		if err != nil {
+			return nil, err
+		}
+		for _, reference := range foreignKeys {
+			dependencies[table] = append(dependencies[table], strings.SplitN(reference, ".", 2)[0])
+		}
+	}
+	return SortByDependency(tables, dependencies), nil
+}
+
+//SortByDependency returns tables ordered so that referenced tables come before tables referencing them,
+//dependencies map a table to tables it references, tables with circular references keep their original order
+func SortByDependency(tables []string, dependencies map[string][]string) []string {
+	var references = make(map[string]map[string]bool)
+	for _, table := range tables {
+		references[table] = make(map[string]bool)
+		for _, referenced := range dependencies[table] {
+			if !strings.EqualFold(referenced, table) {
+				references[table][strings.ToLower(referenced)] = true
+			}
+		}
+	}
+	var result = make([]string, 0, len(tables))
+	var added = make(map[string]bool)
+	var pending = tables
+	for len(pending) > 0 {
+		var next = make([]string, 0)
+		for _, table := range pending {
+			if hasPendingDependency(references[table], pending, added) {
+				next = append(next, table)
+				continue
+			}
+			result = append(result, table)
+			added[strings.ToLower(table)] = true
+		}
+		if len(next) == len(pending) {
+			return append(result, next...)
+		}
+		pending = next
+	}
+	return result
+}
+
+func hasPendingDependency(dependencies map[string]bool, pending []string, added map[string]bool) bool {
+	for _, candidate := range pending {
+		name := strings.ToLower(candidate)
+		if dependencies[name] && !added[name] {
+			return true
+		}
+	}
+	return false
+}
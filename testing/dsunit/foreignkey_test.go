@@ -0,0 +1,48 @@
+package dsunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/dsc"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestSortByDependency(t *testing.T) {
+	directory, err := ioutil.TempDir("", "fk")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	config, err := dsc.NewConfigWithParameters(sqliteDriver, "[url]", "", map[string]interface{}{
+		"url": path.Join(directory, "fk.db"),
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	manager, err := dsc.NewManagerFactory().Create(config)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer manager.ConnectionProvider().Close()
+	for _, SQL := range []string{
+		"CREATE TABLE account(id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE user(id INTEGER PRIMARY KEY, account_id INTEGER REFERENCES account(id), manager_id INTEGER REFERENCES user(id))",
+		"CREATE TABLE session(id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES user(id))",
+		"CREATE TABLE event(id INTEGER PRIMARY KEY, name TEXT)",
+	} {
+		_, err = manager.Execute(SQL)
+		if !assert.Nil(t, err, SQL) {
+			return
+		}
+	}
+	foreignKeys, err := ForeignKeys(manager, "", "user")
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, map[string]string{"account_id": "account.id", "manager_id": "user.id"}, foreignKeys)
+	}
+	sorted, err := sortByDependency(manager, []string{"session", "event", "user", "account"})
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, []string{"event", "account", "user", "session"}, sorted)
+	}
+}
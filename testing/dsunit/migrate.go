@@ -0,0 +1,332 @@
+package dsunit
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/viant/afs"
+	"github.com/viant/afs/storage"
+	"github.com/viant/assertly"
+	"github.com/viant/dsc"
+	"github.com/viant/dsunit"
+	"github.com/viant/dsunit/script"
+	"github.com/viant/endly"
+	estorage "github.com/viant/endly/system/storage"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultMigrationTable = "endly_schema_migrations"
+
+var (
+	//golang-migrate naming: {version}_{title}.up.sql, {version}_{title}.down.sql
+	golangMigrateExpr = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+	//Flyway naming: V{version}__{description}.sql, U{version}__{description}.sql (undo)
+	flywayExpr = regexp.MustCompile(`^([VU])(\d+(?:[._]\d+)*)__(.+)\.sql$`)
+)
+
+//MigrateRequest represents a request to apply versioned SQL migrations
+type MigrateRequest struct {
+	Datastore   string              `required:"true" description:"registered datastore name"`
+	URL         string              `required:"true" description:"migrations folder URL with golang-migrate (1_init.up.sql) or Flyway (V1__init.sql) named scripts"`
+	Credentials string              `description:"migrations folder URL credentials"`
+	Version     string              `description:"target version, default latest, lower than current version reverts migrations with down/undo scripts"`
+	Table       string              `description:"applied migrations history table, default: endly_schema_migrations"`
+	Expand      bool                `description:"substitute $ expression with content of context.state"`
+	CheckSchema *CheckSchemaRequest `description:"optional schema check run after migration, dest defaults to the migrated datastore"`
+}
+
+//MigrateResponse represents a migrate response
+type MigrateResponse struct {
+	Version  string               `description:"current schema version"`
+	Applied  []string             `description:"versions applied by this request"`
+	Reverted []string             `description:"versions reverted by this request"`
+	Versions []string             `description:"all applied versions"`
+	Schema   *CheckSchemaResponse `description:"schema check response"`
+}
+
+//Init initializes request
+func (r *MigrateRequest) Init() error {
+	if r.Table == "" {
+		r.Table = defaultMigrationTable
+	}
+	if strings.ToLower(r.Version) == "latest" {
+		r.Version = ""
+	}
+	if r.CheckSchema != nil && r.CheckSchema.Dest == nil {
+		r.CheckSchema.Dest = &dsunit.SchemaTarget{Datastore: r.Datastore}
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *MigrateRequest) Validate() error {
+	if r.Datastore == "" {
+		return errors.New("datastore was empty")
+	}
+	if r.URL == "" {
+		return errors.New("URL was empty")
+	}
+	if r.Version != "" && parseMigrationVersion(r.Version) == nil {
+		return fmt.Errorf("invalid version: %v", r.Version)
+	}
+	if r.CheckSchema != nil && r.CheckSchema.Source == nil {
+		return errors.New("checkSchema.source was empty")
+	}
+	return nil
+}
+
+//Assertion returns schema check validation slice
+func (r *MigrateResponse) Assertion() []*assertly.Validation {
+	if r == nil || r.Schema == nil {
+		return []*assertly.Validation{}
+	}
+	return r.Schema.Assertion()
+}
+
+//migration represents a versioned migration with optional down (undo) script
+type migration struct {
+	Version     string
+	version     []int
+	Description string
+	Up          string
+	Down        string
+}
+
+//parseMigrationVersion parses dot or underscore separated version, returns nil if invalid
+func parseMigrationVersion(version string) []int {
+	fragments := strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '_'
+	})
+	if len(fragments) == 0 {
+		return nil
+	}
+	var result = make([]int, len(fragments))
+	for i, fragment := range fragments {
+		value, err := toolbox.ToInt(fragment)
+		if err != nil {
+			return nil
+		}
+		result[i] = value
+	}
+	return result
+}
+
+//compareMigrationVersions returns -1, 0, 1 if version1 is lower, equal or greater than version2
+func compareMigrationVersions(version1, version2 []int) int {
+	for i := 0; i < len(version1) || i < len(version2); i++ {
+		var v1, v2 int
+		if i < len(version1) {
+			v1 = version1[i]
+		}
+		if i < len(version2) {
+			v2 = version2[i]
+		}
+		if v1 != v2 {
+			if v1 < v2 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+//normalizeMigrationVersion returns version without leading zeros, i.e. 0001 -> 1, 1_1 -> 1.1
+func normalizeMigrationVersion(version []int) string {
+	var fragments = make([]string, len(version))
+	for i, fragment := range version {
+		fragments[i] = toolbox.AsString(fragment)
+	}
+	return strings.Join(fragments, ".")
+}
+
+//loadMigrations lists migrations folder, returns migrations sorted by version
+func loadMigrations(fs afs.Service, URL string, options ...storage.Option) ([]*migration, error) {
+	objects, err := fs.List(context.Background(), URL, options...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list migrations: %v", URL)
+	}
+	var indexed = make(map[string]*migration)
+	for _, object := range objects {
+		if object.IsDir() {
+			continue
+		}
+		name := path.Base(object.URL())
+		var version, description string
+		isDown := false
+		if matched := golangMigrateExpr.FindStringSubmatch(name); len(matched) > 0 {
+			version, description, isDown = matched[1], matched[2], matched[3] == "down"
+		} else if matched := flywayExpr.FindStringSubmatch(name); len(matched) > 0 {
+			version, description, isDown = matched[2], strings.Replace(matched[3], "_", " ", -1), matched[1] == "U"
+		} else {
+			continue
+		}
+		parsed := parseMigrationVersion(version)
+		key := normalizeMigrationVersion(parsed)
+		item, ok := indexed[key]
+		if !ok {
+			item = &migration{Version: key, version: parsed, Description: description}
+			indexed[key] = item
+		}
+		if isDown {
+			item.Down = object.URL()
+			continue
+		}
+		if item.Up != "" {
+			return nil, fmt.Errorf("duplicate migration version %v: %v, %v", key, item.Up, object.URL())
+		}
+		item.Up = object.URL()
+	}
+	var result = make([]*migration, 0)
+	for _, item := range indexed {
+		if item.Up == "" {
+			return nil, fmt.Errorf("missing up migration for version %v: %v", item.Version, item.Down)
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return compareMigrationVersions(result[i].version, result[j].version) < 0
+	})
+	return result, nil
+}
+
+//appliedMigrations returns applied versions, history table is created if needed
+func (s *service) appliedMigrations(manager dsc.Manager, table string) (map[string]bool, error) {
+	dialect := dsc.GetDatastoreDialect(manager.Config().DriverName)
+	datastore, err := dialect.GetCurrentDatastore(manager)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := dialect.GetTables(manager, datastore)
+	if err != nil {
+		return nil, err
+	}
+	hasTable := false
+	for _, candidate := range tables {
+		if strings.EqualFold(candidate, table) {
+			hasTable = true
+			break
+		}
+	}
+	if !hasTable {
+		if _, err = manager.Execute(fmt.Sprintf("CREATE TABLE %v(version VARCHAR(64) NOT NULL PRIMARY KEY, description VARCHAR(255), script VARCHAR(255), applied_at VARCHAR(32))", table)); err != nil {
+			return nil, errors.Wrapf(err, "failed to create migration table: %v", table)
+		}
+	}
+	var records = make([]map[string]interface{}, 0)
+	if err = manager.ReadAll(&records, fmt.Sprintf("SELECT version FROM %v", table), nil, nil); err != nil {
+		return nil, err
+	}
+	var result = make(map[string]bool)
+	for _, record := range records {
+		for k, v := range record {
+			if strings.EqualFold(k, "version") {
+				result[toolbox.AsString(v)] = true
+			}
+		}
+	}
+	return result, nil
+}
+
+//runMigrationScript runs migration script, script is translated to SQLite if datastore runs in smoke mode
+func (s *service) runMigrationScript(context *endly.Context, fs afs.Service, request *MigrateRequest, URL string, options ...storage.Option) error {
+	content, err := fs.DownloadWithURL(context.Background(), URL, options...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load migration: %v", URL)
+	}
+	text := string(content)
+	if mode := getSmokeMode(context); mode != nil && mode.isMapped(request.Datastore) {
+		text = translateToSQLite(text)
+	}
+	SQL := script.ParseWithReader(strings.NewReader(text))
+	if len(SQL) == 0 {
+		return nil
+	}
+	response := s.Service.RunSQL(&dsunit.RunSQLRequest{Datastore: request.Datastore, Expand: request.Expand, SQL: SQL})
+	if err = response.Error(); err != nil {
+		return errors.Wrapf(err, "failed to run migration: %v", URL)
+	}
+	return nil
+}
+
+func (s *service) migrate(context *endly.Context, request *MigrateRequest) (*MigrateResponse, error) {
+	manager, err := s.getManager(request.Datastore)
+	if err != nil {
+		return nil, err
+	}
+	resource, storageOptions, err := estorage.GetResourceWithOptions(context, url.NewResource(request.URL, request.Credentials))
+	if err != nil {
+		return nil, err
+	}
+	fs, err := estorage.StorageService(context, resource)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := loadMigrations(fs, resource.URL, storageOptions...)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := s.appliedMigrations(manager, request.Table)
+	if err != nil {
+		return nil, err
+	}
+	var target []int
+	if request.Version != "" {
+		target = parseMigrationVersion(request.Version)
+	} else if len(migrations) > 0 {
+		target = migrations[len(migrations)-1].version
+	}
+	response := &MigrateResponse{Applied: make([]string, 0), Reverted: make([]string, 0)}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		item := migrations[i]
+		if !applied[item.Version] || compareMigrationVersions(item.version, target) <= 0 {
+			continue
+		}
+		if item.Down == "" {
+			return nil, fmt.Errorf("unable to revert version %v: missing down/undo migration", item.Version)
+		}
+		if err = s.runMigrationScript(context, fs, request, item.Down, storageOptions...); err != nil {
+			return nil, err
+		}
+		if _, err = manager.Execute(fmt.Sprintf("DELETE FROM %v WHERE version = ?", request.Table), item.Version); err != nil {
+			return nil, err
+		}
+		delete(applied, item.Version)
+		response.Reverted = append(response.Reverted, item.Version)
+	}
+	for _, item := range migrations {
+		if applied[item.Version] || compareMigrationVersions(item.version, target) > 0 {
+			continue
+		}
+		if err = s.runMigrationScript(context, fs, request, item.Up, storageOptions...); err != nil {
+			return nil, err
+		}
+		if _, err = manager.Execute(fmt.Sprintf("INSERT INTO %v(version, description, script, applied_at) VALUES(?, ?, ?, ?)", request.Table),
+			item.Version, item.Description, path.Base(item.Up), time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return nil, err
+		}
+		applied[item.Version] = true
+		response.Applied = append(response.Applied, item.Version)
+	}
+	for _, item := range migrations {
+		if applied[item.Version] {
+			response.Versions = append(response.Versions, item.Version)
+			response.Version = item.Version
+		}
+	}
+	if request.CheckSchema != nil {
+		checkRequest := dsunit.CheckSchemaRequest(*request.CheckSchema)
+		checkResponse := CheckSchemaResponse(*s.Service.CheckSchema(&checkRequest))
+		response.Schema = &checkResponse
+		if err = checkResponse.Error(); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
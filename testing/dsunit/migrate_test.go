@@ -0,0 +1,51 @@
+package dsunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/toolbox/url"
+	"testing"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	var useCases = []struct {
+		description string
+		URL         string
+		expect      []string
+		hasDown     []bool
+	}{
+		{
+			description: "golang-migrate naming",
+			URL:         "test/migration/golang",
+			expect:      []string{"1", "2"},
+			hasDown:     []bool{true, true},
+		},
+		{
+			description: "flyway naming",
+			URL:         "test/migration/flyway",
+			expect:      []string{"1", "1.1", "2"},
+			hasDown:     []bool{false, false, true},
+		},
+	}
+	fs := afs.New()
+	for _, useCase := range useCases {
+		migrations, err := loadMigrations(fs, url.NewResource(useCase.URL).URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if !assert.Equal(t, len(useCase.expect), len(migrations), useCase.description) {
+			continue
+		}
+		for i, migration := range migrations {
+			assert.Equal(t, useCase.expect[i], migration.Version, useCase.description)
+			assert.Equal(t, useCase.hasDown[i], migration.Down != "", useCase.description)
+		}
+	}
+}
+
+func TestCompareMigrationVersions(t *testing.T) {
+	assert.Equal(t, -1, compareMigrationVersions(parseMigrationVersion("1_1"), parseMigrationVersion("1.2")))
+	assert.Equal(t, 0, compareMigrationVersions(parseMigrationVersion("0001"), parseMigrationVersion("1.0")))
+	assert.Equal(t, 1, compareMigrationVersions(parseMigrationVersion("20190102"), parseMigrationVersion("10")))
+	assert.Nil(t, parseMigrationVersion("v1"))
+}
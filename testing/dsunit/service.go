@@ -179,6 +179,39 @@ const (
 	"Prefix":"expect_"
   }`
 	dsunitServiceMapping = `{"mappings":{"URL":"regression/db1/mapping.json"}}`
+
+	dsunitServiceMigrateExample = `{
+		"Datastore": "db1",
+		"URL": "datastore/db1/migration",
+		"Version": "3",
+		"CheckSchema": {
+			"Source": {
+				"Datastore": "db1_reference"
+			}
+		}
+	}`
+
+	dsunitServiceSmokeExample = `{
+		"BaseDirectory": "/tmp/smoke",
+		"Datastores": [
+			"db1"
+		]
+	}`
+
+	dsunitServiceSnapshotExample = `{
+		"Datastore": "db1",
+		"Tables": [
+			"table1",
+			"table2"
+		],
+		"Strategy": "memory",
+		"RestoreOnTag": true
+	}`
+
+	dsunitServiceRestoreExample = `{
+		"Datastore": "db1",
+		"Discard": true
+	}`
 )
 
 func expandTablesIfNeeded(context *endly.Context, req *InitRequest) {
@@ -227,6 +260,11 @@ func (s *service) registerRoutes() {
 			}
 
 			if req, ok := request.(*dsunit.RegisterRequest); ok {
+				if mode := getSmokeMode(context); mode != nil {
+					if err := mode.mapRegister(req); err != nil {
+						return nil, err
+					}
+				}
 				if req.Config != nil {
 					expandConfigParameters(context, req.Config.Parameters)
 					s.publishConfigParameters(context, req.Config)
@@ -297,6 +335,11 @@ func (s *service) registerRoutes() {
 						return nil, err
 					}
 				}
+				if mode := getSmokeMode(context); mode != nil {
+					if err = mode.mapScripts(context, req); err != nil {
+						return nil, err
+					}
+				}
 				resp := s.Service.RunScript(req)
 				response := RunSQLResponse(*resp)
 				return &response, response.Error()
@@ -390,6 +433,11 @@ func (s *service) registerRoutes() {
 			}
 
 			if req, ok := request.(*dsunit.InitRequest); ok {
+				if mode := getSmokeMode(context); mode != nil {
+					if err := mode.mapInit(context, req); err != nil {
+						return nil, err
+					}
+				}
 				if req.Config != nil {
 					expandConfigParameters(context, req.Config.Parameters)
 					s.publishConfigParameters(context, req.Config)
@@ -483,7 +531,17 @@ func (s *service) registerRoutes() {
 						})
 					}
 				}
-
+				var manager dsc.Manager
+				if req.DatastoreDatasets != nil {
+					manager = s.Service.Registry().Get(req.Datastore)
+				}
+				diffEvent, err := newExpectDiffEvent(manager, resp)
+				if err != nil {
+					return nil, err
+				}
+				if diffEvent != nil {
+					context.Publish(diffEvent)
+				}
 				return &response, response.Error()
 			}
 			return nil, fmt.Errorf("unsupported request type: %T", request)
@@ -603,6 +661,9 @@ func (s *service) registerRoutes() {
 			if req, ok := request.(*dsunit.CompareRequest); ok {
 				resp := s.Service.Compare(req)
 				response := CompareResponse(*resp)
+				if diffEvent := newCompareDiffEvent(req, resp); diffEvent != nil {
+					context.Publish(diffEvent)
+				}
 				var err = response.Error()
 				return &response, err
 			}
@@ -637,6 +698,106 @@ func (s *service) registerRoutes() {
 		},
 	})
 
+	s.Register(&endly.Route{
+		Action: "migrate",
+		RequestInfo: &endly.ActionInfo{
+			Description: "apply versioned SQL migrations (golang-migrate or Flyway naming) up to a target version",
+			Examples: []*endly.UseCase{
+				{
+					Description: "migrate",
+					Data:        dsunitServiceMigrateExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &MigrateRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &MigrateResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*MigrateRequest); ok {
+				return s.migrate(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "smoke",
+		RequestInfo: &endly.ActionInfo{
+			Description: "map subsequently registered MySQL/Postgres datastores onto embedded SQLite files, DDL scripts are translated to SQLite",
+			Examples: []*endly.UseCase{
+				{
+					Description: "smoke",
+					Data:        dsunitServiceSmokeExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &SmokeRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &SmokeResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*SmokeRequest); ok {
+				return s.smoke(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "snapshot",
+		RequestInfo: &endly.ActionInfo{
+			Description: "capture datastore tables state to be restored later",
+			Examples: []*endly.UseCase{
+				{
+					Description: "snapshot",
+					Data:        dsunitServiceSnapshotExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &SnapshotRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &SnapshotResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*SnapshotRequest); ok {
+				return s.snapshot(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "restore",
+		RequestInfo: &endly.ActionInfo{
+			Description: "restore datastore tables state from a snapshot",
+			Examples: []*endly.UseCase{
+				{
+					Description: "restore",
+					Data:        dsunitServiceRestoreExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &RestoreRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &RestoreResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*RestoreRequest); ok {
+				return s.restore(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
 	s.Register(&endly.Route{
 		Action: "sequence",
 		RequestInfo: &endly.ActionInfo{
@@ -695,6 +856,19 @@ func (s *service) Run(context *endly.Context, request interface{}) *endly.Servic
 	return s.AbstractService.Run(context, request)
 }
 
+//GetManager returns registered datastore manager
+func GetManager(context *endly.Context, datastore string) (dsc.Manager, error) {
+	endlyService, err := context.Service(ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	dsService, ok := endlyService.(*service)
+	if !ok {
+		return nil, fmt.Errorf("unsupported service type: %T", endlyService)
+	}
+	return dsService.getManager(datastore)
+}
+
 //New creates a new Datastore unit service
 func New() endly.Service {
 	var result = &service{
@@ -215,3 +215,72 @@ func TestDsUnitService_Errors(t *testing.T) {
 	assert.True(t, serviceResponse.Error != "")
 
 }
+
+func TestDsUnitService_SnapshotRestore(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := getRegisteredDsUnitService(manager, context, "mydb1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, strategy := range []string{SnapshotStrategyMemory, SnapshotStrategyTable, SnapshotStrategyFile} {
+		serviceResponse := service.Run(context, dsunit.NewPrepareRequest(dsunit.NewDatasetResource("mydb1", url.NewResource("test/dataset1").URL, "prepare_", "")))
+		if !assert.Equal(t, "", serviceResponse.Error, strategy) {
+			continue
+		}
+		serviceResponse = service.Run(context, &SnapshotRequest{Datastore: "mydb1", Tables: []string{"ACCOUNT", "USER"}, Strategy: strategy})
+		if !assert.Equal(t, "", serviceResponse.Error, strategy) {
+			continue
+		}
+		serviceResponse = service.Run(context, dsunit.NewRunSQLRequest("mydb1", "DELETE FROM USER", "DELETE FROM ACCOUNT"))
+		assert.Equal(t, "", serviceResponse.Error, strategy)
+
+		serviceResponse = service.Run(context, &RestoreRequest{Datastore: "mydb1", Discard: true})
+		if !assert.Equal(t, "", serviceResponse.Error, strategy) {
+			continue
+		}
+		serviceResponse = service.Run(context, dsunit.NewExpectRequest(0,
+			dsunit.NewDatasetResource("mydb1", url.NewResource("test/dataset1").URL, "verify_", "")))
+		if assert.Equal(t, "", serviceResponse.Error, strategy) {
+			verifyResponse, ok := serviceResponse.Response.(*ExpectResponse)
+			if assert.True(t, ok) {
+				assert.EqualValues(t, 0, len(verifyResponse.Validation[0].Failures), strategy)
+			}
+		}
+	}
+	serviceResponse := service.Run(context, &RestoreRequest{Datastore: "mydb1"})
+	assert.True(t, serviceResponse.Error != "")
+}
+
+func TestDsUnitService_Migrate(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := getRegisteredDsUnitService(manager, context, "mydb1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	serviceResponse := service.Run(context, &MigrateRequest{Datastore: "mydb1", URL: "test/migration/golang", Version: "1"})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response, ok := serviceResponse.Response.(*MigrateResponse)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, []string{"1"}, response.Applied)
+			assert.Equal(t, "1", response.Version)
+		}
+	}
+	serviceResponse = service.Run(context, &MigrateRequest{Datastore: "mydb1", URL: "test/migration/golang"})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response, ok := serviceResponse.Response.(*MigrateResponse)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, []string{"2"}, response.Applied)
+			assert.EqualValues(t, []string{"1", "2"}, response.Versions)
+		}
+	}
+	serviceResponse = service.Run(context, &MigrateRequest{Datastore: "mydb1", URL: "test/migration/golang", Version: "0"})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response, ok := serviceResponse.Response.(*MigrateResponse)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, []string{"2", "1"}, response.Reverted)
+			assert.Equal(t, "", response.Version)
+		}
+	}
+}
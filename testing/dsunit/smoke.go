@@ -0,0 +1,220 @@
+package dsunit
+
+import (
+	"fmt"
+	"github.com/viant/dsc"
+	"github.com/viant/dsunit"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+)
+
+//SmokeEnvKey represents environment variable enabling smoke mode for all sessions, value is either true or SQLite base directory
+const SmokeEnvKey = "ENDLY_DSUNIT_SMOKE"
+
+var smokeDrivers = map[string]bool{
+	"mysql":    true,
+	"postgres": true,
+	"pgx":      true,
+}
+
+//SmokeRequest represents a request to map MySQL/Postgres datastores onto embedded SQLite files for the session
+type SmokeRequest struct {
+	BaseDirectory string   `description:"SQLite files directory, default: $TMPDIR/endly/dsunit/smoke"`
+	Datastores    []string `description:"datastores to map, default all MySQL/Postgres datastores registered after this request"`
+	Disable       bool     `description:"flag to disable smoke mode for subsequently registered datastores"`
+}
+
+//SmokeResponse represents a smoke response
+type SmokeResponse struct {
+	BaseDirectory string
+	Enabled       bool
+}
+
+//Init initializes request
+func (r *SmokeRequest) Init() error {
+	if r.BaseDirectory == "" {
+		r.BaseDirectory = path.Join(os.TempDir(), "endly", "dsunit", "smoke")
+	}
+	r.BaseDirectory = url.NewResource(r.BaseDirectory).ParsedURL.Path
+	return nil
+}
+
+//smokeMode represents session smoke mode
+type smokeMode struct {
+	*SmokeRequest
+	mux    *sync.RWMutex
+	mapped map[string]bool
+}
+
+var smokeModeKey = (*smokeMode)(nil)
+
+//getSmokeMode returns session smoke mode or nil, ENDLY_DSUNIT_SMOKE enables it by default
+func getSmokeMode(context *endly.Context) *smokeMode {
+	var result *smokeMode
+	if context.Contains(smokeModeKey) {
+		context.GetInto(smokeModeKey, &result)
+	} else if value := os.Getenv(SmokeEnvKey); value != "" && value != "false" {
+		request := &SmokeRequest{}
+		if value != "true" {
+			request.BaseDirectory = value
+		}
+		_ = request.Init()
+		result = newSmokeMode(request)
+		_ = context.Put(smokeModeKey, result)
+	}
+	return result
+}
+
+//isMapped returns true if datastore has been mapped onto SQLite
+func (m *smokeMode) isMapped(datastore string) bool {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.mapped[datastore]
+}
+
+//canMap returns true if datastore config can be mapped onto SQLite
+func (m *smokeMode) canMap(datastore string, config *dsc.Config) bool {
+	if m.Disable || config == nil || !smokeDrivers[config.DriverName] {
+		return false
+	}
+	if len(m.Datastores) == 0 {
+		return true
+	}
+	return toolbox.HasSliceAnyElements(m.Datastores, datastore)
+}
+
+//mapConfig replaces datastore config with SQLite file config
+func (m *smokeMode) mapConfig(datastore string, config *dsc.Config) (*dsc.Config, error) {
+	if err := os.MkdirAll(m.BaseDirectory, 0744); err != nil {
+		return nil, err
+	}
+	result, err := dsc.NewConfigWithParameters(sqliteDriver, "[url]", "", map[string]interface{}{
+		"url":    path.Join(m.BaseDirectory, datastore+".db"),
+		"dbname": datastore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range config.Parameters {
+		if _, has := result.Parameters[k]; !has {
+			result.Parameters[k] = v
+		}
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.mapped[datastore] = true
+	return result, nil
+}
+
+//mapRegister maps register request onto SQLite if needed
+func (m *smokeMode) mapRegister(request *dsunit.RegisterRequest) (err error) {
+	if request == nil {
+		return nil
+	}
+	if err = loadRegisterConfig(request); err != nil {
+		return err
+	}
+	if !m.canMap(request.Datastore, request.Config) {
+		return nil
+	}
+	request.Config, err = m.mapConfig(request.Datastore, request.Config)
+	request.ConfigURL = ""
+	return err
+}
+
+//loadRegisterConfig loads register config from config URL, so that it can be mapped
+func loadRegisterConfig(request *dsunit.RegisterRequest) (err error) {
+	if request.Config != nil || request.ConfigURL == "" {
+		return nil
+	}
+	if request.Config, err = dsc.NewConfigFromURL(request.ConfigURL); err != nil {
+		return fmt.Errorf("failed to load %v config: %v, %v", request.Datastore, request.ConfigURL, err)
+	}
+	request.ConfigURL = ""
+	return nil
+}
+
+//mapInit maps init request onto SQLite, admin datastore is not used as SQLite datastore is its own admin
+func (m *smokeMode) mapInit(context *endly.Context, request *dsunit.InitRequest) error {
+	if request.RegisterRequest == nil {
+		return nil
+	}
+	datastore := request.RegisterRequest.Datastore
+	if datastore == "" {
+		datastore = request.Datastore
+	}
+	if err := loadRegisterConfig(request.RegisterRequest); err != nil {
+		return err
+	}
+	if !m.canMap(datastore, request.RegisterRequest.Config) {
+		return nil
+	}
+	request.RegisterRequest.Datastore = datastore
+	if err := m.mapRegister(request.RegisterRequest); err != nil {
+		return err
+	}
+	request.Admin = nil
+	if request.RunScriptRequest == nil {
+		return nil
+	}
+	if request.RunScriptRequest.Datastore == "" {
+		request.RunScriptRequest.Datastore = datastore
+	}
+	return m.mapScripts(context, request.RunScriptRequest)
+}
+
+//mapScripts translates scripts of a mapped datastore into SQLite DDL files
+func (m *smokeMode) mapScripts(context *endly.Context, request *dsunit.RunScriptRequest) error {
+	if !m.isMapped(request.Datastore) {
+		return nil
+	}
+	for i, script := range request.Scripts {
+		resource, err := context.ExpandResource(script)
+		if err != nil {
+			return err
+		}
+		DDL, err := resource.DownloadText()
+		if err != nil {
+			return fmt.Errorf("failed to load script %v, %v", resource.URL, err)
+		}
+		if request.Expand {
+			state := context.State()
+			DDL = state.ExpandAsText(DDL)
+		}
+		location := path.Join(m.BaseDirectory, fmt.Sprintf("%v_%v", request.Datastore, path.Base(resource.ParsedURL.Path)))
+		if err = ioutil.WriteFile(location, []byte(translateToSQLite(DDL)), 0644); err != nil {
+			return err
+		}
+		request.Scripts[i] = url.NewResource(location)
+	}
+	return nil
+}
+
+func newSmokeMode(request *SmokeRequest) *smokeMode {
+	return &smokeMode{
+		SmokeRequest: request,
+		mux:          &sync.RWMutex{},
+		mapped:       make(map[string]bool),
+	}
+}
+
+func (s *service) smoke(context *endly.Context, request *SmokeRequest) (*SmokeResponse, error) {
+	mode := newSmokeMode(request)
+	if previous := getSmokeMode(context); previous != nil {
+		//already mapped datastores keep using SQLite
+		previous.mux.RLock()
+		for datastore := range previous.mapped {
+			mode.mapped[datastore] = true
+		}
+		previous.mux.RUnlock()
+	}
+	if err := context.Replace(smokeModeKey, mode); err != nil {
+		return nil, err
+	}
+	return &SmokeResponse{BaseDirectory: request.BaseDirectory, Enabled: !request.Disable}, nil
+}
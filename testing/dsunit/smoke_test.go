@@ -0,0 +1,36 @@
+package dsunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/dsunit"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestSmokeMode_MapRegister(t *testing.T) {
+	directory, err := ioutil.TempDir("", "smoke")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	configURL := path.Join(directory, "db1.json")
+	config := `{"DriverName":"mysql","Descriptor":"[username]:[password]@tcp(127.0.0.1:3306)/[dbname]?parseTime=true","Parameters":{"dbname":"db1"}}`
+	if !assert.Nil(t, ioutil.WriteFile(configURL, []byte(config), 0644)) {
+		return
+	}
+	mode := newSmokeMode(&SmokeRequest{BaseDirectory: directory})
+
+	request := &dsunit.RegisterRequest{Datastore: "db1", ConfigURL: configURL}
+	assert.Nil(t, mode.mapRegister(request))
+	if assert.NotNil(t, request.Config) {
+		assert.Equal(t, sqliteDriver, request.Config.DriverName)
+		assert.Equal(t, path.Join(directory, "db1.db"), request.Config.Get("url"))
+	}
+	assert.Equal(t, "", request.ConfigURL)
+	assert.True(t, mode.isMapped("db1"))
+
+	request = &dsunit.RegisterRequest{Datastore: "db2", ConfigURL: path.Join(directory, "missing.json")}
+	assert.NotNil(t, mode.mapRegister(request))
+}
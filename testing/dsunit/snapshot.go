@@ -0,0 +1,394 @@
+package dsunit
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/viant/dsc"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	//SnapshotStrategyMemory captures table rows in endly process memory (default)
+	SnapshotStrategyMemory = "memory"
+	//SnapshotStrategyTable captures table rows into server side shadow tables
+	SnapshotStrategyTable = "table"
+	//SnapshotStrategyFile captures SQLite database file copy
+	SnapshotStrategyFile = "file"
+)
+
+const (
+	defaultSnapshotPrefix = "snapshot_"
+	sqliteDriver          = "sqlite3"
+)
+
+//SnapshotRequest represents a request to capture datastore tables state
+type SnapshotRequest struct {
+	Datastore    string   `required:"true" description:"registered datastore name"`
+	Name         string   `description:"snapshot name, default datastore name"`
+	Tables       []string `description:"tables to capture, default all datastore tables"`
+	Strategy     string   `description:"snapshot strategy: memory (default), table - server side shadow tables, file - SQLite database file copy"`
+	Prefix       string   `description:"shadow table prefix for table strategy, default: snapshot_"`
+	RestoreOnTag bool     `description:"flag to restore snapshot once all actions of each workflow TagID (use case) have been run"`
+}
+
+//SnapshotResponse represents a snapshot response
+type SnapshotResponse struct {
+	Name     string
+	Strategy string
+	Tables   map[string]int `description:"captured row count per table, -1 if unknown"`
+}
+
+//RestoreRequest represents a request to restore datastore tables from a snapshot
+type RestoreRequest struct {
+	Datastore string `description:"registered datastore name, used as snapshot name if name is empty"`
+	Name      string `description:"snapshot name"`
+	Discard   bool   `description:"flag to discard snapshot after restore, it also removes shadow tables/file copy and TagID restore hook"`
+}
+
+//RestoreResponse represents a restore response
+type RestoreResponse struct {
+	Name   string
+	Tables map[string]int `description:"restored row count per table, -1 if unknown"`
+}
+
+//Init initializes request
+func (r *SnapshotRequest) Init() error {
+	if r.Name == "" {
+		r.Name = r.Datastore
+	}
+	if r.Strategy == "" {
+		r.Strategy = SnapshotStrategyMemory
+	}
+	if r.Prefix == "" {
+		r.Prefix = defaultSnapshotPrefix
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *SnapshotRequest) Validate() error {
+	if r.Datastore == "" {
+		return errors.New("datastore was empty")
+	}
+	switch r.Strategy {
+	case SnapshotStrategyMemory, SnapshotStrategyTable, SnapshotStrategyFile:
+	default:
+		return fmt.Errorf("unsupported snapshot strategy: %v", r.Strategy)
+	}
+	return nil
+}
+
+//Init initializes request
+func (r *RestoreRequest) Init() error {
+	if r.Name == "" {
+		r.Name = r.Datastore
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *RestoreRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name was empty")
+	}
+	return nil
+}
+
+//snapshot represents captured datastore tables state
+type snapshot struct {
+	*SnapshotRequest
+	records map[string][]map[string]interface{}
+	file    string
+}
+
+//snapshots represents session snapshots
+type snapshots struct {
+	mux   *sync.Mutex
+	items map[string]*snapshot
+}
+
+var snapshotsKey = (*snapshots)(nil)
+
+func getSnapshots(context *endly.Context) *snapshots {
+	var result *snapshots
+	if !context.Contains(snapshotsKey) {
+		result = &snapshots{mux: &sync.Mutex{}, items: make(map[string]*snapshot)}
+		_ = context.Put(snapshotsKey, result)
+		return result
+	}
+	context.GetInto(snapshotsKey, &result)
+	return result
+}
+
+func (s *snapshots) get(name string) (*snapshot, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	result, ok := s.items[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown snapshot: %v", name)
+	}
+	return result, nil
+}
+
+func (s *snapshots) put(snapshot *snapshot) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.items[snapshot.Name] = snapshot
+}
+
+func (s *snapshots) remove(name string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.items, name)
+}
+
+func snapshotListenerName(name string) string {
+	return ServiceID + ":" + name
+}
+
+func (s *service) getManager(datastore string) (dsc.Manager, error) {
+	manager := s.Service.Registry().Get(datastore)
+	if manager == nil {
+		return nil, fmt.Errorf("unknown datastore: %v", datastore)
+	}
+	return manager, nil
+}
+
+func (s *service) snapshot(context *endly.Context, request *SnapshotRequest) (*SnapshotResponse, error) {
+	manager, err := s.getManager(request.Datastore)
+	if err != nil {
+		return nil, err
+	}
+	dialect := dsc.GetDatastoreDialect(manager.Config().DriverName)
+	tables := request.Tables
+	if len(tables) == 0 {
+		datastore, err := dialect.GetCurrentDatastore(manager)
+		if err != nil {
+			return nil, err
+		}
+		if tables, err = dialect.GetTables(manager, datastore); err != nil {
+			return nil, err
+		}
+		var filtered = make([]string, 0)
+		for _, table := range tables {
+			if !strings.HasPrefix(table, request.Prefix) {
+				filtered = append(filtered, table)
+			}
+		}
+		tables = filtered
+	}
+	var snapshotRequest = *request
+	snapshotRequest.Tables = tables
+	var captured = &snapshot{SnapshotRequest: &snapshotRequest}
+	response := &SnapshotResponse{Name: request.Name, Strategy: request.Strategy, Tables: make(map[string]int)}
+	switch request.Strategy {
+	case SnapshotStrategyMemory:
+		if captured.records, err = readTables(manager, tables); err != nil {
+			return nil, err
+		}
+		for table, records := range captured.records {
+			response.Tables[table] = len(records)
+		}
+	case SnapshotStrategyTable:
+		for _, table := range tables {
+			shadow := request.Prefix + table
+			_ = dialect.DropTable(manager, "", shadow)
+			if _, err = manager.Execute(fmt.Sprintf("CREATE TABLE %v AS SELECT * FROM %v", shadow, table)); err != nil {
+				return nil, errors.Wrapf(err, "failed to create shadow table %v", shadow)
+			}
+			response.Tables[table] = -1
+		}
+	case SnapshotStrategyFile:
+		if captured.file, err = copySQLiteFile(manager.Config(), request.Name); err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			response.Tables[table] = -1
+		}
+	}
+	getSnapshots(context).put(captured)
+	if request.RestoreOnTag {
+		model.GetTagListeners(context).Register(snapshotListenerName(request.Name), func(context *endly.Context, tagID string) error {
+			_, err := s.restore(context, &RestoreRequest{Name: snapshotRequest.Name})
+			return err
+		})
+	}
+	return response, nil
+}
+
+func (s *service) restore(context *endly.Context, request *RestoreRequest) (*RestoreResponse, error) {
+	snapshots := getSnapshots(context)
+	captured, err := snapshots.get(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := s.getManager(captured.Datastore)
+	if err != nil {
+		return nil, err
+	}
+	records := captured.records
+	if captured.Strategy == SnapshotStrategyFile {
+		if records, err = readSQLiteFile(captured.file, captured.Tables); err != nil {
+			return nil, err
+		}
+	}
+	response := &RestoreResponse{Name: request.Name, Tables: make(map[string]int)}
+	if err = s.restoreTables(manager, captured, records, response); err != nil {
+		return nil, errors.Wrapf(err, "failed to restore snapshot: %v", request.Name)
+	}
+	if !request.Discard {
+		return response, nil
+	}
+	snapshots.remove(request.Name)
+	model.GetTagListeners(context).Unregister(snapshotListenerName(request.Name))
+	switch captured.Strategy {
+	case SnapshotStrategyTable:
+		dialect := dsc.GetDatastoreDialect(manager.Config().DriverName)
+		for _, table := range captured.Tables {
+			_ = dialect.DropTable(manager, "", captured.Prefix+table)
+		}
+	case SnapshotStrategyFile:
+		_ = os.Remove(captured.file)
+	}
+	return response, nil
+}
+
+//restoreTables replaces tables rows with captured ones within a single transaction, rows are deleted from referencing tables first
+//and inserted into referenced tables first, foreign key checks are also disabled if the dialect supports it at session level
+func (s *service) restoreTables(manager dsc.Manager, captured *snapshot, records map[string][]map[string]interface{}, response *RestoreResponse) (err error) {
+	dialect := dsc.GetDatastoreDialect(manager.Config().DriverName)
+	tables, err := sortByDependency(manager, captured.Tables)
+	if err != nil {
+		return err
+	}
+	connection, err := manager.ConnectionProvider().Get()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+	if dialect.IsKeyCheckSwitchSessionLevel() {
+		if err = dialect.DisableForeignKeyCheck(manager, connection); err != nil {
+			return err
+		}
+		defer func() {
+			_ = dialect.EnableForeignKeyCheck(manager, connection)
+		}()
+	}
+	if err = connection.Begin(); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = connection.Rollback()
+			return
+		}
+		err = connection.Commit()
+	}()
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err = manager.ExecuteOnConnection(connection, fmt.Sprintf("DELETE FROM %v", tables[i]), nil); err != nil {
+			return err
+		}
+	}
+	for _, table := range tables {
+		if captured.Strategy == SnapshotStrategyTable {
+			if _, err = manager.ExecuteOnConnection(connection, fmt.Sprintf("INSERT INTO %v SELECT * FROM %v", table, captured.Prefix+table), nil); err != nil {
+				return err
+			}
+			response.Tables[table] = -1
+			continue
+		}
+		for _, record := range records[table] {
+			SQL, values := insertSQL(table, record)
+			if _, err = manager.ExecuteOnConnection(connection, SQL, values); err != nil {
+				return err
+			}
+		}
+		response.Tables[table] = len(records[table])
+	}
+	return nil
+}
+
+//readTables reads all supplied tables rows
+func readTables(manager dsc.Manager, tables []string) (map[string][]map[string]interface{}, error) {
+	var result = make(map[string][]map[string]interface{})
+	for _, table := range tables {
+		var records = make([]map[string]interface{}, 0)
+		if err := manager.ReadAll(&records, fmt.Sprintf("SELECT * FROM %v", table), nil, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to read %v", table)
+		}
+		result[table] = records
+	}
+	return result, nil
+}
+
+//readSQLiteFile reads tables rows from SQLite database file copy
+func readSQLiteFile(location string, tables []string) (map[string][]map[string]interface{}, error) {
+	config, err := dsc.NewConfigWithParameters(sqliteDriver, "[url]", "", map[string]interface{}{
+		"url": location,
+	})
+	if err != nil {
+		return nil, err
+	}
+	manager, err := dsc.NewManagerFactory().Create(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open snapshot %v", location)
+	}
+	defer manager.ConnectionProvider().Close()
+	return readTables(manager, tables)
+}
+
+//insertSQL returns parametrized insert SQL for supplied record, columns are sorted to keep SQL stable
+func insertSQL(table string, record map[string]interface{}) (string, []interface{}) {
+	var columns = make([]string, 0, len(record))
+	for column := range record {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	var values = make([]interface{}, len(columns))
+	var placeholders = make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = record[column]
+		placeholders[i] = "?"
+	}
+	return fmt.Sprintf("INSERT INTO %v(%v) VALUES(%v)", table, strings.Join(columns, ","), strings.Join(placeholders, ",")), values
+}
+
+//copySQLiteFile copies SQLite database file, returns the copy location
+func copySQLiteFile(config *dsc.Config, name string) (string, error) {
+	if config.DriverName != sqliteDriver {
+		return "", fmt.Errorf("%v snapshot strategy is only supported by %v driver, but had: %v", SnapshotStrategyFile, sqliteDriver, config.DriverName)
+	}
+	location, err := config.DsnDescriptor()
+	if err != nil {
+		return "", err
+	}
+	if index := strings.Index(location, "?"); index != -1 {
+		location = location[:index]
+	}
+	location = strings.TrimPrefix(location, "file:")
+	if !toolbox.FileExists(location) {
+		return "", fmt.Errorf("SQLite database file not found: %v", location)
+	}
+	target := fmt.Sprintf("%v.%v.snapshot", location, name)
+	source, err := os.Open(location)
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+	writer, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	defer writer.Close()
+	if _, err = io.Copy(writer, source); err != nil {
+		return "", errors.Wrapf(err, "failed to copy %v", location)
+	}
+	return target, nil
+}
@@ -0,0 +1,4 @@
+CREATE TABLE orders (
+  id INT PRIMARY KEY,
+  user_id INT NOT NULL
+);
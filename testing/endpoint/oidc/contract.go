@@ -0,0 +1,134 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/endly/testing/validator"
+	"strings"
+	"time"
+)
+
+//ListenRequest represents OIDC identity provider listen request
+type ListenRequest struct {
+	Port          int
+	Issuer        string    `description:"issuer URL, default: http://localhost:$Port"`
+	Users         []*User   `required:"true" description:"test users with claims"`
+	Clients       []*Client `description:"registered clients, if empty any client is accepted"`
+	ExpirySec     int       `description:"token expiry in seconds, default 3600"`
+	KeyID         string    `description:"JWKS key id, default: endly"`
+	KeyLocation   string    `description:"PEM encoded RSA private key location, if empty a new key is generated"`
+	DefaultScopes []string  `description:"scopes granted when none requested, default: openid profile email"`
+}
+
+//Init initializes request
+func (r *ListenRequest) Init() error {
+	if r.Issuer == "" && r.Port > 0 {
+		r.Issuer = fmt.Sprintf("http://localhost:%v", r.Port)
+	}
+	r.Issuer = strings.TrimRight(r.Issuer, "/")
+	if r.ExpirySec == 0 {
+		r.ExpirySec = 3600
+	}
+	if r.KeyID == "" {
+		r.KeyID = "endly"
+	}
+	if len(r.DefaultScopes) == 0 {
+		r.DefaultScopes = []string{"openid", "profile", "email"}
+	}
+	for _, user := range r.Users {
+		if user.Subject == "" {
+			user.Subject = user.Username
+		}
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *ListenRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	if len(r.Users) == 0 {
+		return errors.New("users were empty")
+	}
+	for i, user := range r.Users {
+		if user.Username == "" {
+			return fmt.Errorf("users[%d].Username was empty", i)
+		}
+	}
+	for i, client := range r.Clients {
+		if client.ClientID == "" {
+			return fmt.Errorf("clients[%d].ClientID was empty", i)
+		}
+	}
+	return nil
+}
+
+//ListenResponse represents OIDC identity provider listen response
+type ListenResponse struct {
+	Issuer                string
+	DiscoveryURL          string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	UserInfoEndpoint      string
+	JWKSURI               string
+}
+
+//TokensRequest represents recorded token requests query request
+type TokensRequest struct {
+	Port      int
+	ClientID  string      `description:"client id filter"`
+	GrantType string      `description:"grant type filter"`
+	Username  string      `description:"username filter"`
+	Reset     bool        `description:"if set, removes matched token requests"`
+	Expect    interface{} `description:"if specified, it validates matched token requests as actual"`
+}
+
+//Validate checks if request is valid
+func (r *TokensRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	return nil
+}
+
+//Matches returns true if token request matches request criteria
+func (r *TokensRequest) Matches(request *TokenRequest) bool {
+	if r.ClientID != "" && r.ClientID != request.ClientID {
+		return false
+	}
+	if r.GrantType != "" && r.GrantType != request.GrantType {
+		return false
+	}
+	if r.Username != "" && r.Username != request.Username {
+		return false
+	}
+	return true
+}
+
+//TokensResponse represents recorded token requests query response
+type TokensResponse struct {
+	Requests []*TokenRequest
+	Assert   *validator.AssertResponse
+}
+
+//TokenRequest represents recorded token endpoint request
+type TokenRequest struct {
+	GrantType   string
+	ClientID    string
+	Username    string `json:",omitempty"`
+	Scope       string `json:",omitempty"`
+	RedirectURI string `json:",omitempty"`
+	Form        map[string]string
+	Status      int
+	Error       string `json:",omitempty"`
+	Time        time.Time
+}
+
+//ShutdownRequest represents OIDC identity provider shutdown request
+type ShutdownRequest struct {
+	Port int
+}
+
+//ShutdownResponse represents OIDC identity provider shutdown response
+type ShutdownResponse struct{}
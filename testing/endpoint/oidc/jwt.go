@@ -0,0 +1,57 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/viant/endly/util/jwt"
+)
+
+const signingAlgorithm = "RS256"
+
+//Key represents JSON web key
+type Key = jwt.Key
+
+//KeySet represents JSON web key set
+type KeySet = jwt.KeySet
+
+//signer represents RS256 JWT signer
+type signer struct {
+	keyID string
+	key   *rsa.PrivateKey
+}
+
+//KeySet returns public key set
+func (s *signer) KeySet() *KeySet {
+	return jwt.NewRSAKeySet(s.keyID, signingAlgorithm, &s.key.PublicKey)
+}
+
+//Sign returns signed JWT for supplied claims
+func (s *signer) Sign(claims map[string]interface{}) (string, error) {
+	return jwt.Sign(signingAlgorithm, map[string]interface{}{"kid": s.keyID}, claims, s.key)
+}
+
+//Verify verifies token signature and expiry, it returns token claims
+func (s *signer) Verify(token string) (map[string]interface{}, error) {
+	return jwt.Verify(token, &s.key.PublicKey)
+}
+
+//newSigner creates a signer with supplied PEM encoded private key or a new generated key
+func newSigner(keyID string, privateKey []byte) (*signer, error) {
+	if len(privateKey) == 0 {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %v", err)
+		}
+		return &signer{keyID: keyID, key: key}, nil
+	}
+	parsed, err := jwt.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T, expected RSA", parsed)
+	}
+	return &signer{keyID: keyID, key: key}, nil
+}
@@ -0,0 +1,413 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	authorizePath = "/authorize"
+	tokenPath     = "/token"
+	userInfoPath  = "/userinfo"
+	jwksPath      = "/jwks"
+)
+
+var maskedFormFields = map[string]bool{"password": true, "client_secret": true, "code": true, "refresh_token": true}
+
+//grant represents authorized user and client
+type grant struct {
+	user        *User
+	clientID    string
+	scope       string
+	nonce       string
+	redirectURI string
+	authTime    time.Time
+}
+
+//Server represents OIDC identity provider HTTP server
+type Server struct {
+	http.Server
+	config        *ListenRequest
+	signer        *signer
+	mux           sync.Mutex
+	codes         map[string]*grant
+	refreshTokens map[string]*grant
+	requests      []*TokenRequest
+}
+
+//Requests returns recorded token requests matching filter
+func (s *Server) Requests(filter func(request *TokenRequest) bool, reset bool) []*TokenRequest {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var result = make([]*TokenRequest, 0)
+	var remaining = make([]*TokenRequest, 0)
+	for _, request := range s.requests {
+		if filter == nil || filter(request) {
+			result = append(result, request)
+			continue
+		}
+		remaining = append(remaining, request)
+	}
+	if reset {
+		s.requests = remaining
+	}
+	return result
+}
+
+func (s *Server) discovery(writer http.ResponseWriter, request *http.Request) {
+	issuer := s.config.Issuer
+	writeJSON(writer, http.StatusOK, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + authorizePath,
+		"token_endpoint":                        issuer + tokenPath,
+		"userinfo_endpoint":                     issuer + userInfoPath,
+		"jwks_uri":                              issuer + jwksPath,
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{signingAlgorithm},
+		"grant_types_supported":                 []string{"authorization_code", "password", "client_credentials", "refresh_token"},
+		"scopes_supported":                      s.config.DefaultScopes,
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+	})
+}
+
+func (s *Server) jwks(writer http.ResponseWriter, request *http.Request) {
+	writeJSON(writer, http.StatusOK, s.signer.KeySet())
+}
+
+//authorize authenticates user with username/login_hint (first user by default) and redirects with authorization code
+func (s *Server) authorize(writer http.ResponseWriter, request *http.Request) {
+	if err := request.ParseForm(); err != nil {
+		writeError(writer, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	form := request.Form
+	if responseType := form.Get("response_type"); responseType != "code" {
+		writeError(writer, http.StatusBadRequest, "unsupported_response_type", fmt.Sprintf("unsupported response_type: %v", responseType))
+		return
+	}
+	redirectURI := form.Get("redirect_uri")
+	if redirectURI == "" {
+		writeError(writer, http.StatusBadRequest, "invalid_request", "redirect_uri was empty")
+		return
+	}
+	clientID := form.Get("client_id")
+	if client, ok := s.client(clientID); !ok {
+		writeError(writer, http.StatusBadRequest, "unauthorized_client", fmt.Sprintf("unknown client: %v", clientID))
+		return
+	} else if client != nil && !client.AllowRedirect(redirectURI) {
+		writeError(writer, http.StatusBadRequest, "invalid_request", fmt.Sprintf("redirect_uri not allowed: %v", redirectURI))
+		return
+	}
+	username := form.Get("username")
+	if username == "" {
+		username = form.Get("login_hint")
+	}
+	user, err := s.authenticate(username, form.Get("password"), form.Get("password") != "")
+	if err != nil {
+		writeError(writer, http.StatusUnauthorized, "access_denied", err.Error())
+		return
+	}
+	code := randomToken()
+	s.mux.Lock()
+	s.codes[code] = &grant{
+		user:        user,
+		clientID:    clientID,
+		scope:       s.scope(form.Get("scope")),
+		nonce:       form.Get("nonce"),
+		redirectURI: redirectURI,
+		authTime:    time.Now(),
+	}
+	s.mux.Unlock()
+	query := url.Values{}
+	query.Set("code", code)
+	if state := form.Get("state"); state != "" {
+		query.Set("state", state)
+	}
+	separator := "?"
+	if strings.Contains(redirectURI, "?") {
+		separator = "&"
+	}
+	http.Redirect(writer, request, redirectURI+separator+query.Encode(), http.StatusFound)
+}
+
+//token handles authorization_code, password, client_credentials and refresh_token grants, each request is recorded
+func (s *Server) token(writer http.ResponseWriter, request *http.Request) {
+	record := &TokenRequest{Form: make(map[string]string), Time: time.Now()}
+	defer s.record(record)
+	if request.Method != http.MethodPost {
+		s.writeError(writer, record, http.StatusMethodNotAllowed, "invalid_request", "expected POST")
+		return
+	}
+	if err := request.ParseForm(); err != nil {
+		s.writeError(writer, record, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	for key := range request.PostForm {
+		record.Form[key] = request.PostForm.Get(key)
+		if maskedFormFields[key] {
+			record.Form[key] = "***"
+		}
+	}
+	form := request.PostForm
+	record.GrantType = form.Get("grant_type")
+	record.RedirectURI = form.Get("redirect_uri")
+	clientID, secret, hasBasic := request.BasicAuth()
+	if !hasBasic {
+		clientID, secret = form.Get("client_id"), form.Get("client_secret")
+	}
+	record.ClientID = clientID
+	if client, ok := s.client(clientID); !ok || (client != nil && client.Secret != "" && client.Secret != secret) {
+		s.writeError(writer, record, http.StatusUnauthorized, "invalid_client", fmt.Sprintf("invalid client credentials: %v", clientID))
+		return
+	}
+	var aGrant *grant
+	switch record.GrantType {
+	case "authorization_code":
+		s.mux.Lock()
+		aGrant = s.codes[form.Get("code")]
+		delete(s.codes, form.Get("code"))
+		s.mux.Unlock()
+		if aGrant == nil || aGrant.clientID != clientID {
+			s.writeError(writer, record, http.StatusBadRequest, "invalid_grant", "invalid authorization code")
+			return
+		}
+		if record.RedirectURI != "" && record.RedirectURI != aGrant.redirectURI {
+			s.writeError(writer, record, http.StatusBadRequest, "invalid_grant", "redirect_uri mismatch")
+			return
+		}
+	case "password":
+		user, err := s.authenticate(form.Get("username"), form.Get("password"), true)
+		if err != nil {
+			record.Username = form.Get("username")
+			s.writeError(writer, record, http.StatusBadRequest, "invalid_grant", err.Error())
+			return
+		}
+		aGrant = &grant{user: user, clientID: clientID, scope: s.scope(form.Get("scope")), authTime: time.Now()}
+	case "client_credentials":
+		aGrant = &grant{clientID: clientID, scope: form.Get("scope")}
+	case "refresh_token":
+		s.mux.Lock()
+		aGrant = s.refreshTokens[form.Get("refresh_token")]
+		delete(s.refreshTokens, form.Get("refresh_token"))
+		s.mux.Unlock()
+		if aGrant == nil || aGrant.clientID != clientID {
+			s.writeError(writer, record, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+			return
+		}
+	default:
+		s.writeError(writer, record, http.StatusBadRequest, "unsupported_grant_type", fmt.Sprintf("unsupported grant_type: %v", record.GrantType))
+		return
+	}
+	if aGrant.user != nil {
+		record.Username = aGrant.user.Username
+	}
+	record.Scope = aGrant.scope
+	response, err := s.issue(aGrant)
+	if err != nil {
+		s.writeError(writer, record, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	record.Status = http.StatusOK
+	writeJSON(writer, http.StatusOK, response)
+}
+
+//issue returns token response for supplied grant
+func (s *Server) issue(aGrant *grant) (map[string]interface{}, error) {
+	now := time.Now()
+	subject := aGrant.clientID
+	if aGrant.user != nil {
+		subject = aGrant.user.Subject
+	}
+	accessToken, err := s.signer.Sign(map[string]interface{}{
+		"iss":       s.config.Issuer,
+		"sub":       subject,
+		"aud":       aGrant.clientID,
+		"client_id": aGrant.clientID,
+		"scope":     aGrant.scope,
+		"iat":       now.Unix(),
+		"exp":       now.Add(time.Duration(s.config.ExpirySec) * time.Second).Unix(),
+		"jti":       randomToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var response = map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   s.config.ExpirySec,
+	}
+	if aGrant.scope != "" {
+		response["scope"] = aGrant.scope
+	}
+	if aGrant.user == nil {
+		return response, nil
+	}
+	refreshToken := randomToken()
+	s.mux.Lock()
+	s.refreshTokens[refreshToken] = aGrant
+	s.mux.Unlock()
+	response["refresh_token"] = refreshToken
+	if !hasScope(aGrant.scope, "openid") {
+		return response, nil
+	}
+	var claims = make(map[string]interface{})
+	for key, value := range aGrant.user.Claims {
+		claims[key] = value
+	}
+	claims["iss"] = s.config.Issuer
+	claims["sub"] = aGrant.user.Subject
+	claims["aud"] = aGrant.clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(time.Duration(s.config.ExpirySec) * time.Second).Unix()
+	claims["auth_time"] = aGrant.authTime.Unix()
+	if aGrant.nonce != "" {
+		claims["nonce"] = aGrant.nonce
+	}
+	if response["id_token"], err = s.signer.Sign(claims); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+//userInfo returns user claims for a valid bearer access token
+func (s *Server) userInfo(writer http.ResponseWriter, request *http.Request) {
+	authorization := request.Header.Get("Authorization")
+	if !strings.HasPrefix(strings.ToLower(authorization), "bearer ") {
+		writeError(writer, http.StatusUnauthorized, "invalid_token", "missing bearer token")
+		return
+	}
+	claims, err := s.signer.Verify(strings.TrimSpace(authorization[len("bearer "):]))
+	if err != nil {
+		writeError(writer, http.StatusUnauthorized, "invalid_token", err.Error())
+		return
+	}
+	subject, _ := claims["sub"].(string)
+	for _, user := range s.config.Users {
+		if user.Subject != subject {
+			continue
+		}
+		var info = make(map[string]interface{})
+		for key, value := range user.Claims {
+			info[key] = value
+		}
+		info["sub"] = user.Subject
+		writeJSON(writer, http.StatusOK, info)
+		return
+	}
+	writeError(writer, http.StatusUnauthorized, "invalid_token", fmt.Sprintf("unknown subject: %v", subject))
+}
+
+//client returns registered client, ok is true if client is registered or clients are not configured
+func (s *Server) client(clientID string) (*Client, bool) {
+	if len(s.config.Clients) == 0 {
+		return nil, true
+	}
+	for _, client := range s.config.Clients {
+		if client.ClientID == clientID {
+			return client, true
+		}
+	}
+	return nil, false
+}
+
+//authenticate returns user for supplied username (first user if empty), password is verified if required and user has one
+func (s *Server) authenticate(username, password string, checkPassword bool) (*User, error) {
+	if username == "" {
+		return s.config.Users[0], nil
+	}
+	for _, user := range s.config.Users {
+		if user.Username != username {
+			continue
+		}
+		if checkPassword && user.Password != "" && user.Password != password {
+			return nil, fmt.Errorf("invalid credentials: %v", username)
+		}
+		return user, nil
+	}
+	return nil, fmt.Errorf("unknown user: %v", username)
+}
+
+func (s *Server) scope(scope string) string {
+	if scope == "" {
+		return strings.Join(s.config.DefaultScopes, " ")
+	}
+	return scope
+}
+
+func (s *Server) record(request *TokenRequest) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+func (s *Server) writeError(writer http.ResponseWriter, record *TokenRequest, status int, code, description string) {
+	record.Status = status
+	record.Error = code + ": " + description
+	writeError(writer, status, code, description)
+}
+
+func hasScope(scope, candidate string) bool {
+	for _, item := range strings.Fields(scope) {
+		if item == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func randomToken() string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data)
+	return hex.EncodeToString(data)
+}
+
+func writeError(writer http.ResponseWriter, status int, code, description string) {
+	writeJSON(writer, status, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(value)
+}
+
+//StartServer starts OIDC identity provider server
+func StartServer(config *ListenRequest, privateKey []byte) (*Server, error) {
+	aSigner, err := newSigner(config.KeyID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	server := &Server{
+		config:        config,
+		signer:        aSigner,
+		codes:         make(map[string]*grant),
+		refreshTokens: make(map[string]*grant),
+		requests:      make([]*TokenRequest, 0),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, server.discovery)
+	mux.HandleFunc(jwksPath, server.jwks)
+	mux.HandleFunc(authorizePath, server.authorize)
+	mux.HandleFunc(tokenPath, server.token)
+	mux.HandleFunc(userInfoPath, server.userInfo)
+	server.Server = http.Server{Addr: fmt.Sprintf(":%v", config.Port), Handler: mux}
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start oidc server on port %v, %v", config.Port, err)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return server, nil
+}
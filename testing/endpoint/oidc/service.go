@@ -0,0 +1,175 @@
+package oidc
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/testing/validator"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+)
+
+const (
+	//ServiceID represents OIDC identity provider endpoint service id.
+	ServiceID = "oidc/endpoint"
+)
+
+//service represents OAuth2/OIDC identity provider endpoint service
+type service struct {
+	*endly.AbstractService
+	servers map[int]*Server
+}
+
+func (s *service) listen(context *endly.Context, request *ListenRequest) (*ListenResponse, error) {
+	var privateKey []byte
+	if request.KeyLocation != "" {
+		location := url.NewResource(context.Expand(request.KeyLocation)).ParsedURL.Path
+		var err error
+		if privateKey, err = ioutil.ReadFile(location); err != nil {
+			return nil, fmt.Errorf("failed to load private key: %v, %v", location, err)
+		}
+	}
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	if server, ok := s.servers[request.Port]; ok {
+		_ = server.Shutdown(context.Background())
+		delete(s.servers, request.Port)
+	}
+	server, err := StartServer(request, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	s.servers[request.Port] = server
+	return &ListenResponse{
+		Issuer:                request.Issuer,
+		DiscoveryURL:          request.Issuer + discoveryPath,
+		AuthorizationEndpoint: request.Issuer + authorizePath,
+		TokenEndpoint:         request.Issuer + tokenPath,
+		UserInfoEndpoint:      request.Issuer + userInfoPath,
+		JWKSURI:               request.Issuer + jwksPath,
+	}, nil
+}
+
+func (s *service) server(port int) (*Server, error) {
+	s.Mutex().RLock()
+	defer s.Mutex().RUnlock()
+	server, ok := s.servers[port]
+	if !ok {
+		return nil, fmt.Errorf("oidc endpoint at %v, not found", port)
+	}
+	return server, nil
+}
+
+func (s *service) tokens(context *endly.Context, request *TokensRequest) (*TokensResponse, error) {
+	server, err := s.server(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	var response = &TokensResponse{
+		Requests: server.Requests(request.Matches, request.Reset),
+	}
+	if request.Expect == nil {
+		return response, nil
+	}
+	var actual = make([]interface{}, 0)
+	if err := toolbox.DefaultConverter.AssignConverted(&actual, response.Requests); err != nil {
+		return nil, err
+	}
+	response.Assert, err = validator.Assert(context, request, request.Expect, actual, ServiceID, "assert token requests")
+	return response, err
+}
+
+func (s *service) shutdown(context *endly.Context, request *ShutdownRequest) (*ShutdownResponse, error) {
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	server, ok := s.servers[request.Port]
+	if !ok {
+		return nil, fmt.Errorf("oidc endpoint at %v, not found", request.Port)
+	}
+	delete(s.servers, request.Port)
+	return &ShutdownResponse{}, server.Shutdown(context.Background())
+}
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "listen",
+		RequestInfo: &endly.ActionInfo{
+			Description: "start OAuth2/OIDC identity provider endpoint",
+			Examples: []*endly.UseCase{
+				{
+					Description: "identity provider with test user",
+					Data: `{
+  "Port": 8971,
+  "Clients": [{"ClientID": "ui", "Secret": "secret", "RedirectURIs": ["http://127.0.0.1:8080/"]}],
+  "Users": [
+    {
+      "Username": "bob",
+      "Password": "pass",
+      "Claims": {"email": "bob@localhost", "name": "Bob", "groups": ["admin"]}
+    }
+  ]
+}`,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &ListenRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &ListenResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*ListenRequest); ok {
+				return s.listen(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	},
+		&endly.Route{
+			Action: "tokens",
+			RequestInfo: &endly.ActionInfo{
+				Description: "list recorded token requests, optionally validate them",
+			},
+			RequestProvider: func() interface{} {
+				return &TokensRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &TokensResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*TokensRequest); ok {
+					return s.tokens(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "shutdown",
+			RequestInfo: &endly.ActionInfo{
+				Description: "stop OAuth2/OIDC identity provider endpoint",
+			},
+			RequestProvider: func() interface{} {
+				return &ShutdownRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &ShutdownResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*ShutdownRequest); ok {
+					return s.shutdown(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		})
+}
+
+//New creates a new OAuth2/OIDC identity provider endpoint service
+func New() endly.Service {
+	var result = &service{
+		servers:         make(map[int]*Server),
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,175 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, response *http.Response) map[string]interface{} {
+	defer response.Body.Close()
+	var result = make(map[string]interface{})
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+	return result
+}
+
+func decodeClaims(t *testing.T, token string) map[string]interface{} {
+	segments := strings.Split(token, ".")
+	if !assert.EqualValues(t, 3, len(segments)) {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(segments[1])
+	assert.Nil(t, err)
+	var result = make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &result))
+	return result
+}
+
+func TestService_Run(t *testing.T) {
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	listenResponse := &ListenResponse{}
+	err := endly.Run(context, &ListenRequest{
+		Port:    8971,
+		Clients: []*Client{{ClientID: "ui", Secret: "secret", RedirectURIs: []string{"http://127.0.0.1:8080/"}}},
+		Users: []*User{
+			{Username: "bob", Password: "pass", Claims: map[string]interface{}{"email": "bob@localhost", "groups": []string{"admin"}}},
+			{Username: "alice", Subject: "u-2"},
+		},
+	}, listenResponse)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer endly.Run(context, &ShutdownRequest{Port: 8971}, nil)
+	assert.EqualValues(t, "http://localhost:8971", listenResponse.Issuer)
+
+	response, err := http.Get(listenResponse.DiscoveryURL)
+	if assert.Nil(t, err) {
+		discovery := decodeJSON(t, response)
+		assert.EqualValues(t, "http://localhost:8971/token", discovery["token_endpoint"])
+		assert.EqualValues(t, "http://localhost:8971/jwks", discovery["jwks_uri"])
+	}
+	response, err = http.Get(listenResponse.JWKSURI)
+	if assert.Nil(t, err) {
+		keySet := &KeySet{}
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(keySet))
+		if assert.EqualValues(t, 1, len(keySet.Keys)) {
+			assert.EqualValues(t, "endly", keySet.Keys[0].Kid)
+			assert.EqualValues(t, "AQAB", keySet.Keys[0].E)
+		}
+	}
+
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	authorizeURL := listenResponse.AuthorizationEndpoint + "?response_type=code&client_id=ui&scope=openid+email&state=xyz&nonce=n1&login_hint=bob&redirect_uri=" + url.QueryEscape("http://127.0.0.1:8080/callback")
+	response, err = client.Get(authorizeURL)
+	if !assert.Nil(t, err) || !assert.EqualValues(t, http.StatusFound, response.StatusCode) {
+		return
+	}
+	location, err := url.Parse(response.Header.Get("Location"))
+	assert.Nil(t, err)
+	assert.EqualValues(t, "/callback", location.Path)
+	assert.EqualValues(t, "xyz", location.Query().Get("state"))
+	code := location.Query().Get("code")
+
+	response, err = client.Get(strings.Replace(authorizeURL, "8080", "9090", 1))
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, http.StatusBadRequest, response.StatusCode, "redirect URI is not allowed")
+	}
+
+	request, _ := http.NewRequest("POST", listenResponse.TokenEndpoint, strings.NewReader(url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {"http://127.0.0.1:8080/callback"}}.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth("ui", "secret")
+	response, err = client.Do(request)
+	if !assert.Nil(t, err) || !assert.EqualValues(t, http.StatusOK, response.StatusCode) {
+		return
+	}
+	tokens := decodeJSON(t, response)
+	idClaims := decodeClaims(t, tokens["id_token"].(string))
+	assert.EqualValues(t, "bob", idClaims["sub"])
+	assert.EqualValues(t, "ui", idClaims["aud"])
+	assert.EqualValues(t, "n1", idClaims["nonce"])
+	assert.EqualValues(t, "bob@localhost", idClaims["email"])
+	assert.EqualValues(t, "http://localhost:8971", idClaims["iss"])
+
+	request, _ = http.NewRequest("GET", listenResponse.UserInfoEndpoint, nil)
+	request.Header.Set("Authorization", "Bearer "+tokens["access_token"].(string))
+	response, err = client.Do(request)
+	if assert.Nil(t, err) && assert.EqualValues(t, http.StatusOK, response.StatusCode) {
+		info := decodeJSON(t, response)
+		assert.EqualValues(t, "bob", info["sub"])
+		assert.EqualValues(t, []interface{}{"admin"}, info["groups"])
+	}
+	request, _ = http.NewRequest("GET", listenResponse.UserInfoEndpoint, nil)
+	request.Header.Set("Authorization", "Bearer "+tokens["access_token"].(string)+"x")
+	response, err = client.Do(request)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, http.StatusUnauthorized, response.StatusCode)
+	}
+
+	var useCases = []struct {
+		description string
+		form        url.Values
+		status      int
+	}{
+		{"code reuse", url.Values{"grant_type": {"authorization_code"}, "code": {code}, "client_id": {"ui"}, "client_secret": {"secret"}}, http.StatusBadRequest},
+		{"refresh", url.Values{"grant_type": {"refresh_token"}, "refresh_token": {tokens["refresh_token"].(string)}, "client_id": {"ui"}, "client_secret": {"secret"}}, http.StatusOK},
+		{"password", url.Values{"grant_type": {"password"}, "username": {"alice"}, "password": {"any"}, "client_id": {"ui"}, "client_secret": {"secret"}}, http.StatusOK},
+		{"invalid password", url.Values{"grant_type": {"password"}, "username": {"bob"}, "password": {"wrong"}, "client_id": {"ui"}, "client_secret": {"secret"}}, http.StatusBadRequest},
+		{"invalid client", url.Values{"grant_type": {"client_credentials"}, "client_id": {"ui"}, "client_secret": {"wrong"}}, http.StatusUnauthorized},
+		{"client credentials", url.Values{"grant_type": {"client_credentials"}, "scope": {"api"}, "client_id": {"ui"}, "client_secret": {"secret"}}, http.StatusOK},
+	}
+	for _, useCase := range useCases {
+		response, err = http.PostForm(listenResponse.TokenEndpoint, useCase.form)
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, useCase.status, response.StatusCode, useCase.description)
+			response.Body.Close()
+		}
+	}
+
+	tokensResponse := &TokensResponse{}
+	err = endly.Run(context, &TokensRequest{
+		Port:      8971,
+		GrantType: "password",
+		Expect: []interface{}{
+			map[string]interface{}{"Username": "alice", "Status": 200, "Form": map[string]interface{}{"password": "***"}},
+			map[string]interface{}{"Username": "bob", "Status": 400, "Error": "/invalid credentials/"},
+		},
+	}, tokensResponse)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 2, len(tokensResponse.Requests))
+		if assert.NotNil(t, tokensResponse.Assert) {
+			assert.EqualValues(t, 0, tokensResponse.Assert.FailedCount, tokensResponse.Assert.Report())
+		}
+	}
+	err = endly.Run(context, &TokensRequest{Port: 8971, Reset: true}, tokensResponse)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 7, len(tokensResponse.Requests))
+		assert.EqualValues(t, "authorization_code", tokensResponse.Requests[0].GrantType)
+		assert.EqualValues(t, "ui", tokensResponse.Requests[0].ClientID)
+		assert.EqualValues(t, "bob", tokensResponse.Requests[0].Username)
+	}
+	err = endly.Run(context, &TokensRequest{Port: 8971}, tokensResponse)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 0, len(tokensResponse.Requests))
+	}
+	err = endly.Run(context, &TokensRequest{Port: 8972}, tokensResponse)
+	assert.NotNil(t, err)
+}
+
+func TestListenRequest_Validate(t *testing.T) {
+	request := &ListenRequest{Port: 8971}
+	assert.Nil(t, request.Init())
+	assert.NotNil(t, request.Validate())
+	request.Users = []*User{{Username: "bob"}}
+	assert.Nil(t, request.Init())
+	assert.Nil(t, request.Validate())
+	assert.EqualValues(t, "bob", request.Users[0].Subject)
+	assert.EqualValues(t, 3600, request.ExpirySec)
+}
@@ -0,0 +1,31 @@
+package oidc
+
+import "strings"
+
+//User represents identity provider test user
+type User struct {
+	Username string
+	Password string                 `description:"if empty any password is accepted"`
+	Subject  string                 `description:"sub claim, default: username"`
+	Claims   map[string]interface{} `description:"additional ID token and userinfo claims, i.e. email, name, groups"`
+}
+
+//Client represents registered OAuth2 client
+type Client struct {
+	ClientID     string
+	Secret       string   `description:"if empty client authentication is not required"`
+	RedirectURIs []string `description:"allowed redirect URI prefixes, if empty any redirect URI is accepted"`
+}
+
+//AllowRedirect returns true if redirect URI is allowed
+func (c *Client) AllowRedirect(redirectURI string) bool {
+	if len(c.RedirectURIs) == 0 {
+		return true
+	}
+	for _, candidate := range c.RedirectURIs {
+		if strings.HasPrefix(redirectURI, candidate) {
+			return true
+		}
+	}
+	return false
+}
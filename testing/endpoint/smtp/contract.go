@@ -3,8 +3,12 @@ package smtp
 import (
 	"fmt"
 	"github.com/viant/assertly"
+	"github.com/viant/endly/testing/validator"
+	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/secret"
 	"os"
+	"strings"
+	"time"
 )
 
 //ListenRequest represents a new listen request
@@ -89,3 +93,82 @@ type AssertResponse struct {
 func (r *AssertResponse) Assertion() []*assertly.Validation {
 	return r.Validations
 }
+
+//MessagesRequest represents received messages query request
+type MessagesRequest struct {
+	User    string      `description:"SMTP login user"`
+	From    string      `description:"sender address fragment"`
+	To      string      `description:"recipient address fragment"`
+	Subject string      `description:"subject fragment"`
+	Since   string      `description:"received since time expression i.e. 5min ago or RFC3339 time"`
+	Until   string      `description:"received until time expression i.e. now or RFC3339 time"`
+	Limit   int         `description:"max number of most recent messages"`
+	Expect  interface{} `description:"if specified, it validates matched messages as actual"`
+	since   *time.Time
+	until   *time.Time
+}
+
+//Init initializes request
+func (r *MessagesRequest) Init() (err error) {
+	if r.Since != "" {
+		if r.since, err = asTime(r.Since); err != nil {
+			return fmt.Errorf("invalid since: %v, %v", r.Since, err)
+		}
+	}
+	if r.Until != "" {
+		if r.until, err = asTime(r.Until); err != nil {
+			return fmt.Errorf("invalid until: %v, %v", r.Until, err)
+		}
+	}
+	return nil
+}
+
+//Matches returns true if message matches request criteria
+func (r *MessagesRequest) Matches(message *Message) bool {
+	if r.User != "" && r.User != message.User {
+		return false
+	}
+	if r.From != "" && !containsFold(message.From, r.From) {
+		return false
+	}
+	if r.To != "" {
+		matched := false
+		for _, recipient := range message.To {
+			if containsFold(recipient, r.To) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if r.Subject != "" && !containsFold(message.Subject, r.Subject) {
+		return false
+	}
+	if r.since != nil && message.Received.Before(*r.since) {
+		return false
+	}
+	if r.until != nil && message.Received.After(*r.until) {
+		return false
+	}
+	return true
+}
+
+//MessagesResponse represents received messages query response
+type MessagesResponse struct {
+	Messages []*Message
+	Assert   *validator.AssertResponse
+}
+
+func containsFold(text, fragment string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(fragment))
+}
+
+//asTime converts time expression (i.e. 5min ago, now) or RFC3339 formatted time to time
+func asTime(value string) (*time.Time, error) {
+	if result, err := toolbox.TimeAt(value); err == nil {
+		return result, nil
+	}
+	return toolbox.ToTime(value, time.RFC3339)
+}
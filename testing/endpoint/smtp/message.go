@@ -3,20 +3,79 @@ package smtp
 import (
 	"io"
 	"io/ioutil"
+	"net/mail"
+	"net/textproto"
 	"strings"
+	"time"
 )
 
 //Message represent an email
 type Message struct {
-	From    string
-	To      []string
-	Subject string
-	Header  map[string]string
-	Raw     string
-	Body    string
+	User        string `json:",omitempty"`
+	From        string
+	To          []string
+	Subject     string
+	Header      map[string]string
+	Raw         string
+	Body        string
+	Text        string        `json:",omitempty"`
+	HTML        string        `json:",omitempty"`
+	Parts       []*Part       `json:",omitempty"`
+	Attachments []*Attachment `json:",omitempty"`
+	Received    time.Time
 }
 
+//Decode decodes message headers, body and MIME parts
 func (m *Message) Decode() {
+	parsed, err := mail.ReadMessage(strings.NewReader(m.Raw))
+	if err != nil {
+		m.decodeRaw()
+		return
+	}
+	names := headerNames(m.Raw)
+	for key := range parsed.Header {
+		name, ok := names[key]
+		if !ok {
+			name = key
+		}
+		m.Header[name] = decodeHeader(parsed.Header.Get(key))
+	}
+	m.Subject = decodeHeader(parsed.Header.Get("Subject"))
+	body, err := ioutil.ReadAll(parsed.Body)
+	if err != nil {
+		m.decodeRaw()
+		return
+	}
+	m.Body = string(body)
+	m.decodeParts(parsed.Header.Get("Content-Type"), parsed.Header.Get("Content-Transfer-Encoding"), body)
+}
+
+//headerNames returns original header names keyed by canonical name, so that header keys keep raw message casing
+func headerNames(raw string) map[string]string {
+	var result = make(map[string]string)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			break
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		index := strings.Index(line, ":")
+		if index == -1 {
+			continue
+		}
+		name := strings.TrimSpace(line[:index])
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		if _, ok := result[canonical]; !ok {
+			result[canonical] = name
+		}
+	}
+	return result
+}
+
+//decodeRaw decodes headers and body line by line, it is used when message is not RFC 5322 compliant
+func (m *Message) decodeRaw() {
 	lines := strings.Split(m.Raw, "\n")
 	for i, line := range lines {
 		pair := strings.SplitN(line, ":", 2)
@@ -33,6 +92,42 @@ func (m *Message) Decode() {
 	}
 }
 
+//decodeParts decodes MIME parts, text and HTML content and attachments
+func (m *Message) decodeParts(contentType, encoding string, body []byte) {
+	m.Parts = make([]*Part, 0)
+	m.Attachments = make([]*Attachment, 0)
+	for _, part := range parseParts(contentType, encoding, "", "", "", body, 0) {
+		m.Parts = append(m.Parts, part)
+		if attachment := part.Attachment(); attachment != nil {
+			m.Attachments = append(m.Attachments, attachment)
+			continue
+		}
+		switch part.MediaType {
+		case "text/plain":
+			if m.Text == "" {
+				m.Text = part.Content
+			}
+		case "text/html":
+			if m.HTML == "" {
+				m.HTML = part.Content
+			}
+		}
+	}
+}
+
+//HeaderValue returns case insensitive header value
+func (m *Message) HeaderValue(name string) string {
+	if value, ok := m.Header[name]; ok {
+		return value
+	}
+	for key, value := range m.Header {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
 func NewMessage(from string, to []string, reader io.Reader) (*Message, error) {
 	result := &Message{
 		From:   from,
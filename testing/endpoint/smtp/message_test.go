@@ -0,0 +1,132 @@
+package smtp
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessage_Decode_HeaderCasing(t *testing.T) {
+	raw := "From: alice@localhost\r\nMessage-ID: <1@localhost>\r\nX-MAILER: cron\r\nx-trace-id: abc\r\n  def\r\nSubject: Report\r\n\r\nbody\r\n"
+	message, err := NewMessage("alice@localhost", []string{"bob@localhost"}, strings.NewReader(raw))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "Report", message.Subject)
+	assert.EqualValues(t, "<1@localhost>", message.Header["Message-ID"], "original key casing is kept")
+	assert.EqualValues(t, "cron", message.Header["X-MAILER"])
+	assert.EqualValues(t, "abc def", message.Header["x-trace-id"])
+	assert.EqualValues(t, "cron", message.HeaderValue("X-Mailer"))
+	_, canonical := message.Header["Message-Id"]
+	assert.False(t, canonical)
+}
+
+func TestNewMessage(t *testing.T) {
+	reader, err := os.Open(path.Join(toolbox.CallerDirectory(3), "test", "invoice.eml"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer reader.Close()
+	message, err := NewMessage("billing@example.com", []string{"bob@localhost"}, reader)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "Invoice 1001 – ACME", message.Subject)
+	assert.EqualValues(t, "billing", message.Header["X-Mailer"])
+	assert.EqualValues(t, "billing", message.HeaderValue("x-mailer"))
+	assert.EqualValues(t, "Total due: 42 USD\nThank you\r\n", message.Text)
+	assert.EqualValues(t, "<p>Total due: <b>42 USD</b></p>", message.HTML)
+	if assert.EqualValues(t, 3, len(message.Parts)) {
+		assert.EqualValues(t, "1.1", message.Parts[0].Path)
+		assert.EqualValues(t, "UTF-8", message.Parts[0].Charset)
+		assert.EqualValues(t, "2", message.Parts[2].Path)
+	}
+	if assert.EqualValues(t, 1, len(message.Attachments)) {
+		attachment := message.Attachments[0]
+		assert.EqualValues(t, "invoice.pdf", attachment.Filename)
+		assert.EqualValues(t, "application/pdf", attachment.ContentType)
+		assert.EqualValues(t, "attachment", attachment.Disposition)
+		assert.EqualValues(t, 23, attachment.Size)
+		assert.EqualValues(t, "f67c7b8efe260f723c3b64cbc0bd39a71bef4d64f2740b0a7e0766800d41e50b", attachment.SHA256)
+		assert.EqualValues(t, "", attachment.Content)
+	}
+
+	plain, err := NewMessage("tester@localhost", []string{"bob@localhost"}, strings.NewReader("Subject: test subject\r\n\r\nthis is test body"))
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, "test subject", plain.Subject)
+		assert.EqualValues(t, "this is test body", plain.Body)
+		assert.EqualValues(t, "this is test body", plain.Text)
+		assert.EqualValues(t, 0, len(plain.Attachments))
+	}
+}
+
+func TestService_Messages(t *testing.T) {
+	srv := New().(*service)
+	content, err := ioutil.ReadFile(path.Join(toolbox.CallerDirectory(3), "test", "invoice.eml"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	invoice, _ := NewMessage("billing@example.com", []string{"bob@localhost"}, strings.NewReader(string(content)))
+	reset, _ := NewMessage("auth@example.com", []string{"alice@localhost"}, strings.NewReader("Subject: Reset your password\r\n\r\nhttps://example.com/reset?token=abc"))
+	old, _ := NewMessage("billing@example.com", []string{"bob@localhost"}, strings.NewReader("Subject: Invoice 999\r\n\r\nold"))
+	old.Received = time.Now().Add(-time.Hour)
+	srv.messages.Push("bob", old)
+	srv.messages.Push("bob", invoice)
+	srv.messages.Push("alice", reset)
+	assert.NotNil(t, srv.messages.Shift("bob"), "shifted messages should still be listed")
+
+	context := endly.New().NewContext(nil)
+	var useCases = []struct {
+		description string
+		request     *MessagesRequest
+		expect      []string
+		failed      int
+	}{
+		{description: "all", request: &MessagesRequest{}, expect: []string{"Invoice 999", "Invoice 1001 – ACME", "Reset your password"}},
+		{description: "recipient and subject", request: &MessagesRequest{To: "BOB@", Subject: "invoice"}, expect: []string{"Invoice 999", "Invoice 1001 – ACME"}},
+		{description: "since", request: &MessagesRequest{To: "bob@", Since: "5min ago"}, expect: []string{"Invoice 1001 – ACME"}},
+		{description: "user and limit", request: &MessagesRequest{User: "bob", Limit: 1}, expect: []string{"Invoice 1001 – ACME"}},
+		{
+			description: "attachment assertion",
+			request: &MessagesRequest{Subject: "1001", Expect: []interface{}{
+				map[string]interface{}{
+					"Header":      map[string]interface{}{"X-Mailer": "billing"},
+					"HTML":        "/42 USD/",
+					"Attachments": []interface{}{map[string]interface{}{"Filename": "invoice.pdf", "Size": 23, "SHA256": "f67c7b8efe260f723c3b64cbc0bd39a71bef4d64f2740b0a7e0766800d41e50b"}},
+				},
+			}},
+			expect: []string{"Invoice 1001 – ACME"},
+		},
+		{
+			description: "failed attachment assertion",
+			request: &MessagesRequest{Subject: "reset", Expect: []interface{}{
+				map[string]interface{}{"Text": "/token=/", "Attachments": "@notEmpty@"},
+			}},
+			expect: []string{"Reset your password"},
+			failed: 1,
+		},
+	}
+	for _, useCase := range useCases {
+		serviceResponse := srv.Run(context, useCase.request)
+		if !assert.EqualValues(t, "", serviceResponse.Error, useCase.description) {
+			continue
+		}
+		response := serviceResponse.Response.(*MessagesResponse)
+		var subjects = make([]string, 0)
+		for _, message := range response.Messages {
+			subjects = append(subjects, message.Subject)
+		}
+		assert.EqualValues(t, useCase.expect, subjects, useCase.description)
+		if useCase.request.Expect != nil && assert.NotNil(t, response.Assert, useCase.description) {
+			assert.EqualValues(t, useCase.failed, response.Assert.FailedCount, useCase.description+" "+response.Assert.Report())
+		}
+	}
+	serviceResponse := srv.Run(context, &MessagesRequest{Since: "abc"})
+	assert.True(t, serviceResponse.Error != "")
+}
@@ -7,14 +7,16 @@ import (
 	"github.com/viant/endly/workflow"
 	"github.com/viant/toolbox"
 	"sync"
+	"time"
 )
 
 //Messages represents a FIFO message collection grouped  by user
 type Messages struct {
 	*sync.Mutex
-	byUser  map[string][]*Message
-	debug   bool
-	context *endly.Context
+	byUser   map[string][]*Message
+	received []*Message
+	debug    bool
+	context  *endly.Context
 }
 
 //Push appends a message by user
@@ -28,6 +30,11 @@ func (m *Messages) Push(username string, message *Message) {
 			Message: fmt.Sprintf("push [%v] <- %v", username, info),
 		}, nil)
 	}
+	message.User = username
+	if message.Received.IsZero() {
+		message.Received = time.Now()
+	}
+	m.received = append(m.received, message)
 	_, ok := m.byUser[username]
 	if !ok {
 		m.byUser[username] = make([]*Message, 0)
@@ -58,10 +65,24 @@ func (m *Messages) Shift(username string) *Message {
 	return message
 }
 
+//List returns all received messages matching filter, shifted messages are included
+func (m *Messages) List(filter func(message *Message) bool) []*Message {
+	m.Lock()
+	defer m.Unlock()
+	var result = make([]*Message, 0)
+	for _, message := range m.received {
+		if filter == nil || filter(message) {
+			result = append(result, message)
+		}
+	}
+	return result
+}
+
 //NewMessages returns a new FIFO message collection by user
 func NewMessages() *Messages {
 	return &Messages{
-		byUser: make(map[string][]*Message),
-		Mutex:  &sync.Mutex{},
+		byUser:   make(map[string][]*Message),
+		received: make([]*Message, 0),
+		Mutex:    &sync.Mutex{},
 	}
 }
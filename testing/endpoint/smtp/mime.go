@@ -0,0 +1,161 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"strconv"
+	"strings"
+)
+
+const maxPartDepth = 10
+
+//Part represents decoded MIME part
+type Part struct {
+	Path        string `description:"part index path, i.e. 1.2"`
+	ContentType string
+	MediaType   string
+	Charset     string `json:",omitempty"`
+	Disposition string `json:",omitempty"`
+	Filename    string `json:",omitempty"`
+	ContentID   string `json:",omitempty"`
+	Size        int
+	SHA256      string
+	MD5         string
+	Content     string `json:",omitempty" description:"decoded text content, binary content is omitted"`
+}
+
+//IsAttachment returns true if part is an attachment or inline file
+func (p *Part) IsAttachment() bool {
+	return p.Disposition == "attachment" || p.Filename != ""
+}
+
+//Attachment returns part attachment or nil
+func (p *Part) Attachment() *Attachment {
+	if !p.IsAttachment() {
+		return nil
+	}
+	return &Attachment{
+		Filename:    p.Filename,
+		ContentType: p.MediaType,
+		Disposition: p.Disposition,
+		ContentID:   p.ContentID,
+		Size:        p.Size,
+		SHA256:      p.SHA256,
+		MD5:         p.MD5,
+		Content:     p.Content,
+	}
+}
+
+//Attachment represents mail attachment
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Disposition string `json:",omitempty"`
+	ContentID   string `json:",omitempty"`
+	Size        int
+	SHA256      string
+	MD5         string
+	Content     string `json:",omitempty" description:"decoded text content, binary content is omitted"`
+}
+
+var wordDecoder = &mime.WordDecoder{}
+
+//decodeHeader decodes RFC 2047 encoded words
+func decodeHeader(value string) string {
+	if decoded, err := wordDecoder.DecodeHeader(value); err == nil {
+		return decoded
+	}
+	return value
+}
+
+//decodeTransfer decodes base64 or quoted-printable content
+func decodeTransfer(encoding string, data []byte) []byte {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		compact := strings.Join(strings.Fields(string(data)), "")
+		if decoded, err := base64.StdEncoding.DecodeString(compact); err == nil {
+			return decoded
+		}
+	case "quoted-printable":
+		if decoded, err := ioutil.ReadAll(quotedprintable.NewReader(bytes.NewReader(data))); err == nil {
+			return decoded
+		}
+	}
+	return data
+}
+
+//isTextMedia returns true if media type content can be represented as text
+func isTextMedia(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || mediaType == "application/xml"
+}
+
+func newPart(path, contentType, encoding, disposition, contentID string, data []byte) *Part {
+	result := &Part{
+		Path:        path,
+		ContentType: contentType,
+		ContentID:   strings.Trim(contentID, "<>"),
+	}
+	var params map[string]string
+	var err error
+	if result.MediaType, params, err = mime.ParseMediaType(contentType); err != nil || contentType == "" {
+		result.MediaType = "text/plain"
+	}
+	result.Charset = params["charset"]
+	if disposition != "" {
+		var dispositionParams map[string]string
+		if result.Disposition, dispositionParams, err = mime.ParseMediaType(disposition); err == nil {
+			result.Filename = decodeHeader(dispositionParams["filename"])
+		}
+	}
+	if result.Filename == "" && params["name"] != "" {
+		result.Filename = decodeHeader(params["name"])
+	}
+	data = decodeTransfer(encoding, data)
+	result.Size = len(data)
+	sha := sha256.Sum256(data)
+	result.SHA256 = hex.EncodeToString(sha[:])
+	md5Sum := md5.Sum(data)
+	result.MD5 = hex.EncodeToString(md5Sum[:])
+	if isTextMedia(result.MediaType) {
+		result.Content = string(data)
+	}
+	return result
+}
+
+//parseParts returns leaf MIME parts, multipart bodies are parsed recursively
+func parseParts(contentType, encoding, disposition, contentID, path string, body []byte, depth int) []*Part {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" || depth > maxPartDepth {
+		if path == "" {
+			path = "1"
+		}
+		return []*Part{newPart(path, contentType, encoding, disposition, contentID, body)}
+	}
+	var result = make([]*Part, 0)
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for i := 1; ; i++ {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		//multipart reader decodes quoted-printable content and removes its transfer encoding header
+		data, err := ioutil.ReadAll(part)
+		if err != nil {
+			break
+		}
+		partPath := strconv.Itoa(i)
+		if path != "" {
+			partPath = path + "." + partPath
+		}
+		result = append(result, parseParts(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"),
+			part.Header.Get("Content-Disposition"), part.Header.Get("Content-Id"), partPath, data, depth+1)...)
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/viant/endly"
 	"github.com/viant/endly/model/criteria"
 	"github.com/viant/endly/testing/validator"
+	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
 	"github.com/viant/toolbox/url"
 	"log"
@@ -108,6 +109,25 @@ func (s *service) assert(context *endly.Context, request *AssertRequest) (*Asser
 	return response, nil
 }
 
+func (s *service) listMessages(context *endly.Context, request *MessagesRequest) (*MessagesResponse, error) {
+	var response = &MessagesResponse{
+		Messages: s.messages.List(request.Matches),
+	}
+	if request.Limit > 0 && len(response.Messages) > request.Limit {
+		response.Messages = response.Messages[len(response.Messages)-request.Limit:]
+	}
+	if request.Expect == nil {
+		return response, nil
+	}
+	var actual = make([]interface{}, 0)
+	if err := toolbox.DefaultConverter.AssignConverted(&actual, response.Messages); err != nil {
+		return nil, err
+	}
+	var err error
+	response.Assert, err = validator.Assert(context, request, request.Expect, actual, "smtp/endpoint", "assert received messages")
+	return response, err
+}
+
 func (s *service) registerRoutes() {
 	//listen action route
 	s.Register(&endly.Route{
@@ -128,6 +148,41 @@ func (s *service) registerRoutes() {
 			return nil, fmt.Errorf("unsupported request type: %T", request)
 		},
 	})
+	//messages action route
+	s.Register(&endly.Route{
+		Action: "messages",
+		RequestInfo: &endly.ActionInfo{
+			Description: "list received messages with parsed MIME parts and attachments",
+			Examples: []*endly.UseCase{
+				{
+					Description: "invoice attachment",
+					Data: `{
+  "To": "bob@localhost",
+  "Subject": "invoice",
+  "Since": "5min ago",
+  "Expect": [
+    {
+      "Header": {"X-Mailer": "billing"},
+      "Attachments": [{"Filename": "invoice.pdf", "ContentType": "application/pdf", "SHA256": "/[a-f0-9]{64}/"}]
+    }
+  ]
+}`,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &MessagesRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &MessagesResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*MessagesRequest); ok {
+				return s.listMessages(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
 	//assert action route
 	s.Register(&endly.Route{
 		Action: "assert",
@@ -0,0 +1,120 @@
+package generate
+
+import (
+	"github.com/pkg/errors"
+	"github.com/viant/dsc"
+	edsunit "github.com/viant/endly/testing/dsunit"
+	"github.com/viant/toolbox"
+	"strings"
+)
+
+//constraints represents table foreign keys and unique columns discovered from a live schema
+type constraints struct {
+	foreignKeys map[string]string //column -> referenced table.column
+	unique      map[string]bool   //columns with a single column unique index or constraint
+}
+
+//constraintQuery represents driver specific unique constraint discovery SQL, table (and datastore for mysql) are passed as parameters
+type constraintQuery struct {
+	uniqueIndexes   string //returns name, col
+	uniqueIndexInfo string //sqlite only: returns index columns for index name
+	withDatastore   bool
+}
+
+var constraintQueries = map[string]*constraintQuery{
+	"sqlite3": {
+		uniqueIndexes:   `SELECT name FROM pragma_index_list(?) WHERE "unique" = 1 AND origin <> 'pk'`,
+		uniqueIndexInfo: `SELECT name AS col FROM pragma_index_info(?)`,
+	},
+	"mysql": {
+		uniqueIndexes: `SELECT index_name AS name, column_name AS col
+FROM information_schema.statistics
+WHERE table_schema = ? AND table_name = ? AND non_unique = 0 AND index_name <> 'PRIMARY'`,
+		withDatastore: true,
+	},
+	"postgres": {
+		uniqueIndexes: `SELECT tc.constraint_name AS name, kcu.column_name AS col
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+WHERE tc.constraint_type = 'UNIQUE' AND tc.table_schema = current_schema() AND tc.table_name = $1`,
+	},
+}
+
+//readConstraints reads table foreign keys and single column unique indexes, unsupported drivers return no constraints
+func readConstraints(manager dsc.Manager, datastore, table string) (*constraints, error) {
+	foreignKeys, err := edsunit.ForeignKeys(manager, datastore, table)
+	if err != nil {
+		return nil, err
+	}
+	result := &constraints{foreignKeys: foreignKeys, unique: make(map[string]bool)}
+	query, ok := constraintQueries[manager.Config().DriverName]
+	if !ok {
+		return result, nil
+	}
+	var parameters = []interface{}{table}
+	if query.withDatastore {
+		parameters = []interface{}{datastore, table}
+	}
+	indexes, err := readRecords(manager, query.uniqueIndexes, parameters)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %v unique indexes", table)
+	}
+	var indexColumns = make(map[string][]string)
+	var names = make([]string, 0)
+	for _, record := range indexes {
+		name := record["name"]
+		if _, has := indexColumns[name]; !has {
+			names = append(names, name)
+		}
+		if query.uniqueIndexInfo != "" {
+			columns, err := readRecords(manager, query.uniqueIndexInfo, []interface{}{name})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %v index %v", table, name)
+			}
+			for _, column := range columns {
+				indexColumns[name] = append(indexColumns[name], column["col"])
+			}
+			continue
+		}
+		indexColumns[name] = append(indexColumns[name], record["col"])
+	}
+	for _, name := range names {
+		//composite unique indexes are not enforced by column level unique rule
+		if columns := indexColumns[name]; len(columns) == 1 {
+			result.unique[strings.ToLower(columns[0])] = true
+		}
+	}
+	return result, nil
+}
+
+//readRecords reads records with lower case keys and text values
+func readRecords(manager dsc.Manager, SQL string, parameters []interface{}) ([]map[string]string, error) {
+	var records = make([]map[string]interface{}, 0)
+	if err := manager.ReadAll(&records, SQL, parameters, nil); err != nil {
+		return nil, err
+	}
+	var result = make([]map[string]string, 0, len(records))
+	for _, record := range records {
+		var normalized = make(map[string]string)
+		for key, value := range record {
+			if value != nil {
+				normalized[strings.ToLower(key)] = toolbox.AsString(value)
+			}
+		}
+		result = append(result, normalized)
+	}
+	return result, nil
+}
+
+//apply sets discovered foreign key and unique rules on table columns
+func (c *constraints) apply(table *Table) {
+	for _, column := range table.Columns {
+		name := strings.ToLower(column.Name)
+		if foreignKey, ok := c.foreignKeys[name]; ok {
+			column.ForeignKey = foreignKey
+		}
+		if c.unique[name] {
+			column.Unique = true
+		}
+	}
+}
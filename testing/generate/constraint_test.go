@@ -0,0 +1,104 @@
+package generate
+
+import (
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/dsc"
+	"github.com/viant/dsunit"
+	"github.com/viant/endly"
+	edsunit "github.com/viant/endly/testing/dsunit"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+const constraintSchema = `
+CREATE TABLE orders (
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users(id),
+	code VARCHAR(32) NOT NULL,
+	line INTEGER NOT NULL,
+	batch INTEGER NOT NULL,
+	UNIQUE (code)
+);
+CREATE UNIQUE INDEX orders_line_batch ON orders(line, batch);
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY,
+	email VARCHAR(64) NOT NULL UNIQUE,
+	name VARCHAR(64)
+);
+`
+
+func TestService_GenerateWithConstraints(t *testing.T) {
+	directory, err := ioutil.TempDir("", "generate")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	dbFile := path.Join(directory, "db.sqlite")
+	db, err := sql.Open("sqlite3", dbFile)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec(constraintSchema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	config, err := dsc.NewConfigWithParameters("sqlite3", "[url]", "", map[string]interface{}{"url": dbFile})
+	if !assert.Nil(t, err) {
+		return
+	}
+	err = endly.Run(context, (*edsunit.RegisterRequest)(dsunit.NewRegisterRequest("db", config)), &edsunit.RegisterResponse{})
+	if !assert.Nil(t, err) {
+		return
+	}
+	manager, err := edsunit.GetManager(context, "db")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	{ //foreign keys and single column unique indexes are discovered
+		tables, err := (&service{}).readSchema(manager, []*Table{{Table: "orders"}, {Table: "users"}})
+		if !assert.Nil(t, err) {
+			return
+		}
+		orders := lookupTable(tables, "orders")
+		assert.Equal(t, "users.id", lookupColumn(orders.Columns, "user_id").ForeignKey)
+		assert.True(t, lookupColumn(orders.Columns, "code").Unique)
+		assert.False(t, lookupColumn(orders.Columns, "line").Unique)
+		assert.True(t, lookupColumn(orders.Columns, "id").PrimaryKey)
+		users := lookupTable(tables, "users")
+		assert.True(t, lookupColumn(users.Columns, "email").Unique)
+		assert.False(t, lookupColumn(users.Columns, "name").Unique)
+		assert.Equal(t, []string{"users", "orders"}, []string{sortTables(tables)[0].Table, sortTables(tables)[1].Table})
+	}
+
+	{ //generated data keeps referential integrity
+		response := &Response{}
+		err := endly.Run(context, &Request{
+			Datastore: "db",
+			Seed:      7,
+			Rows:      20,
+			Tables: []*Table{
+				{Table: "orders", Columns: []*Column{{Name: "code", Provider: "word"}, {Name: "line", Values: []interface{}{1, 2, 3, 4, 5}}, {Name: "batch", Distribution: "sequence"}}},
+				{Table: "users", Rows: 5},
+			},
+			Insert: true,
+		}, response)
+		if !assert.Nil(t, err) {
+			return
+		}
+		var orphans, orders, codes int
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*), COUNT(DISTINCT code) FROM orders").Scan(&orders, &codes))
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM orders o LEFT JOIN users u ON u.id = o.user_id WHERE u.id IS NULL").Scan(&orphans))
+		assert.Equal(t, 20, orders)
+		assert.Equal(t, 20, codes)
+		assert.Equal(t, 0, orphans)
+	}
+}
@@ -0,0 +1,182 @@
+package generate
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/viant/toolbox/url"
+	"strings"
+)
+
+const (
+	//FormatJSON represents JSON dataset format
+	FormatJSON = "json"
+	//FormatCSV represents CSV dataset format
+	FormatCSV = "csv"
+)
+
+//Request represents a synthetic data generation request
+type Request struct {
+	Datastore string        `description:"registered datastore name, used to read table schema and/or insert generated data"`
+	Schema    *url.Resource `description:"JSON/YAML schema location with []*Table, columns are merged with datastore schema by name"`
+	Tables    []*Table      `description:"tables to generate, if datastore is used columns are optional overrides"`
+	Rows      int           `description:"default number of rows per table"`
+	Seed      int64         `description:"random generator seed, the same seed produces the same data, default current time"`
+	Dest      *url.Resource `description:"optional dsunit-ready datasets folder"`
+	Format    string        `description:"dest dataset format: json or csv, default json"`
+	Prefix    string        `description:"dataset file prefix"`
+	Postfix   string        `description:"dataset file postfix"`
+	Insert    bool          `description:"flag to insert generated data directly into datastore with dsunit:prepare"`
+}
+
+//Table represents a generated table
+type Table struct {
+	Table   string    `required:"true"`
+	Rows    int       `description:"number of rows, default request rows"`
+	Columns []*Column `description:"table columns"`
+}
+
+//Column represents generated column rules
+type Column struct {
+	Name         string        `required:"true"`
+	Type         string        `description:"int, float, string, bool, date, time, uuid or database type name"`
+	Length       int           `description:"max string length"`
+	Nullable     bool          `description:"flag to allow null values"`
+	NullRatio    float64       `description:"null values ratio (0..1) for nullable column"`
+	Unique       bool          `description:"flag to generate unique values"`
+	PrimaryKey   bool          `description:"primary key flag, primary key is unique and not null"`
+	ForeignKey   string        `description:"referenced table.column, values are picked from referenced table"`
+	Provider     string        `description:"faker style provider: firstName, lastName, name, email, username, phone, company, street, city, country, countryCode, zip, word, sentence, uuid, ipv4, url, domain, color"`
+	Distribution string        `description:"uniform (default), normal, sequence"`
+	Min          interface{}   `description:"min value or time"`
+	Max          interface{}   `description:"max value or time"`
+	Mean         float64       `description:"normal distribution mean"`
+	StdDev       float64       `description:"normal distribution standard deviation"`
+	Values       []interface{} `description:"values to choose from"`
+	Weights      []float64     `description:"values weights"`
+	Value        interface{}   `description:"constant value"`
+	Format       string        `description:"time/date layout, default 2006-01-02 15:04:05 for time and 2006-01-02 for date"`
+}
+
+//Response represents a generation response
+type Response struct {
+	Seed     int64
+	Tables   []*TableInfo
+	Data     map[string][]map[string]interface{} `description:"generated data if neither dest nor insert was specified"`
+	Inserted int
+}
+
+//TableInfo represents generated table info
+type TableInfo struct {
+	Table string
+	Rows  int
+	URL   string `json:",omitempty"`
+}
+
+//Init initializes request
+func (r *Request) Init() error {
+	if r.Format == "" {
+		r.Format = FormatJSON
+	}
+	r.Format = strings.ToLower(r.Format)
+	if r.Rows == 0 {
+		r.Rows = 10
+	}
+	if r.Dest != nil {
+		r.Dest.Init()
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *Request) Validate() error {
+	if r.Datastore == "" && r.Schema == nil && len(r.Tables) == 0 {
+		return errors.New("datastore, schema and tables were empty")
+	}
+	if r.Insert && r.Datastore == "" {
+		return errors.New("datastore was empty")
+	}
+	if r.Format != FormatJSON && r.Format != FormatCSV {
+		return fmt.Errorf("unsupported format: %v", r.Format)
+	}
+	for _, table := range r.Tables {
+		if table.Table == "" {
+			return errors.New("table was empty")
+		}
+		for _, column := range table.Columns {
+			if err := column.Validate(); err != nil {
+				return errors.Wrapf(err, "invalid %v column", table.Table)
+			}
+		}
+	}
+	return nil
+}
+
+//Validate checks if column is valid
+func (c *Column) Validate() error {
+	if c.Name == "" {
+		return errors.New("name was empty")
+	}
+	if len(c.Weights) > 0 && len(c.Weights) != len(c.Values) {
+		return fmt.Errorf("%v: weights count %v does not match values count %v", c.Name, len(c.Weights), len(c.Values))
+	}
+	if c.ForeignKey != "" && !strings.Contains(c.ForeignKey, ".") {
+		return fmt.Errorf("%v: invalid foreign key %v, expected table.column", c.Name, c.ForeignKey)
+	}
+	if c.Provider != "" && providers[c.Provider] == nil {
+		return fmt.Errorf("%v: unsupported provider %v", c.Name, c.Provider)
+	}
+	return nil
+}
+
+//merge overrides column with non empty override attributes
+func (c *Column) merge(override *Column) {
+	if override.Type != "" {
+		c.Type = override.Type
+	}
+	if override.Length > 0 {
+		c.Length = override.Length
+	}
+	if override.Nullable {
+		c.Nullable = true
+	}
+	if override.NullRatio > 0 {
+		c.NullRatio = override.NullRatio
+	}
+	if override.Unique {
+		c.Unique = true
+	}
+	if override.PrimaryKey {
+		c.PrimaryKey = true
+	}
+	if override.ForeignKey != "" {
+		c.ForeignKey = override.ForeignKey
+	}
+	if override.Provider != "" {
+		c.Provider = override.Provider
+	}
+	if override.Distribution != "" {
+		c.Distribution = override.Distribution
+	}
+	if override.Min != nil {
+		c.Min = override.Min
+	}
+	if override.Max != nil {
+		c.Max = override.Max
+	}
+	if override.Mean != 0 {
+		c.Mean = override.Mean
+	}
+	if override.StdDev != 0 {
+		c.StdDev = override.StdDev
+	}
+	if len(override.Values) > 0 {
+		c.Values = override.Values
+		c.Weights = override.Weights
+	}
+	if override.Value != nil {
+		c.Value = override.Value
+	}
+	if override.Format != "" {
+		c.Format = override.Format
+	}
+}
@@ -0,0 +1,400 @@
+package generate
+
+import (
+	"fmt"
+	edsunit "github.com/viant/endly/testing/dsunit"
+	"github.com/viant/toolbox"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	kindInt    = "int"
+	kindFloat  = "float"
+	kindString = "string"
+	kindBool   = "bool"
+	kindDate   = "date"
+	kindTime   = "time"
+	kindUUID   = "uuid"
+
+	distributionUniform  = "uniform"
+	distributionNormal   = "normal"
+	distributionSequence = "sequence"
+
+	defaultTimeLayout = "2006-01-02 15:04:05"
+	defaultDateLayout = "2006-01-02"
+	maxUniqueAttempts = 100
+)
+
+var (
+	defaultMinTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	defaultMaxTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+//columnKind returns generator kind for column type or database type name
+func columnKind(column *Column) string {
+	columnType := strings.ToLower(column.Type)
+	switch {
+	case columnType == "":
+		return kindString
+	case columnType == kindUUID:
+		return kindUUID
+	case strings.Contains(columnType, "bool") || columnType == "bit":
+		return kindBool
+	case strings.Contains(columnType, "int") || strings.Contains(columnType, "serial"):
+		return kindInt
+	case strings.Contains(columnType, "float") || strings.Contains(columnType, "double") || strings.Contains(columnType, "real") ||
+		strings.Contains(columnType, "decimal") || strings.Contains(columnType, "numeric") || strings.Contains(columnType, "money"):
+		return kindFloat
+	case columnType == kindDate:
+		return kindDate
+	case strings.Contains(columnType, "time") || strings.Contains(columnType, "date"):
+		return kindTime
+	}
+	return kindString
+}
+
+//generator represents a seeded data generator
+type generator struct {
+	random *rand.Rand
+	data   map[string][]map[string]interface{}
+	lookup func(table, column string) ([]interface{}, error)
+}
+
+//columnState represents column generation state
+type columnState struct {
+	*Column
+	kind   string
+	unique map[string]bool
+	refs   []interface{}
+	self   string
+}
+
+//generateTable generates table records, nullable foreign keys without referenced values are set to NULL,
+//self references are picked from previously generated rows
+func (g *generator) generateTable(table *Table, rows int) ([]map[string]interface{}, error) {
+	var states = make([]*columnState, 0, len(table.Columns))
+	var selfStates = make([]*columnState, 0)
+	for _, column := range table.Columns {
+		state := &columnState{Column: column, kind: columnKind(column), unique: make(map[string]bool)}
+		if column.ForeignKey != "" {
+			pair := strings.SplitN(column.ForeignKey, ".", 2)
+			if strings.EqualFold(pair[0], table.Table) {
+				state.self = pair[1]
+				selfStates = append(selfStates, state)
+				continue
+			}
+			refs, err := g.references(column.ForeignKey)
+			if err != nil {
+				return nil, err
+			}
+			if len(refs) == 0 && !column.Nullable {
+				return nil, fmt.Errorf("%v.%v: no values in referenced %v", table.Table, column.Name, column.ForeignKey)
+			}
+			state.refs = refs
+		}
+		states = append(states, state)
+	}
+	var result = make([]map[string]interface{}, 0, rows)
+	for row := 0; row < rows; row++ {
+		var record = make(map[string]interface{})
+		for _, state := range states {
+			value, err := g.uniqueValue(state, row, rows)
+			if err != nil {
+				return nil, fmt.Errorf("%v.%v: %v", table.Table, state.Name, err)
+			}
+			record[state.Name] = value
+		}
+		for _, state := range selfStates {
+			value, err := g.selfReference(state, record, row, rows)
+			if err != nil {
+				return nil, fmt.Errorf("%v.%v: %v", table.Table, state.Name, err)
+			}
+			record[state.Name] = value
+		}
+		for _, state := range selfStates {
+			if value := recordValue(record, state.self); value != nil {
+				state.refs = append(state.refs, value)
+			}
+		}
+		result = append(result, record)
+	}
+	g.data[table.Table] = result
+	return result, nil
+}
+
+//selfReference returns value referencing previously generated row, the first row references NULL or itself
+func (g *generator) selfReference(state *columnState, record map[string]interface{}, row, rows int) (interface{}, error) {
+	if len(state.refs) > 0 {
+		return g.uniqueValue(state, row, rows)
+	}
+	if state.Nullable {
+		return nil, nil
+	}
+	if value := recordValue(record, state.self); value != nil {
+		return value, nil
+	}
+	return nil, fmt.Errorf("no values in referenced %v", state.ForeignKey)
+}
+
+func recordValue(record map[string]interface{}, column string) interface{} {
+	for k, v := range record {
+		if strings.EqualFold(k, column) {
+			return v
+		}
+	}
+	return nil
+}
+
+//references returns referenced table.column values, generated data takes precedence over datastore data
+func (g *generator) references(foreignKey string) ([]interface{}, error) {
+	pair := strings.SplitN(foreignKey, ".", 2)
+	table, column := pair[0], pair[1]
+	for candidate, records := range g.data {
+		if !strings.EqualFold(candidate, table) {
+			continue
+		}
+		var result = make([]interface{}, 0, len(records))
+		for _, record := range records {
+			for k, v := range record {
+				if strings.EqualFold(k, column) && v != nil {
+					result = append(result, v)
+				}
+			}
+		}
+		return result, nil
+	}
+	if g.lookup == nil {
+		return nil, fmt.Errorf("unknown referenced table: %v", table)
+	}
+	return g.lookup(table, column)
+}
+
+func (g *generator) uniqueValue(state *columnState, row, rows int) (interface{}, error) {
+	if !state.Unique && !state.PrimaryKey {
+		return g.value(state, row, rows)
+	}
+	for i := 0; i < maxUniqueAttempts; i++ {
+		value, err := g.value(state, row, rows)
+		if err != nil || value == nil {
+			return value, err
+		}
+		key := toolbox.AsString(value)
+		if !state.unique[key] {
+			state.unique[key] = true
+			return value, nil
+		}
+	}
+	if state.kind == kindString && len(state.Values) == 0 && state.ForeignKey == "" {
+		value := fmt.Sprintf("%v_%d", g.stringValue(state), row)
+		state.unique[value] = true
+		return value, nil
+	}
+	return nil, fmt.Errorf("unable to generate unique value after %v attempts", maxUniqueAttempts)
+}
+
+func (g *generator) value(state *columnState, row, rows int) (interface{}, error) {
+	if state.Value != nil {
+		return state.Value, nil
+	}
+	if state.Nullable && !state.PrimaryKey && state.NullRatio > 0 && g.random.Float64() < state.NullRatio {
+		return nil, nil
+	}
+	if state.ForeignKey != "" {
+		if len(state.refs) == 0 {
+			return nil, nil
+		}
+		return state.refs[g.random.Intn(len(state.refs))], nil
+	}
+	if len(state.Values) > 0 {
+		return g.choice(state.Values, state.Weights), nil
+	}
+	if state.Provider != "" {
+		value := providers[state.Provider](g.random)
+		if text, ok := value.(string); ok && state.Length > 0 && len(text) > state.Length {
+			value = text[:state.Length]
+		}
+		return value, nil
+	}
+	switch state.kind {
+	case kindInt:
+		return g.intValue(state, row, rows), nil
+	case kindFloat:
+		return g.floatValue(state, row), nil
+	case kindBool:
+		return g.random.Intn(2) == 1, nil
+	case kindDate, kindTime:
+		return g.timeValue(state, row)
+	case kindUUID:
+		return newUUID(g.random), nil
+	}
+	return g.stringValue(state), nil
+}
+
+//choice returns weighted random value
+func (g *generator) choice(values []interface{}, weights []float64) interface{} {
+	if len(weights) == 0 {
+		return values[g.random.Intn(len(values))]
+	}
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	point := g.random.Float64() * total
+	for i, weight := range weights {
+		if point < weight {
+			return values[i]
+		}
+		point -= weight
+	}
+	return values[len(values)-1]
+}
+
+func (g *generator) numericRange(state *columnState, defaultMax float64) (float64, float64) {
+	min, max := 0.0, defaultMax
+	if state.Min != nil {
+		min = toolbox.AsFloat(state.Min)
+	}
+	if state.Max != nil {
+		max = toolbox.AsFloat(state.Max)
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+func (g *generator) normal(state *columnState, min, max float64) float64 {
+	stdDev := state.StdDev
+	if stdDev == 0 {
+		stdDev = (max - min) / 6
+	}
+	value := state.Mean + g.random.NormFloat64()*stdDev
+	if state.Min != nil {
+		value = math.Max(min, value)
+	}
+	if state.Max != nil {
+		value = math.Min(max, value)
+	}
+	return value
+}
+
+func (g *generator) intValue(state *columnState, row, rows int) interface{} {
+	defaultMax := 1000.0
+	if state.Unique || state.PrimaryKey {
+		defaultMax = math.Max(defaultMax, float64(rows*10))
+	}
+	min, max := g.numericRange(state, defaultMax)
+	distribution := state.Distribution
+	if distribution == "" && state.PrimaryKey {
+		distribution = distributionSequence
+	}
+	switch distribution {
+	case distributionSequence:
+		if state.Min == nil {
+			min = 1
+		}
+		return int(min) + row
+	case distributionNormal:
+		return int(math.Round(g.normal(state, min, max)))
+	}
+	return int(min) + g.random.Intn(int(max-min)+1)
+}
+
+func (g *generator) floatValue(state *columnState, row int) interface{} {
+	min, max := g.numericRange(state, 1000)
+	var value float64
+	switch state.Distribution {
+	case distributionSequence:
+		value = min + float64(row)
+	case distributionNormal:
+		value = g.normal(state, min, max)
+	default:
+		value = min + g.random.Float64()*(max-min)
+	}
+	return math.Round(value*100) / 100
+}
+
+func (g *generator) timeBoundary(value interface{}, layout string, defaultValue time.Time) (time.Time, error) {
+	if value == nil {
+		return defaultValue, nil
+	}
+	result, err := toolbox.ToTime(value, layout)
+	if err != nil {
+		return defaultValue, err
+	}
+	return *result, nil
+}
+
+func (g *generator) timeValue(state *columnState, row int) (interface{}, error) {
+	layout := state.Format
+	if layout == "" {
+		layout = defaultTimeLayout
+		if state.kind == kindDate {
+			layout = defaultDateLayout
+		}
+	}
+	min, err := g.timeBoundary(state.Min, layout, defaultMinTime)
+	if err != nil {
+		return nil, err
+	}
+	max, err := g.timeBoundary(state.Max, layout, defaultMaxTime)
+	if err != nil {
+		return nil, err
+	}
+	var value time.Time
+	switch state.Distribution {
+	case distributionSequence:
+		step := time.Hour
+		if state.kind == kindDate {
+			step = 24 * time.Hour
+		}
+		value = min.Add(time.Duration(row) * step)
+	default:
+		span := max.Unix() - min.Unix()
+		if span <= 0 {
+			span = 1
+		}
+		value = min.Add(time.Duration(g.random.Int63n(span)) * time.Second)
+	}
+	return value.UTC().Format(layout), nil
+}
+
+func (g *generator) stringValue(state *columnState) string {
+	value := pick(g.random, words) + "_" + digits(g.random, 4)
+	if state.Length > 0 && len(value) > state.Length {
+		value = value[:state.Length]
+	}
+	return value
+}
+
+//sortTables sorts tables so that referenced tables are generated first
+func sortTables(tables []*Table) []*Table {
+	var names = make([]string, 0, len(tables))
+	var byName = make(map[string]*Table)
+	var dependencies = make(map[string][]string)
+	for _, table := range tables {
+		names = append(names, table.Table)
+		byName[table.Table] = table
+		for _, column := range table.Columns {
+			if column.ForeignKey != "" {
+				dependencies[table.Table] = append(dependencies[table.Table], strings.SplitN(column.ForeignKey, ".", 2)[0])
+			}
+		}
+	}
+	var result = make([]*Table, 0, len(tables))
+	for _, name := range edsunit.SortByDependency(names, dependencies) {
+		result = append(result, byName[name])
+	}
+	return result
+}
+
+func newGenerator(seed int64, lookup func(table, column string) ([]interface{}, error)) *generator {
+	return &generator{
+		random: rand.New(rand.NewSource(seed)),
+		data:   make(map[string][]map[string]interface{}),
+		lookup: lookup,
+	}
+}
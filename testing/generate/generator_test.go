@@ -0,0 +1,135 @@
+package generate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"testing"
+)
+
+func TestGenerator_GenerateTable(t *testing.T) {
+	var tables = []*Table{
+		{
+			Table: "orders",
+			Columns: []*Column{
+				{Name: "id", Type: "int", PrimaryKey: true},
+				{Name: "user_id", ForeignKey: "users.id"},
+				{Name: "amount", Type: "decimal", Distribution: "normal", Mean: 100, StdDev: 10, Min: 1},
+				{Name: "created", Type: "timestamp", Min: "2021-01-01 00:00:00", Max: "2021-12-31 00:00:00"},
+			},
+		},
+		{
+			Table: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "bigint", PrimaryKey: true},
+				{Name: "email", Type: "varchar", Provider: "email", Unique: true},
+				{Name: "status", Values: []interface{}{"active", "disabled"}, Weights: []float64{1, 0}},
+				{Name: "comments", Type: "text", Nullable: true, NullRatio: 1},
+			},
+		},
+	}
+	sorted := sortTables(tables)
+	assert.EqualValues(t, "users", sorted[0].Table)
+	assert.EqualValues(t, "orders", sorted[1].Table)
+
+	generate := func() map[string][]map[string]interface{} {
+		generator := newGenerator(7, nil)
+		for _, table := range sorted {
+			_, err := generator.generateTable(table, 20)
+			assert.Nil(t, err)
+		}
+		return generator.data
+	}
+	data := generate()
+	assert.EqualValues(t, data, generate(), "the same seed should produce the same data")
+
+	var userIDs = make(map[int]bool)
+	var emails = make(map[string]bool)
+	for i, record := range data["users"] {
+		assert.EqualValues(t, i+1, record["id"])
+		assert.EqualValues(t, "active", record["status"])
+		assert.Nil(t, record["comments"])
+		userIDs[toolbox.AsInt(record["id"])] = true
+		emails[toolbox.AsString(record["email"])] = true
+	}
+	assert.EqualValues(t, 20, len(emails))
+	for _, record := range data["orders"] {
+		assert.True(t, userIDs[toolbox.AsInt(record["user_id"])])
+		assert.True(t, toolbox.AsFloat(record["amount"]) >= 1)
+		created := toolbox.AsString(record["created"])
+		assert.True(t, created >= "2021-01-01" && created < "2022", created)
+	}
+}
+
+func TestGenerator_Unique(t *testing.T) {
+	generator := newGenerator(1, nil)
+	_, err := generator.generateTable(&Table{Table: "t", Columns: []*Column{{Name: "flag", Type: "bool", Unique: true}}}, 3)
+	assert.NotNil(t, err)
+
+	_, err = generator.generateTable(&Table{Table: "t", Columns: []*Column{{Name: "code", Values: []interface{}{"a"}, Unique: true}}}, 2)
+	assert.NotNil(t, err)
+
+	records, err := generator.generateTable(&Table{Table: "t", Columns: []*Column{{Name: "name", Provider: "color", Unique: true}}}, 30)
+	if assert.Nil(t, err) {
+		var names = make(map[string]bool)
+		for _, record := range records {
+			names[toolbox.AsString(record["name"])] = true
+		}
+		assert.EqualValues(t, 30, len(names))
+	}
+}
+
+func TestColumnKind(t *testing.T) {
+	var useCases = map[string]string{
+		"":                  kindString,
+		"INT":               kindInt,
+		"bigserial":         kindInt,
+		"DECIMAL":           kindFloat,
+		"double precision":  kindFloat,
+		"BOOLEAN":           kindBool,
+		"date":              kindDate,
+		"DATETIME":          kindTime,
+		"timestamp":         kindTime,
+		"VARCHAR":           kindString,
+		"uuid":              kindUUID,
+		"character varying": kindString,
+	}
+	for columnType, expect := range useCases {
+		assert.EqualValues(t, expect, columnKind(&Column{Type: columnType}), columnType)
+	}
+}
+
+func TestGenerator_References(t *testing.T) {
+	generator := newGenerator(3, nil)
+	_, err := generator.generateTable(&Table{Table: "accounts", Columns: []*Column{{Name: "id", Type: "int", PrimaryKey: true}}}, 0)
+	assert.Nil(t, err)
+
+	_, err = generator.generateTable(&Table{Table: "orders", Columns: []*Column{{Name: "account_id", ForeignKey: "accounts.id"}}}, 2)
+	assert.NotNil(t, err, "non nullable foreign key requires referenced values")
+
+	records, err := generator.generateTable(&Table{Table: "orders", Columns: []*Column{{Name: "account_id", ForeignKey: "accounts.id", Nullable: true}}}, 2)
+	if assert.Nil(t, err) {
+		for _, record := range records {
+			assert.Nil(t, record["account_id"])
+		}
+	}
+
+	records, err = generator.generateTable(&Table{Table: "employees", Columns: []*Column{
+		{Name: "id", Type: "int", PrimaryKey: true},
+		{Name: "manager_id", ForeignKey: "employees.id", Nullable: true},
+	}}, 10)
+	if assert.Nil(t, err) {
+		assert.Nil(t, records[0]["manager_id"])
+		for i := 1; i < len(records); i++ {
+			managerID := toolbox.AsInt(records[i]["manager_id"])
+			assert.True(t, managerID >= 1 && managerID < toolbox.AsInt(records[i]["id"]))
+		}
+	}
+
+	records, err = generator.generateTable(&Table{Table: "nodes", Columns: []*Column{
+		{Name: "id", Type: "int", PrimaryKey: true},
+		{Name: "parent_id", ForeignKey: "nodes.id"},
+	}}, 3)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, records[0]["id"], records[0]["parent_id"])
+	}
+}
@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+var (
+	firstNames   = []string{"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Charles", "Karen", "Daniel", "Nancy", "Matthew", "Lisa", "Anthony", "Betty", "Mark", "Margaret", "Paul", "Sandra"}
+	lastNames    = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez", "Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin", "Lee", "Perez", "Thompson", "White", "Harris", "Sanchez", "Clark", "Ramirez", "Lewis", "Robinson"}
+	companies    = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark", "Wayne", "Wonka", "Cyberdyne", "Soylent", "Vandelay", "Tyrell", "Aperture", "Massive", "Oscorp"}
+	companyTypes = []string{"Inc", "LLC", "Corp", "Ltd", "Group"}
+	streets      = []string{"Main", "Oak", "Pine", "Maple", "Cedar", "Elm", "Washington", "Lake", "Hill", "Park", "Sunset", "River"}
+	streetTypes  = []string{"St", "Ave", "Blvd", "Rd", "Ln", "Dr"}
+	cities       = []string{"New York", "Los Angeles", "Chicago", "Houston", "Phoenix", "Philadelphia", "San Antonio", "San Diego", "Dallas", "Austin", "Seattle", "Denver", "Boston", "Portland", "Miami"}
+	countries    = [][]string{{"United States", "US"}, {"Canada", "CA"}, {"Mexico", "MX"}, {"United Kingdom", "GB"}, {"Germany", "DE"}, {"France", "FR"}, {"Poland", "PL"}, {"Spain", "ES"}, {"Italy", "IT"}, {"Japan", "JP"}, {"Brazil", "BR"}, {"Australia", "AU"}}
+	words        = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa", "quebec", "romeo", "sierra", "tango", "uniform", "victor", "whiskey", "xray", "yankee", "zulu"}
+	domains      = []string{"example.com", "example.org", "example.net", "test.com", "mail.com"}
+	colors       = []string{"red", "green", "blue", "yellow", "orange", "purple", "black", "white", "gray", "pink"}
+)
+
+func pick(random *rand.Rand, values []string) string {
+	return values[random.Intn(len(values))]
+}
+
+func digits(random *rand.Rand, count int) string {
+	var result = make([]byte, count)
+	for i := range result {
+		result[i] = byte('0' + random.Intn(10))
+	}
+	return string(result)
+}
+
+func newUUID(random *rand.Rand) string {
+	var data = make([]byte, 16)
+	random.Read(data)
+	data[6] = (data[6] & 0x0f) | 0x40
+	data[8] = (data[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:])
+}
+
+//providers represents faker style value providers
+var providers = map[string]func(random *rand.Rand) interface{}{
+	"firstName": func(random *rand.Rand) interface{} {
+		return pick(random, firstNames)
+	},
+	"lastName": func(random *rand.Rand) interface{} {
+		return pick(random, lastNames)
+	},
+	"name": func(random *rand.Rand) interface{} {
+		return pick(random, firstNames) + " " + pick(random, lastNames)
+	},
+	"username": func(random *rand.Rand) interface{} {
+		return strings.ToLower(pick(random, firstNames)[:1]+pick(random, lastNames)) + digits(random, 2)
+	},
+	"email": func(random *rand.Rand) interface{} {
+		return strings.ToLower(pick(random, firstNames)+"."+pick(random, lastNames)) + digits(random, 2) + "@" + pick(random, domains)
+	},
+	"phone": func(random *rand.Rand) interface{} {
+		return fmt.Sprintf("+1-%v-%v-%v", digits(random, 3), digits(random, 3), digits(random, 4))
+	},
+	"company": func(random *rand.Rand) interface{} {
+		return pick(random, companies) + " " + pick(random, companyTypes)
+	},
+	"street": func(random *rand.Rand) interface{} {
+		return fmt.Sprintf("%v %v %v", 1+random.Intn(9999), pick(random, streets), pick(random, streetTypes))
+	},
+	"city": func(random *rand.Rand) interface{} {
+		return pick(random, cities)
+	},
+	"country": func(random *rand.Rand) interface{} {
+		return countries[random.Intn(len(countries))][0]
+	},
+	"countryCode": func(random *rand.Rand) interface{} {
+		return countries[random.Intn(len(countries))][1]
+	},
+	"zip": func(random *rand.Rand) interface{} {
+		return digits(random, 5)
+	},
+	"word": func(random *rand.Rand) interface{} {
+		return pick(random, words)
+	},
+	"sentence": func(random *rand.Rand) interface{} {
+		var fragments = make([]string, 4+random.Intn(6))
+		for i := range fragments {
+			fragments[i] = pick(random, words)
+		}
+		text := strings.Join(fragments, " ")
+		return strings.ToUpper(text[:1]) + text[1:] + "."
+	},
+	"uuid": func(random *rand.Rand) interface{} {
+		return newUUID(random)
+	},
+	"ipv4": func(random *rand.Rand) interface{} {
+		return fmt.Sprintf("%v.%v.%v.%v", 1+random.Intn(254), random.Intn(256), random.Intn(256), 1+random.Intn(254))
+	},
+	"domain": func(random *rand.Rand) interface{} {
+		return pick(random, words) + "." + pick(random, []string{"com", "org", "net", "io"})
+	},
+	"url": func(random *rand.Rand) interface{} {
+		return fmt.Sprintf("https://%v.%v/%v", pick(random, words), pick(random, []string{"com", "org", "net", "io"}), pick(random, words))
+	},
+	"color": func(random *rand.Rand) interface{} {
+		return pick(random, colors)
+	},
+}
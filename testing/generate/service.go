@@ -0,0 +1,326 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/storage"
+	"github.com/viant/dsc"
+	"github.com/viant/dsunit"
+	"github.com/viant/endly"
+	estorage "github.com/viant/endly/system/storage"
+	edsunit "github.com/viant/endly/testing/dsunit"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"strings"
+	"time"
+)
+
+const (
+	//ServiceID represents synthetic data generation service id
+	ServiceID = "data/generate"
+)
+
+const generateExample = `{
+  "Datastore": "db1",
+  "Seed": 42,
+  "Rows": 100,
+  "Tables": [
+    {
+      "Table": "users",
+      "Columns": [
+        {"Name": "email", "Provider": "email", "Unique": true},
+        {"Name": "status", "Values": ["active", "disabled"], "Weights": [0.9, 0.1]}
+      ]
+    },
+    {
+      "Table": "orders",
+      "Rows": 500,
+      "Columns": [
+        {"Name": "user_id", "ForeignKey": "users.id"},
+        {"Name": "amount", "Distribution": "normal", "Mean": 120, "StdDev": 40, "Min": 1}
+      ]
+    }
+  ],
+  "Dest": {
+    "URL": "test/data/setup"
+  }
+}`
+
+type service struct {
+	*endly.AbstractService
+}
+
+//loadTables loads tables from schema and merges them with request tables
+func (s *service) loadTables(context *endly.Context, request *Request) ([]*Table, error) {
+	var result = make([]*Table, 0)
+	if request.Schema != nil {
+		resource, err := context.ExpandResource(request.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if err = resource.Decode(&result); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode schema: %v", resource.URL)
+		}
+	}
+	for _, table := range request.Tables {
+		if existing := lookupTable(result, table.Table); existing != nil {
+			mergeTable(existing, table)
+			continue
+		}
+		result = append(result, table)
+	}
+	return result, nil
+}
+
+func lookupTable(tables []*Table, name string) *Table {
+	for _, table := range tables {
+		if strings.EqualFold(table.Table, name) {
+			return table
+		}
+	}
+	return nil
+}
+
+func lookupColumn(columns []*Column, name string) *Column {
+	for _, column := range columns {
+		if strings.EqualFold(column.Name, name) {
+			return column
+		}
+	}
+	return nil
+}
+
+//mergeTable merges override table columns into table
+func mergeTable(table, override *Table) {
+	if override.Rows > 0 {
+		table.Rows = override.Rows
+	}
+	for _, column := range override.Columns {
+		if existing := lookupColumn(table.Columns, column.Name); existing != nil {
+			existing.merge(column)
+			continue
+		}
+		table.Columns = append(table.Columns, column)
+	}
+}
+
+//readSchema reads tables columns, foreign keys and unique indexes from datastore, request tables columns are used as overrides
+func (s *service) readSchema(manager dsc.Manager, tables []*Table) ([]*Table, error) {
+	dialect := dsc.GetDatastoreDialect(manager.Config().DriverName)
+	datastore, err := dialect.GetCurrentDatastore(manager)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		names, err := dialect.GetTables(manager, datastore)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			tables = append(tables, &Table{Table: name})
+		}
+	}
+	var result = make([]*Table, 0)
+	for _, table := range tables {
+		columns, err := dialect.GetColumns(manager, datastore, table.Table)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %v columns", table.Table)
+		}
+		keys := strings.Split(dialect.GetKeyName(manager, datastore, table.Table), ",")
+		discovered := &Table{Table: table.Table, Rows: table.Rows, Columns: make([]*Column, 0)}
+		for _, source := range columns {
+			column := &Column{Name: source.Name(), Type: source.DatabaseTypeName()}
+			column.Nullable, _ = source.Nullable()
+			if length, ok := source.Length(); ok && length > 0 && length < 1024 {
+				column.Length = int(length)
+			}
+			for _, key := range keys {
+				if strings.EqualFold(strings.TrimSpace(key), column.Name) {
+					column.PrimaryKey = true
+				}
+			}
+			discovered.Columns = append(discovered.Columns, column)
+		}
+		tableConstraints, err := readConstraints(manager, datastore, table.Table)
+		if err != nil {
+			return nil, err
+		}
+		tableConstraints.apply(discovered)
+		mergeTable(discovered, table)
+		result = append(result, discovered)
+	}
+	return result, nil
+}
+
+//lookupReferences returns referenced column values from datastore, values are ordered so that generated data is deterministic for a seed
+func lookupReferences(manager dsc.Manager) func(table, column string) ([]interface{}, error) {
+	return func(table, column string) ([]interface{}, error) {
+		var records = make([]map[string]interface{}, 0)
+		if err := manager.ReadAll(&records, fmt.Sprintf("SELECT %v FROM %v ORDER BY %v", column, table, column), nil, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to read referenced %v.%v", table, column)
+		}
+		var result = make([]interface{}, 0, len(records))
+		for _, record := range records {
+			for _, value := range record {
+				if value != nil {
+					result = append(result, value)
+				}
+			}
+		}
+		return result, nil
+	}
+}
+
+//encode encodes records with table columns order
+func encode(format string, table *Table, records []map[string]interface{}) ([]byte, error) {
+	if format == FormatJSON {
+		text, err := toolbox.AsIndentJSONText(records)
+		return []byte(text), err
+	}
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+	var header = make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		var row = make([]string, len(header))
+		for i, column := range header {
+			if value := record[column]; value != nil {
+				row[i] = toolbox.AsString(value)
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+func (s *service) generate(context *endly.Context, request *Request) (*Response, error) {
+	response := &Response{Seed: request.Seed, Tables: make([]*TableInfo, 0)}
+	if response.Seed == 0 {
+		response.Seed = time.Now().UnixNano()
+	}
+	tables, err := s.loadTables(context, request)
+	if err != nil {
+		return nil, err
+	}
+	var lookup func(table, column string) ([]interface{}, error)
+	if request.Datastore != "" {
+		manager, err := edsunit.GetManager(context, request.Datastore)
+		if err != nil {
+			return nil, err
+		}
+		if tables, err = s.readSchema(manager, tables); err != nil {
+			return nil, err
+		}
+		lookup = lookupReferences(manager)
+	}
+	var dest *url.Resource
+	var storageOpts []storage.Option
+	var fs afs.Service
+	if request.Dest != nil {
+		if dest, storageOpts, err = estorage.GetResourceWithOptions(context, request.Dest); err != nil {
+			return nil, err
+		}
+		if fs, err = estorage.StorageService(context, dest); err != nil {
+			return nil, err
+		}
+	}
+	generator := newGenerator(response.Seed, lookup)
+	var datasets = make([]*dsunit.Dataset, 0)
+	for _, table := range sortTables(tables) {
+		for _, column := range table.Columns {
+			if err = column.Validate(); err != nil {
+				return nil, errors.Wrapf(err, "invalid %v column", table.Table)
+			}
+		}
+		rows := table.Rows
+		if rows == 0 {
+			rows = request.Rows
+		}
+		records, err := generator.generateTable(table, rows)
+		if err != nil {
+			return nil, err
+		}
+		info := &TableInfo{Table: table.Table, Rows: len(records)}
+		response.Tables = append(response.Tables, info)
+		datasets = append(datasets, dsunit.NewDataset(table.Table, records...))
+		if fs == nil {
+			continue
+		}
+		content, err := encode(request.Format, table, records)
+		if err != nil {
+			return nil, err
+		}
+		info.URL = toolbox.URLPathJoin(dest.URL, request.Prefix+table.Table+request.Postfix+"."+request.Format)
+		if err = fs.Upload(context.Background(), info.URL, file.DefaultFileOsMode, bytes.NewReader(content), storageOpts...); err != nil {
+			return nil, errors.Wrapf(err, "failed to upload %v", info.URL)
+		}
+	}
+	if request.Insert {
+		prepareRequest := &edsunit.PrepareRequest{
+			DatasetResource: &dsunit.DatasetResource{
+				Resource:          &url.Resource{},
+				DatastoreDatasets: &dsunit.DatastoreDatasets{Datastore: request.Datastore, Datasets: datasets},
+			},
+		}
+		prepareResponse := &dsunit.PrepareResponse{}
+		if err = endly.Run(context, prepareRequest, prepareResponse); err != nil {
+			return nil, err
+		}
+		for _, info := range prepareResponse.Modification {
+			response.Inserted += info.Added + info.Modified
+		}
+	}
+	if dest == nil && !request.Insert {
+		response.Data = generator.data
+	}
+	return response, nil
+}
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "generate",
+		RequestInfo: &endly.ActionInfo{
+			Description: "generate synthetic data based on datastore or JSON schema",
+			Examples: []*endly.UseCase{
+				{
+					Description: "generate dsunit datasets",
+					Data:        generateExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &Request{}
+		},
+		ResponseProvider: func() interface{} {
+			return &Response{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*Request); ok {
+				return s.generate(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+//New creates a new synthetic data generation service
+func New() endly.Service {
+	var result = &service{
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestService_Generate(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	dest := path.Join(os.TempDir(), "endly_generate")
+	_ = os.RemoveAll(dest)
+	defer os.RemoveAll(dest)
+
+	var useCases = []struct {
+		description string
+		request     *Request
+		expectFiles map[string]string
+		expectRows  map[string]int
+		hasError    bool
+	}{
+		{
+			description: "json schema with overrides",
+			request: &Request{
+				Schema: url.NewResource(path.Join(parent, "test/schema.json")),
+				Seed:   10,
+				Rows:   5,
+				Tables: []*Table{
+					{Table: "orders", Rows: 12, Columns: []*Column{{Name: "status", Values: []interface{}{"new"}}}},
+				},
+				Dest: url.NewResource(dest),
+			},
+			expectFiles: map[string]string{"users.json": `"email"`, "orders.json": `"status": "new"`},
+			expectRows:  map[string]int{"users": 5, "orders": 12},
+		},
+		{
+			description: "csv dataset",
+			request: &Request{
+				Seed:    10,
+				Rows:    3,
+				Tables:  []*Table{{Table: "products", Columns: []*Column{{Name: "id", Type: "int", PrimaryKey: true}, {Name: "name", Provider: "word"}}}},
+				Dest:    url.NewResource(dest),
+				Format:  "csv",
+				Prefix:  "prepare_",
+				Postfix: "_v1",
+			},
+			expectFiles: map[string]string{"prepare_products_v1.csv": "id,name\n1,"},
+			expectRows:  map[string]int{"products": 3},
+		},
+		{
+			description: "unsupported provider",
+			request: &Request{
+				Tables: []*Table{{Table: "products", Columns: []*Column{{Name: "name", Provider: "unknown"}}}},
+			},
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		response := &Response{}
+		err := endly.Run(nil, useCase.request, response)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.request.Seed, response.Seed, useCase.description)
+		for _, info := range response.Tables {
+			assert.EqualValues(t, useCase.expectRows[info.Table], info.Rows, useCase.description+" "+info.Table)
+		}
+		for name, expect := range useCase.expectFiles {
+			content, err := ioutil.ReadFile(path.Join(dest, name))
+			if assert.Nil(t, err, useCase.description) {
+				assert.True(t, strings.Contains(string(content), expect), useCase.description+" "+name)
+			}
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/url"
 	"regexp"
+	"strings"
+	"time"
 )
 
 //AssertRequest represents a log assert request
@@ -23,15 +25,10 @@ func (r *AssertRequest) Init() error {
 		return nil
 	}
 	for _, expecRecords := range r.Expect {
-		if len(expecRecords.Records) == 0 {
-			continue
-		}
-		for i, record := range expecRecords.Records {
-			if toolbox.IsSlice(record) {
-				if aMap, err := toolbox.ToMap(record); err == nil {
-					expecRecords.Records[i] = aMap
-				}
-			}
+		normalizeRecords(expecRecords.Records)
+		normalizeRecords(expecRecords.Absent)
+		for _, count := range expecRecords.Counts {
+			count.Pattern = normalizeRecord(count.Pattern)
 		}
 	}
 	if r.DescriptionTemplate == "" {
@@ -46,6 +43,21 @@ func (r *AssertRequest) Init() error {
 	return nil
 }
 
+func normalizeRecord(record interface{}) interface{} {
+	if toolbox.IsSlice(record) {
+		if aMap, err := toolbox.ToMap(record); err == nil {
+			return aMap
+		}
+	}
+	return record
+}
+
+func normalizeRecords(records []interface{}) {
+	for i, record := range records {
+		records[i] = normalizeRecord(record)
+	}
+}
+
 //Validate check if request is valid
 func (r *AssertRequest) Validate() error {
 	if len(r.Expect) == 0 {
@@ -55,6 +67,14 @@ func (r *AssertRequest) Validate() error {
 		if expecRecords.Type == "" {
 			return fmt.Errorf("Expect[%d].Type was empty", i)
 		}
+		for j, count := range expecRecords.Counts {
+			if count.Pattern == nil {
+				return fmt.Errorf("Expect[%d].Counts[%d].Pattern was empty", i, j)
+			}
+			if count.Count == nil && count.Min == nil && count.Max == nil {
+				return fmt.Errorf("Expect[%d].Counts[%d]: count, min or max was empty", i, j)
+			}
+		}
 	}
 	return nil
 }
@@ -64,6 +84,100 @@ type TypedRecord struct {
 	TagID   string `description:"neatly tag id for matching validation summary"`
 	Type    string `required:"true" description:"log type register with listener"`
 	Records []interface{}
+	Absent  []interface{}  `description:"records that must not appear in pending log records, i.e. level: ERROR"`
+	Counts  []*RecordCount `description:"expected number of pending log records matching a pattern"`
+	Window  *TimeWindow    `description:"if specified absent and counts assertions apply only to log records within the time window"`
+}
+
+//RecordCount represents an expected number of log records matching a pattern
+type RecordCount struct {
+	Pattern interface{} `required:"true" description:"expected record, either text or map, assertly syntax is supported i.e. /ERROR/"`
+	Count   *int        `description:"exact number of matching records"`
+	Min     *int        `description:"min number of matching records"`
+	Max     *int        `description:"max number of matching records"`
+}
+
+//IsSatisfied returns true if supplied number of matching records satisfies the expectation
+func (c *RecordCount) IsSatisfied(count int) bool {
+	if c.Count != nil && count != *c.Count {
+		return false
+	}
+	if c.Min != nil && count < *c.Min {
+		return false
+	}
+	if c.Max != nil && count > *c.Max {
+		return false
+	}
+	return true
+}
+
+//Expected returns expected count description
+func (c *RecordCount) Expected() string {
+	var result = make([]string, 0)
+	if c.Count != nil {
+		result = append(result, fmt.Sprintf("count: %v", *c.Count))
+	}
+	if c.Min != nil {
+		result = append(result, fmt.Sprintf("min: %v", *c.Min))
+	}
+	if c.Max != nil {
+		result = append(result, fmt.Sprintf("max: %v", *c.Max))
+	}
+	return strings.Join(result, ", ")
+}
+
+//TimeWindow represents log records time window
+type TimeWindow struct {
+	From       string `description:"window start, RFC3339 or time expression i.e. 5min ago"`
+	To         string `description:"window end, RFC3339 or time expression i.e. now"`
+	TimeField  string `description:"parsed log record time attribute, if empty the time a record was detected by listener is used"`
+	TimeLayout string `description:"time field layout, RFC3339 by default"`
+	from       *time.Time
+	to         *time.Time
+}
+
+//Init initializes time window
+func (w *TimeWindow) Init() error {
+	var err error
+	if w.TimeLayout == "" {
+		w.TimeLayout = time.RFC3339
+	}
+	if w.From != "" {
+		if w.from, err = asWindowTime(w.From, w.TimeLayout); err != nil {
+			return fmt.Errorf("invalid window from: %v, %v", w.From, err)
+		}
+	}
+	if w.To != "" {
+		if w.to, err = asWindowTime(w.To, w.TimeLayout); err != nil {
+			return fmt.Errorf("invalid window to: %v, %v", w.To, err)
+		}
+	}
+	return nil
+}
+
+//Contains returns true if log record falls within the window
+func (w *TimeWindow) Contains(record *Record) bool {
+	recordTime := &record.Timestamp
+	if w.TimeField != "" {
+		aMap, err := record.AsMap()
+		if err != nil {
+			return false
+		}
+		value, ok := aMap[w.TimeField]
+		if !ok {
+			return false
+		}
+		if recordTime, err = toolbox.ToTime(value, w.TimeLayout); err != nil || recordTime == nil {
+			return false
+		}
+	}
+	if w.from != nil && recordTime.Before(*w.from) {
+		return false
+	}
+	if w.to != nil && recordTime.After(*w.to) {
+		return false
+	}
+	return true
 }
 
 //AssertResponse represents a log assert response
@@ -78,8 +192,13 @@ func (r *AssertResponse) Assertion() []*assertly.Validation {
 
 //Type represents  a log type
 type Type struct {
-	Name         string `required:"true" description:"log type name"`
-	Format       string `description:"log format"`
+	Name         string   `required:"true" description:"log type name"`
+	Format       string   `description:"log format: json (default), logfmt, csv, tsv, apache, nginx, syslog or regexp"`
+	Columns      []string `description:"csv/tsv format column names, if empty the first log file line is used as a header"`
+	Pattern      string   `description:"regexp format expression with named groups i.e. ^(?P<time>\\S+) (?P<level>\\w+) (?P<message>.+)$"`
+	Multiline    bool     `description:"if set, lines starting with a whitespace, 'at ', 'Caused by:' or '...' are appended to the previous record i.e. stack traces"`
+	RecordStart  string   `description:"multiline expression matching the first line of a record, other lines are appended to the previous record"`
+	recordStart  *regexp.Regexp
 	Mask         string `description:"expected log file mast"`
 	Exclusion    string `description:"if specified, exclusion fragment can not match log record"`
 	Inclusion    string `description:"if specified, inclusion fragment must match log record"`
@@ -91,9 +210,20 @@ type Type struct {
 
 //ListenRequest represents listen for a logs request.
 type ListenRequest struct {
-	FrequencyMs int
-	Source      *url.Resource `required:"true" description:"log location"`
-	Types       []*Type       `required:"true" description:"log types"`
+	FrequencyMs    int
+	Source         *url.Resource `required:"true" description:"log location, docker://container or k8s://namespace/pod/container streams container logs"`
+	Types          []*Type       `required:"true" description:"log types"`
+	IncludeHistory bool          `description:"if set, docker and k8s log streams replay container log history, otherwise only records logged after listen are streamed"`
+}
+
+//Init initializes log types
+func (r *ListenRequest) Init() error {
+	for _, logType := range r.Types {
+		if err := logType.Init(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 //ListenResponse represents a log validation listen response.
@@ -116,6 +246,37 @@ func (t *Type) GetIndexExpr() (*regexp.Regexp, error) {
 	return t.indexExpr, err
 }
 
+//IsMultiline returns true if log record can span multiple lines
+func (t *Type) IsMultiline() bool {
+	return t.Multiline || t.RecordStart != ""
+}
+
+//Init compiles record start expression
+func (t *Type) Init() error {
+	if t.RecordStart == "" {
+		return nil
+	}
+	var err error
+	if t.recordStart, err = regexp.Compile(t.RecordStart); err != nil {
+		return fmt.Errorf("invalid %v recordStart: %v, %v", t.Name, t.RecordStart, err)
+	}
+	return nil
+}
+
+//IsContinuation returns true if supplied line continues the previous multiline log record, type has to be initialized
+func (t *Type) IsContinuation(line string) bool {
+	if t.recordStart != nil {
+		return !t.recordStart.MatchString(line)
+	}
+	if line == "" {
+		return false
+	}
+	if line[0] == ' ' || line[0] == '\t' {
+		return true
+	}
+	return strings.HasPrefix(line, "at ") || strings.HasPrefix(line, "Caused by:") || strings.HasPrefix(line, "...")
+}
+
 //ResetRequest represents a log reset request
 type ResetRequest struct {
 	LogTypes []string `required:"true" description:"log types to reset"`
@@ -27,6 +27,9 @@ type File struct {
 	IndexedRecords  map[string]*Record
 	Mutex           *sync.RWMutex
 	context         *endly.Context
+	parser          Parser
+	pendingRecord   *Record
+	pendingTime     time.Time
 }
 
 //ShiftLogRecord returns and remove the first log record if present
@@ -88,7 +91,9 @@ func (f *File) PushLogRecord(record *Record) {
 	if len(f.Records) == 0 {
 		f.Records = make([]*Record, 0)
 	}
-
+	if record.parser == nil {
+		record.parser = f.parser
+	}
 	indexValue := ""
 	f.Records = append(f.Records, record)
 	if f.UseIndex() {
@@ -112,6 +117,57 @@ func (f *File) PushLogRecord(record *Record) {
 
 }
 
+//holdLogRecord holds multiline log record till it is complete, so that its continuation lines are appended before it is published
+func (f *File) holdLogRecord(record *Record) {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	f.pendingRecord = record
+	f.pendingTime = time.Now()
+}
+
+//appendLogRecordLine appends continuation line to the held multiline log record, returns false if there is no such record
+func (f *File) appendLogRecordLine(line string) bool {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	if f.pendingRecord == nil {
+		return false
+	}
+	f.pendingRecord.Line += "\n" + line
+	f.pendingTime = time.Now()
+	if f.Type.Debug {
+		_ = endly.Run(f.context, &workflow.PrintRequest{
+			Style:   msg.MessageStyleInput,
+			Message: fmt.Sprintf("append [%v:%v] <- %v", f.Type.Name, f.pendingRecord.Number, line),
+		}, nil)
+	}
+	return true
+}
+
+//flushPendingRecord pushes held multiline log record once no line was appended for idle time
+func (f *File) flushPendingRecord(idle time.Duration) {
+	f.Mutex.Lock()
+	record := f.pendingRecord
+	if record == nil || time.Since(f.pendingTime) < idle {
+		f.Mutex.Unlock()
+		return
+	}
+	f.pendingRecord = nil
+	f.Mutex.Unlock()
+	f.PushLogRecord(record)
+}
+
+//Parser returns log file parser
+func (f *File) Parser() (Parser, error) {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	if f.parser != nil {
+		return f.parser, nil
+	}
+	var err error
+	f.parser, err = NewParser(f.Type)
+	return f.parser, err
+}
+
 //Reset resets processing state
 func (f *File) Reset(object storage.Object) {
 	f.Mutex.Lock()
@@ -119,6 +175,17 @@ func (f *File) Reset(object storage.Object) {
 	f.Size = int(object.Size())
 	f.LastModified = object.ModTime()
 	f.ProcessingState.Reset()
+	f.parser = nil
+	f.pendingRecord = nil
+}
+
+//PendingRecords returns pending validation records without removing them
+func (f *File) PendingRecords() []*Record {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	var result = make([]*Record, len(f.Records))
+	copy(result, f.Records)
+	return result
 }
 
 //HasPendingLogs returns true if file has pending validation records
@@ -148,33 +215,55 @@ func (f *File) readLogRecords(reader io.Reader) error {
 			line += aChar
 			continue
 		}
-
-		line = strings.Trim(line, " \r\t")
 		lineIndex++
-		if f.Exclusion != "" {
-			if strings.Contains(line, f.Exclusion) {
-				line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
-				continue
-			}
-		}
-		if f.Inclusion != "" {
-			if !strings.Contains(line, f.Inclusion) {
-				line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
-				continue
-			}
+		if err = f.processLine(line, lineIndex); err != nil {
+			return err
 		}
+		line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
+	}
+	return nil
+}
 
+//processLine groups multiline records, applies header, exclusion and inclusion rules and queues log record
+func (f *File) processLine(line string, lineIndex int) error {
+	parser, err := f.Parser()
+	if err != nil {
+		return err
+	}
+	rawLine := strings.TrimRight(line, "\r")
+	line = strings.Trim(line, " \r\t")
+	if headerParser, ok := parser.(HeaderParser); ok && !headerParser.HasHeader() && len(line) > 0 {
+		return headerParser.SetHeader(line)
+	}
+	if f.IsMultiline() && f.IsContinuation(rawLine) {
 		if len(line) > 0 {
-			f.PushLogRecord(&Record{
-				URL:    f.URL,
-				Line:   line,
-				Number: lineIndex,
-			})
+			f.appendLogRecordLine(line)
 		}
-		if err != nil {
-			return err
+		return nil
+	}
+	f.flushPendingRecord(0)
+	if f.Exclusion != "" {
+		if strings.Contains(line, f.Exclusion) {
+			return nil
 		}
-		line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
+	}
+	if f.Inclusion != "" {
+		if !strings.Contains(line, f.Inclusion) {
+			return nil
+		}
+	}
+	if len(line) > 0 {
+		record := &Record{
+			URL:       f.URL,
+			Line:      line,
+			Number:    lineIndex,
+			Timestamp: time.Now(),
+		}
+		if f.IsMultiline() {
+			f.holdLogRecord(record)
+			return nil
+		}
+		f.PushLogRecord(record)
 	}
 	return nil
 }
@@ -0,0 +1,314 @@
+package log
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/viant/toolbox"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	//FormatJSON represents JSON log format (default)
+	FormatJSON = "json"
+	//FormatLogfmt represents key=value log format
+	FormatLogfmt = "logfmt"
+	//FormatCSV represents comma separated values log format
+	FormatCSV = "csv"
+	//FormatTSV represents tab separated values log format
+	FormatTSV = "tsv"
+	//FormatApache represents apache/nginx combined (or common) access log format
+	FormatApache = "apache"
+	//FormatNginx represents nginx combined access log format
+	FormatNginx = "nginx"
+	//FormatSyslog represents RFC3164 or RFC5424 syslog format
+	FormatSyslog = "syslog"
+	//FormatRegExpr represents custom log format defined with Type.Pattern named groups
+	FormatRegExpr = "regexp"
+)
+
+//stackTraceKey represents a key for continuation lines of a multiline record
+const stackTraceKey = "stackTrace"
+
+const combinedLogPattern = `^(?P<remoteAddr>\S+) (?P<ident>\S+) (?P<user>\S+) \[(?P<time>[^\]]+)\] "(?P<method>\S+) (?P<uri>\S+) ?(?P<protocol>[^"]*)" (?P<status>\d{3}) (?P<bytes>\S+)(?: "(?P<referer>[^"]*)" "(?P<userAgent>[^"]*)")?`
+const syslog5424Pattern = `^(?:<(?P<priority>\d{1,3})>)1 (?P<timestamp>\S+) (?P<hostname>\S+) (?P<appName>\S+) (?P<procID>\S+) (?P<msgID>\S+) (?P<structuredData>-|(?:\[[^\]]*\])+) ?(?P<message>.*)$`
+const syslog3164Pattern = `^(?:<(?P<priority>\d{1,3})>)?(?P<timestamp>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (?P<hostname>\S+) (?P<appName>[^\[:\s]+)(?:\[(?P<procID>\d+)\])?: ?(?P<message>.*)$`
+
+//Parser represents a log record parser
+type Parser interface {
+	//Parse parses log record line into a map
+	Parse(line string) (map[string]interface{}, error)
+}
+
+//HeaderParser represents a parser that uses the first log file line as a header
+type HeaderParser interface {
+	Parser
+	//HasHeader returns true if header has been already set
+	HasHeader() bool
+	//SetHeader sets header from the supplied line
+	SetHeader(line string) error
+}
+
+//ParserProvider represents a parser provider
+type ParserProvider func(logType *Type) (Parser, error)
+
+var parserProviders = map[string]ParserProvider{}
+var parserProvidersMux = &sync.RWMutex{}
+
+//RegisterParser registers log format parser provider
+func RegisterParser(format string, provider ParserProvider) {
+	parserProvidersMux.Lock()
+	defer parserProvidersMux.Unlock()
+	parserProviders[strings.ToLower(format)] = provider
+}
+
+//NewParser creates a new parser for the log type format, each log file uses a dedicated parser
+func NewParser(logType *Type) (Parser, error) {
+	format := strings.ToLower(logType.Format)
+	if format == "" {
+		format = FormatJSON
+	}
+	parserProvidersMux.RLock()
+	provider, ok := parserProviders[format]
+	parserProvidersMux.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported log format: %v", logType.Format)
+	}
+	return provider(logType)
+}
+
+type jsonParser struct{}
+
+func (p *jsonParser) Parse(line string) (map[string]interface{}, error) {
+	var result = make(map[string]interface{})
+	err := toolbox.NewJSONDecoderFactory().Create(strings.NewReader(line)).Decode(&result)
+	return result, err
+}
+
+type logfmtParser struct{}
+
+//Parse parses key=value pairs, value can be double quoted with backslash escapes, key without value is parsed as true
+func (p *logfmtParser) Parse(line string) (map[string]interface{}, error) {
+	var result = make(map[string]interface{})
+	line, trace := splitMultiline(line)
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		start := i
+		for i < len(runes) && runes[i] != '=' && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		key := string(runes[start:i])
+		if key == "" {
+			if i < len(runes) {
+				return nil, fmt.Errorf("invalid logfmt at %v: %v", i, line)
+			}
+			break
+		}
+		if i >= len(runes) || runes[i] != '=' {
+			result[key] = true
+			continue
+		}
+		i++
+		if i < len(runes) && runes[i] == '"' {
+			i++
+			value := make([]rune, 0)
+			closed := false
+			for ; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						value = append(value, '\n')
+					case 't':
+						value = append(value, '\t')
+					default:
+						value = append(value, runes[i])
+					}
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				value = append(value, runes[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated quoted value for key %v: %v", key, line)
+			}
+			result[key] = string(value)
+			continue
+		}
+		start = i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		result[key] = string(runes[start:i])
+	}
+	putStackTrace(result, trace)
+	return result, nil
+}
+
+type csvParser struct {
+	delimiter rune
+	mux       *sync.Mutex
+	columns   []string
+}
+
+func (p *csvParser) parseLine(line string) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = p.delimiter
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	return reader.Read()
+}
+
+//HasHeader returns true if columns has been set
+func (p *csvParser) HasHeader() bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return len(p.columns) > 0
+}
+
+//SetHeader sets columns from a header line
+func (p *csvParser) SetHeader(line string) error {
+	columns, err := p.parseLine(line)
+	if err != nil {
+		return fmt.Errorf("invalid header: %v, %v", line, err)
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.columns = columns
+	return nil
+}
+
+func (p *csvParser) Parse(line string) (map[string]interface{}, error) {
+	line, trace := splitMultiline(line)
+	values, err := p.parseLine(line)
+	if err != nil {
+		return nil, err
+	}
+	p.mux.Lock()
+	columns := p.columns
+	p.mux.Unlock()
+	var result = make(map[string]interface{})
+	for i, value := range values {
+		if i < len(columns) {
+			result[columns[i]] = value
+			continue
+		}
+		result[fmt.Sprintf("column%d", i+1)] = value
+	}
+	putStackTrace(result, trace)
+	return result, nil
+}
+
+type regExprParser struct {
+	expr *regexp.Regexp
+}
+
+func (p *regExprParser) Parse(line string) (map[string]interface{}, error) {
+	line, trace := splitMultiline(line)
+	matches := p.expr.FindStringSubmatch(line)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("log record does not match %v: %v", p.expr.String(), line)
+	}
+	var result = make(map[string]interface{})
+	for i, name := range p.expr.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		result[name] = matches[i]
+	}
+	putStackTrace(result, trace)
+	return result, nil
+}
+
+type syslogParser struct {
+	rfc5424 *regExprParser
+	rfc3164 *regExprParser
+}
+
+func (p *syslogParser) Parse(line string) (map[string]interface{}, error) {
+	result, err := p.rfc5424.Parse(line)
+	if err != nil {
+		if result, err = p.rfc3164.Parse(line); err != nil {
+			return nil, fmt.Errorf("log record does not match RFC5424 nor RFC3164 syslog format: %v", line)
+		}
+	}
+	if priority, ok := result["priority"]; ok && priority != "" {
+		value := toolbox.AsInt(priority)
+		result["facility"] = value / 8
+		result["severity"] = value % 8
+	}
+	for k, v := range result {
+		if v == "" || v == "-" {
+			delete(result, k)
+		}
+	}
+	return result, nil
+}
+
+//splitMultiline splits a record into the first line and continuation lines
+func splitMultiline(line string) (string, string) {
+	if index := strings.Index(line, "\n"); index != -1 {
+		return line[:index], line[index+1:]
+	}
+	return line, ""
+}
+
+func putStackTrace(record map[string]interface{}, trace string) {
+	if trace != "" {
+		record[stackTraceKey] = trace
+	}
+}
+
+func newRegExprParser(pattern string) (*regExprParser, error) {
+	expr, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %v, %v", pattern, err)
+	}
+	return &regExprParser{expr: expr}, nil
+}
+
+func newCsvParserProvider(delimiter rune) ParserProvider {
+	return func(logType *Type) (Parser, error) {
+		return &csvParser{delimiter: delimiter, mux: &sync.Mutex{}, columns: logType.Columns}, nil
+	}
+}
+
+func init() {
+	RegisterParser(FormatJSON, func(logType *Type) (Parser, error) {
+		return &jsonParser{}, nil
+	})
+	RegisterParser(FormatLogfmt, func(logType *Type) (Parser, error) {
+		return &logfmtParser{}, nil
+	})
+	RegisterParser(FormatCSV, newCsvParserProvider(','))
+	RegisterParser(FormatTSV, newCsvParserProvider('\t'))
+	combinedProvider := func(logType *Type) (Parser, error) {
+		return newRegExprParser(combinedLogPattern)
+	}
+	RegisterParser(FormatApache, combinedProvider)
+	RegisterParser(FormatNginx, combinedProvider)
+	RegisterParser(FormatSyslog, func(logType *Type) (Parser, error) {
+		result := &syslogParser{}
+		var err error
+		if result.rfc5424, err = newRegExprParser(syslog5424Pattern); err != nil {
+			return nil, err
+		}
+		result.rfc3164, err = newRegExprParser(syslog3164Pattern)
+		return result, err
+	})
+	RegisterParser(FormatRegExpr, func(logType *Type) (Parser, error) {
+		if logType.Pattern == "" {
+			return nil, fmt.Errorf("pattern was empty for %v format", FormatRegExpr)
+		}
+		return newRegExprParser(logType.Pattern)
+	})
+}
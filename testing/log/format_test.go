@@ -0,0 +1,159 @@
+package log_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/testing/log"
+	"testing"
+)
+
+func TestNewParser(t *testing.T) {
+	var useCases = []struct {
+		description string
+		logType     *log.Type
+		header      string
+		line        string
+		expect      map[string]interface{}
+		hasError    bool
+	}{
+		{
+			description: "json format",
+			logType:     &log.Type{},
+			line:        `{"level":"INFO","k":1}`,
+			expect:      map[string]interface{}{"level": "INFO", "k": 1},
+		},
+		{
+			description: "logfmt format",
+			logType:     &log.Type{Format: "logfmt"},
+			line:        `time=2019-01-01T10:00:00Z level=ERROR msg="failed to \"connect\"" retry`,
+			expect:      map[string]interface{}{"time": "2019-01-01T10:00:00Z", "level": "ERROR", "msg": `failed to "connect"`, "retry": true},
+		},
+		{
+			description: "logfmt with stack trace",
+			logType:     &log.Type{Format: "logfmt"},
+			line:        "level=ERROR msg=panic\nat main.go:10\nat runtime.go:20",
+			expect:      map[string]interface{}{"level": "ERROR", "msg": "panic", "stackTrace": "at main.go:10\nat runtime.go:20"},
+		},
+		{
+			description: "csv format with header",
+			logType:     &log.Type{Format: "csv"},
+			header:      "id,type,user",
+			line:        `1,event1,"user, 1"`,
+			expect:      map[string]interface{}{"id": "1", "type": "event1", "user": "user, 1"},
+		},
+		{
+			description: "tsv format with columns",
+			logType:     &log.Type{Format: "tsv", Columns: []string{"id", "type"}},
+			line:        "1\tevent1\textra",
+			expect:      map[string]interface{}{"id": "1", "type": "event1", "column3": "extra"},
+		},
+		{
+			description: "apache combined format",
+			logType:     &log.Type{Format: "apache"},
+			line:        `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			expect: map[string]interface{}{
+				"remoteAddr": "127.0.0.1",
+				"ident":      "-",
+				"user":       "frank",
+				"time":       "10/Oct/2000:13:55:36 -0700",
+				"method":     "GET",
+				"uri":        "/apache_pb.gif",
+				"protocol":   "HTTP/1.0",
+				"status":     "200",
+				"bytes":      "2326",
+				"referer":    "http://www.example.com/start.html",
+				"userAgent":  "Mozilla/4.08",
+			},
+		},
+		{
+			description: "syslog RFC3164 format",
+			logType:     &log.Type{Format: "syslog"},
+			line:        `<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed`,
+			expect: map[string]interface{}{
+				"priority":  "34",
+				"facility":  4,
+				"severity":  2,
+				"timestamp": "Oct 11 22:14:15",
+				"hostname":  "mymachine",
+				"appName":   "su",
+				"procID":    "123",
+				"message":   "'su root' failed",
+			},
+		},
+		{
+			description: "syslog RFC5424 format",
+			logType:     &log.Type{Format: "syslog"},
+			line:        `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 - An application event`,
+			expect: map[string]interface{}{
+				"priority":  "165",
+				"facility":  20,
+				"severity":  5,
+				"timestamp": "2003-10-11T22:14:15.003Z",
+				"hostname":  "mymachine.example.com",
+				"appName":   "evntslog",
+				"msgID":     "ID47",
+				"message":   "An application event",
+			},
+		},
+		{
+			description: "regexp format",
+			logType:     &log.Type{Format: "regexp", Pattern: `^(?P<level>\w+) (?P<message>.+)$`},
+			line:        "WARN disk is almost full",
+			expect:      map[string]interface{}{"level": "WARN", "message": "disk is almost full"},
+		},
+		{
+			description: "regexp format - no match",
+			logType:     &log.Type{Format: "regexp", Pattern: `^(?P<level>\d+)$`},
+			line:        "WARN",
+			hasError:    true,
+		},
+		{
+			description: "unsupported format",
+			logType:     &log.Type{Format: "xml"},
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		parser, err := log.NewParser(useCase.logType)
+		if err == nil && useCase.header != "" {
+			headerParser, ok := parser.(log.HeaderParser)
+			if !assert.True(t, ok, useCase.description) {
+				continue
+			}
+			err = headerParser.SetHeader(useCase.header)
+		}
+		var actual map[string]interface{}
+		if err == nil {
+			actual, err = parser.Parse(useCase.line)
+		}
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.Equal(t, len(useCase.expect), len(actual), useCase.description)
+		for k, v := range useCase.expect {
+			assert.EqualValues(t, v, actual[k], useCase.description+" "+k)
+		}
+	}
+}
+
+func TestType_IsContinuation(t *testing.T) {
+	logType := &log.Type{Multiline: true}
+	assert.True(t, logType.IsMultiline())
+	assert.True(t, logType.IsContinuation("\tat com.acme.Main(Main.java:10)"))
+	assert.True(t, logType.IsContinuation("Caused by: java.io.IOException"))
+	assert.False(t, logType.IsContinuation("2019-01-01 ERROR failed"))
+
+	logType = &log.Type{RecordStart: `^\d{4}-\d{2}-\d{2}`}
+	assert.Nil(t, logType.Init())
+	assert.False(t, logType.IsContinuation("2019-01-01 ERROR failed"))
+	assert.True(t, logType.IsContinuation("java.lang.NullPointerException"))
+
+	logType = &log.Type{Name: "app", RecordStart: `^(\d{4}`}
+	assert.NotNil(t, logType.Init())
+	request := &log.ListenRequest{Types: []*log.Type{logType}}
+	assert.NotNil(t, request.Init())
+}
@@ -2,7 +2,9 @@ package log
 
 import (
 	"fmt"
+	"github.com/viant/toolbox"
 	"regexp"
+	"time"
 )
 
 func matchLogIndex(expr *regexp.Regexp, input string) string {
@@ -18,3 +20,11 @@ func matchLogIndex(expr *regexp.Regexp, input string) string {
 func logTypeMetaKey(name string) string {
 	return fmt.Sprintf("meta_%v", name)
 }
+
+//asWindowTime converts time expression (i.e. 5min ago, now) or formatted time to time
+func asWindowTime(value, layout string) (*time.Time, error) {
+	if result, err := toolbox.TimeAt(value); err == nil {
+		return result, nil
+	}
+	return toolbox.ToTime(value, layout)
+}
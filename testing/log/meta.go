@@ -16,28 +16,37 @@ type TypeMeta struct {
 	LogFiles map[string]*File
 }
 
+func (m *TypeMeta) logFiles() []*File {
+	var result = make([]*File, 0)
+	for _, logFile := range m.LogFiles {
+		result = append(result, logFile)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		var left = result[i].LastModified
+		var right = result[j].LastModified
+		if !left.After(right) && !right.After(left) {
+			return result[i].URL > result[j].URL
+		}
+		return left.After(right)
+	})
+	return result
+}
+
 //Iterator returns log record iterator
 func (m *TypeMeta) Iterator() toolbox.Iterator {
-	logFileProvider := func() []*File {
-		var result = make([]*File, 0)
-		for _, logFile := range m.LogFiles {
-			result = append(result, logFile)
-		}
-		sort.Slice(result, func(i, j int) bool {
-			var left = result[i].LastModified
-			var right = result[j].LastModified
-			if !left.After(right) && !right.After(left) {
-				return result[i].URL > result[j].URL
-			}
-			return left.After(right)
-		})
-		return result
+	return &logRecordIterator{
+		logFiles:        m.logFiles(),
+		logFileProvider: m.logFiles,
 	}
+}
 
-	return &logRecordIterator{
-		logFiles:        logFileProvider(),
-		logFileProvider: logFileProvider,
+//PendingRecords returns all pending validation records
+func (m *TypeMeta) PendingRecords() []*Record {
+	var result = make([]*Record, 0)
+	for _, logFile := range m.logFiles() {
+		result = append(result, logFile.PendingRecords()...)
 	}
+	return result
 }
 
 //NewTypeMeta creates a nre log type meta.
@@ -3,13 +3,16 @@ package log
 import (
 	"github.com/viant/toolbox"
 	"strings"
+	"time"
 )
 
 //Record represents a log record
 type Record struct {
-	URL    string
-	Number int
-	Line   string
+	URL       string
+	Number    int
+	Line      string
+	Timestamp time.Time `description:"time the record was detected by listener"`
+	parser    Parser
 }
 
 //IndexedRecord represents indexed log record
@@ -20,6 +23,9 @@ type IndexedRecord struct {
 
 //AsMap returns log records as map
 func (r *Record) AsMap() (map[string]interface{}, error) {
+	if r.parser != nil {
+		return r.parser.Parse(r.Line)
+	}
 	var result = make(map[string]interface{})
 	err := toolbox.NewJSONDecoderFactory().Create(strings.NewReader(r.Line)).Decode(&result)
 	return result, err
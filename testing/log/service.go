@@ -75,6 +75,14 @@ func (s *service) assert(context *endly.Context, request *AssertRequest) (*Asser
 		aMap.Put("logType", expectedLogRecords.Type)
 		aMap.Put("tagID", expectedLogRecords.TagID)
 
+		if len(expectedLogRecords.Absent) > 0 || len(expectedLogRecords.Counts) > 0 {
+			validations, err := s.assertPendingRecords(context, typeMeta, expectedLogRecords, request, aMap.ExpandAsText(request.DescriptionTemplate))
+			if err != nil {
+				return response, err
+			}
+			response.Validations = append(response.Validations, validations...)
+		}
+
 		for _, expectedRecord := range expectedLogRecords.Records {
 			var validation = &assertly.Validation{
 				TagID:       expectedLogRecords.TagID,
@@ -117,6 +125,91 @@ func (s *service) assert(context *endly.Context, request *AssertRequest) (*Asser
 	return response, nil
 }
 
+//assertPendingRecords verifies that absent records do not appear and number of records matching count patterns, pending records are not removed
+func (s *service) assertPendingRecords(context *endly.Context, typeMeta *TypeMeta, expectedLogRecords *TypedRecord, request *AssertRequest, description string) ([]*assertly.Validation, error) {
+	var result = make([]*assertly.Validation, 0)
+	window := expectedLogRecords.Window
+	if window != nil {
+		if err := window.Init(); err != nil {
+			return nil, err
+		}
+	}
+	s.Sleep(context, request.LogWaitTimeMs) //give a listener time to detect recent log records
+
+	for _, absentRecord := range expectedLogRecords.Absent {
+		var validation = &assertly.Validation{
+			TagID:       expectedLogRecords.TagID,
+			Description: description,
+		}
+		result = append(result, validation)
+		matched, err := s.matchPendingRecords(context, typeMeta, window, absentRecord)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			validation.PassedCount++
+			continue
+		}
+		for _, record := range matched {
+			_, filename := toolbox.URLSplit(record.URL)
+			validation.AddFailure(assertly.NewFailure("", fmt.Sprintf("[%v]:%v:%v", expectedLogRecords.TagID, filename, record.Number), "unexpected log record", absentRecord, record.Line))
+		}
+	}
+
+	for _, count := range expectedLogRecords.Counts {
+		var validation = &assertly.Validation{
+			TagID:       expectedLogRecords.TagID,
+			Description: description,
+		}
+		result = append(result, validation)
+		var matched []*Record
+		var err error
+		for j := 0; j <= request.LogWaitRetryCount; j++ {
+			if matched, err = s.matchPendingRecords(context, typeMeta, window, count.Pattern); err != nil {
+				return nil, err
+			}
+			if count.IsSatisfied(len(matched)) || (count.Max != nil && len(matched) > *count.Max) {
+				break
+			}
+			if j < request.LogWaitRetryCount {
+				s.Sleep(context, request.LogWaitTimeMs)
+			}
+		}
+		if count.IsSatisfied(len(matched)) {
+			validation.PassedCount++
+			continue
+		}
+		validation.AddFailure(assertly.NewFailure("", fmt.Sprintf("[%v]", expectedLogRecords.TagID), "log record count", count.Expected(), len(matched), count.Pattern))
+	}
+	return result, nil
+}
+
+//matchPendingRecords returns pending records matching expected record
+func (s *service) matchPendingRecords(context *endly.Context, typeMeta *TypeMeta, window *TimeWindow, expectedRecord interface{}) ([]*Record, error) {
+	var result = make([]*Record, 0)
+	isLogStructured := toolbox.IsMap(expectedRecord)
+	for _, record := range typeMeta.PendingRecords() {
+		if window != nil && !window.Contains(record) {
+			continue
+		}
+		var actualLogRecord interface{} = record.Line
+		if isLogStructured {
+			var err error
+			if actualLogRecord, err = record.AsMap(); err != nil {
+				continue
+			}
+		}
+		validation, err := criteria.Assert(context, "", expectedRecord, actualLogRecord)
+		if err != nil {
+			return nil, err
+		}
+		if !validation.HasFailure() {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
 func (s *service) waitForRecord(context *endly.Context, recordIterator toolbox.Iterator, request *AssertRequest) bool {
 	for j := 0; j < request.LogWaitRetryCount; j++ {
 		if recordIterator.HasNext() {
@@ -233,6 +326,8 @@ func (s *service) readLogFile(context *endly.Context, source *url.Resource, fs a
 	s.Mutex().Unlock()
 
 	if !isNewLogFile && (logFile.Size == int(fileInfo.Size()) && logFile.LastModified.Unix() == fileInfo.ModTime().Unix()) {
+		//file has not changed since last read, held multiline record is complete
+		logFile.flushPendingRecord(0)
 		return result, nil
 	}
 
@@ -354,6 +449,20 @@ func (s *service) listen(context *endly.Context, request *ListenRequest) (*Liste
 		if state.Has(logTypeMetaKey(logType.Name)) {
 			return nil, fmt.Errorf("listener has been already register for %v", logType.Name)
 		}
+		if _, err := NewParser(logType); err != nil {
+			return nil, fmt.Errorf("invalid log type %v: %v", logType.Name, err)
+		}
+	}
+
+	if isStreamSource(source) {
+		logTypeMetas, err := s.listenForStream(context, source, request.IncludeHistory, request.Types...)
+		if err != nil {
+			return nil, err
+		}
+		for _, logType := range request.Types {
+			state.Put(logTypeMetaKey(logType.Name), logTypeMetas[logType.Name])
+		}
+		return &ListenResponse{Meta: logTypeMetas}, nil
 	}
 
 	fs, err := estorage.StorageService(context, source)
@@ -240,6 +240,89 @@ func TestLogValidatorService_TestIndexedRecord(t *testing.T) {
 
 }
 
+var logfmtRecords = `time=2018-01-12T14:07:09Z level=INFO msg="request started" id=1
+time=2018-01-12T14:07:10Z level=WARN msg="slow request" id=1
+time=2018-01-12T14:07:11Z level=INFO msg="request completed" id=1
+time=2018-01-12T14:07:12Z level=ERROR msg="connection refused" id=2
+`
+
+func TestLogValidatorService_AssertAbsentAndCounts(t *testing.T) {
+	manager := endly.New()
+	service, err := manager.Service(log.ServiceID)
+	assert.Nil(t, err)
+	context := manager.NewContext(toolbox.NewContext())
+	defer context.Close()
+	tempLog := path.Join(os.TempDir(), "endly_test_logfmt.log")
+	_ = toolbox.RemoveFileIfExist(tempLog)
+	err = ioutil.WriteFile(tempLog, []byte(logfmtRecords), 0644)
+	assert.Nil(t, err)
+
+	var response = service.Run(context, &log.ListenRequest{
+		Source: url.NewResource(tempLog),
+		Types: []*log.Type{
+			{
+				Name:   "logfmt",
+				Format: "logfmt",
+				Mask:   "endly_test_logfmt.log",
+			},
+		},
+	})
+	assert.EqualValues(t, "", response.Error)
+
+	var one, two, five = 1, 2, 5
+	response = service.Run(context, &log.AssertRequest{
+		LogWaitTimeMs:     100,
+		LogWaitRetryCount: 1,
+		Expect: []*log.TypedRecord{
+			{
+				Type: "logfmt",
+				Absent: []interface{}{
+					map[string]interface{}{"level": "FATAL"},
+					map[string]interface{}{"level": "ERROR"},
+				},
+				Counts: []*log.RecordCount{
+					{Pattern: map[string]interface{}{"level": "INFO"}, Count: &two},
+					{Pattern: "/request/", Min: &one, Max: &two},
+					{Pattern: map[string]interface{}{"id": "1"}, Max: &five},
+				},
+				Window: &log.TimeWindow{
+					TimeField: "time",
+					To:        "2018-01-12T14:07:11Z",
+				},
+			},
+		},
+	})
+	assert.Equal(t, "", response.Error)
+	assertResponse, ok := response.Response.(*log.AssertResponse)
+	if !assert.True(t, ok) {
+		return
+	}
+	if assert.EqualValues(t, 5, len(assertResponse.Validations)) {
+		var expectedFailures = []int{0, 0, 0, 1, 0}
+		for i, expected := range expectedFailures {
+			assert.EqualValues(t, expected, assertResponse.Validations[i].FailedCount, i)
+		}
+	}
+
+	response = service.Run(context, &log.AssertRequest{
+		LogWaitTimeMs:     100,
+		LogWaitRetryCount: 1,
+		Expect: []*log.TypedRecord{
+			{
+				Type: "logfmt",
+				Absent: []interface{}{
+					map[string]interface{}{"level": "ERROR"},
+				},
+			},
+		},
+	})
+	assert.Equal(t, "", response.Error)
+	assertResponse, ok = response.Response.(*log.AssertResponse)
+	if assert.True(t, ok) && assert.EqualValues(t, 1, len(assertResponse.Validations)) {
+		assert.EqualValues(t, 1, assertResponse.Validations[0].FailedCount)
+	}
+}
+
 func Test_TT(t *testing.T) {
 	fmt.Printf(regexp.QuoteMeta("events[v3].csv]"))
 }
@@ -0,0 +1,245 @@
+package log
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/docker"
+	"github.com/viant/endly/system/kubernetes/shared"
+	"github.com/viant/endly/workflow"
+	"github.com/viant/toolbox/url"
+	"io"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//DockerScheme represents docker container log source scheme i.e. docker://myContainer
+	DockerScheme = "docker"
+	//KubernetesScheme represents kubernetes pod log source scheme i.e. k8s://namespace/pod/container
+	KubernetesScheme = "k8s"
+	//streamIdleTime represents time after which held multiline stream record is considered complete
+	streamIdleTime = 400 * time.Millisecond
+)
+
+//streamReader represents a log stream reader closing all underlying streams
+type streamReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+//Close closes underlying streams
+func (r *streamReader) Close() error {
+	var err error
+	for _, closer := range r.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+//streamOpener opens a log stream for supplied source starting from since time (zero time replays whole history), returns stream and log name
+type streamOpener func(context *endly.Context, source *url.Resource, since time.Time) (io.ReadCloser, string, error)
+
+//kubernetesClientset returns kubernetes clientset for supplied context client
+var kubernetesClientset = func(ctxClient *shared.CtxClient) (kubernetes.Interface, error) {
+	return ctxClient.Clientset()
+}
+
+//openPodLogs opens pod log stream
+var openPodLogs = func(pods corev1.PodInterface, name string, options *v1.PodLogOptions) (io.ReadCloser, error) {
+	return pods.GetLogs(name, options).Stream()
+}
+
+var streamOpeners = map[string]streamOpener{
+	DockerScheme:     openDockerLogStream,
+	KubernetesScheme: openKubernetesLogStream,
+}
+
+//isStreamSource returns true if source is a container log stream
+func isStreamSource(source *url.Resource) bool {
+	if source == nil || source.ParsedURL == nil {
+		return false
+	}
+	_, ok := streamOpeners[source.ParsedURL.Scheme]
+	return ok
+}
+
+//openDockerLogStream opens docker://container stdout and stderr stream
+func openDockerLogStream(context *endly.Context, source *url.Resource, since time.Time) (io.ReadCloser, string, error) {
+	name := source.ParsedURL.Host
+	if name == "" {
+		return nil, "", fmt.Errorf("container name was empty: %v", source.URL)
+	}
+	ctxClient, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := ctxClient.Client.ContainerInspect(ctxClient.Context, name)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to inspect container %v, %v", name, err)
+	}
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	}
+	if !since.IsZero() {
+		options.Since = fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond())
+	}
+	reader, err := ctxClient.Client.ContainerLogs(ctxClient.Context, name, options)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open container %v logs, %v", name, err)
+	}
+	if info.Config != nil && info.Config.Tty {
+		return reader, name, nil
+	}
+	//non tty container logs multiplex stdout and stderr
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pipeWriter, pipeWriter, reader)
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	return &streamReader{Reader: pipeReader, closers: []io.Closer{reader, pipeReader}}, name, nil
+}
+
+//openKubernetesLogStream opens k8s://namespace/pod/container log stream, pod can be also a pod name prefix i.e. deployment name
+func openKubernetesLogStream(context *endly.Context, source *url.Resource, since time.Time) (io.ReadCloser, string, error) {
+	namespace := source.ParsedURL.Host
+	fragments := strings.Split(strings.Trim(source.ParsedURL.Path, "/"), "/")
+	if fragments[0] == "" {
+		return nil, "", fmt.Errorf("pod name was empty: %v", source.URL)
+	}
+	podName := fragments[0]
+	container := ""
+	if len(fragments) > 1 {
+		container = fragments[1]
+	}
+	ctxClient, err := shared.GetCtxClient(context)
+	if err != nil {
+		return nil, "", err
+	}
+	if namespace == "" {
+		namespace = ctxClient.Namespace
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	clientset, err := kubernetesClientset(ctxClient)
+	if err != nil {
+		return nil, "", err
+	}
+	pods := clientset.CoreV1().Pods(namespace)
+	if _, err = pods.Get(podName, metav1.GetOptions{}); err != nil {
+		podList, listErr := pods.List(metav1.ListOptions{})
+		if listErr != nil {
+			return nil, "", fmt.Errorf("failed to get pod %v/%v, %v", namespace, podName, err)
+		}
+		matched := ""
+		for _, pod := range podList.Items {
+			if strings.HasPrefix(pod.Name, podName) && pod.Status.Phase == v1.PodRunning {
+				matched = pod.Name
+				break
+			}
+		}
+		if matched == "" {
+			return nil, "", fmt.Errorf("failed to get pod %v/%v, %v", namespace, podName, err)
+		}
+		podName = matched
+	}
+	options := &v1.PodLogOptions{Container: container, Follow: true}
+	if !since.IsZero() {
+		options.SinceTime = &metav1.Time{Time: since}
+	}
+	reader, err := openPodLogs(pods, podName, options)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open pod %v/%v logs, %v", namespace, podName, err)
+	}
+	name := podName
+	if container != "" {
+		name += "/" + container
+	}
+	return reader, name, nil
+}
+
+//listenForStream registers log type metas for a container log stream and queues streamed log records until context is closed
+func (s *service) listenForStream(context *endly.Context, source *url.Resource, includeHistory bool, logTypes ...*Type) (TypesMeta, error) {
+	opener := streamOpeners[source.ParsedURL.Scheme]
+	since := time.Now()
+	if includeHistory {
+		since = time.Time{}
+	}
+	reader, name, err := opener(context, source, since)
+	if err != nil {
+		return nil, err
+	}
+	var response TypesMeta = make(map[string]*TypeMeta)
+	var logFiles = make([]*File, 0)
+	for _, logType := range logTypes {
+		logFile := &File{
+			context:         context,
+			Type:            logType,
+			Name:            name,
+			URL:             source.URL,
+			LastModified:    time.Now(),
+			ProcessingState: &ProcessingState{},
+			Mutex:           &sync.RWMutex{},
+			Records:         make([]*Record, 0),
+			IndexedRecords:  make(map[string]*Record),
+		}
+		logTypeMeta := NewTypeMeta(source, logType)
+		logTypeMeta.LogFiles[name] = logFile
+		response[logType.Name] = logTypeMeta
+		logFiles = append(logFiles, logFile)
+	}
+
+	go func() {
+		for !context.IsClosed() {
+			time.Sleep(streamIdleTime)
+			for _, logFile := range logFiles {
+				logFile.flushPendingRecord(streamIdleTime)
+			}
+		}
+		_ = reader.Close()
+	}()
+	go func() {
+		defer reader.Close()
+		lineReader := bufio.NewReader(reader)
+		lineIndex := 0
+		for {
+			line, err := lineReader.ReadString('\n')
+			if len(line) > 0 {
+				lineIndex++
+				line = strings.TrimRight(line, "\n")
+				for _, logFile := range logFiles {
+					if processErr := logFile.processLine(line, lineIndex); processErr != nil {
+						printStreamError(context, fmt.Sprintf("failed to process %v log record: %v", logFile.Type.Name, processErr))
+					}
+				}
+			}
+			if err != nil {
+				for _, logFile := range logFiles {
+					logFile.flushPendingRecord(0)
+				}
+				if err != io.EOF && !context.IsClosed() {
+					printStreamError(context, fmt.Sprintf("failed to read log stream %v: %v", source.URL, err))
+				}
+				return
+			}
+		}
+	}()
+	return response, nil
+}
+
+//printStreamError reports stream error without failing workflow, stream is read in the background
+func printStreamError(context *endly.Context, message string) {
+	_ = endly.Run(context, &workflow.PrintRequest{Error: message}, nil)
+}
@@ -0,0 +1,198 @@
+package log
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/system/kubernetes/shared"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io"
+	"io/ioutil"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func dockerLogFrame(stream byte, payload string) []byte {
+	var result = make([]byte, 8)
+	result[0] = stream
+	binary.BigEndian.PutUint32(result[4:], uint32(len(payload)))
+	return append(result, []byte(payload)...)
+}
+
+func waitForRecords(file *File, count int) []*Record {
+	for i := 0; i < 50; i++ {
+		file.Mutex.RLock()
+		records := file.Records
+		file.Mutex.RUnlock()
+		if len(records) >= count {
+			return records
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return file.Records
+}
+
+func TestService_ListenDockerStream(t *testing.T) {
+	var queries = make([]string, 0)
+	var mux sync.Mutex
+	daemon := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		URI := strings.TrimPrefix(request.URL.Path, "/v1.37")
+		switch URI {
+		case "/containers/app/json":
+			_ = json.NewEncoder(writer).Encode(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: "c1"}, Config: &container.Config{}})
+		case "/containers/app/logs":
+			mux.Lock()
+			queries = append(queries, request.URL.RawQuery)
+			mux.Unlock()
+			_, _ = writer.Write(dockerLogFrame(1, `{"level":"info","message":"started"}`+"\n"))
+			_, _ = writer.Write(dockerLogFrame(2, `{"level":"error","message":"failed"}`+"\n"))
+		default:
+			http.Error(writer, `{"message":"not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer daemon.Close()
+	dockerHost := os.Getenv("DOCKER_HOST")
+	_ = os.Setenv("DOCKER_HOST", strings.Replace(daemon.URL, "http://", "tcp://", 1))
+	defer os.Setenv("DOCKER_HOST", dockerHost)
+
+	for i, includeHistory := range []bool{false, true} {
+		context := endly.New().NewContext(nil)
+		started := time.Now()
+		response := &ListenResponse{}
+		err := endly.Run(context, &ListenRequest{
+			Source:         url.NewResource("docker://app"),
+			Types:          []*Type{{Name: "app"}},
+			IncludeHistory: includeHistory,
+		}, response)
+		if !assert.Nil(t, err) {
+			return
+		}
+		records := waitForRecords(response.Meta["app"].LogFiles["app"], 2)
+		if assert.Equal(t, 2, len(records)) {
+			assert.Equal(t, `{"level":"info","message":"started"}`, records[0].Line)
+			assert.Equal(t, `{"level":"error","message":"failed"}`, records[1].Line)
+		}
+		mux.Lock()
+		query := queries[i]
+		mux.Unlock()
+		assert.True(t, strings.Contains(query, "follow=1"), query)
+		if includeHistory {
+			assert.False(t, strings.Contains(query, "since="), query)
+		} else if assert.True(t, strings.Contains(query, "since="), query) {
+			since := query[strings.Index(query, "since=")+6:]
+			if index := strings.Index(since, "&"); index != -1 {
+				since = since[:index]
+			}
+			delta := toolbox.AsFloat(since) - float64(started.UnixNano())/1e9
+			assert.True(t, delta > -1 && delta < 5, since)
+		}
+		context.Close()
+	}
+}
+
+func TestService_ListenKubernetesStream(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-5d8f", Namespace: "test"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	})
+	var logOptions *v1.PodLogOptions
+	var podName string
+	defaultClientset, defaultOpenPodLogs := kubernetesClientset, openPodLogs
+	defer func() {
+		kubernetesClientset, openPodLogs = defaultClientset, defaultOpenPodLogs
+	}()
+	kubernetesClientset = func(ctxClient *shared.CtxClient) (kubernetes.Interface, error) {
+		return clientset, nil
+	}
+	openPodLogs = func(pods corev1.PodInterface, name string, options *v1.PodLogOptions) (io.ReadCloser, error) {
+		podName, logOptions = name, options
+		return ioutil.NopCloser(strings.NewReader("level=info message=started\nlevel=info message=ready\n")), nil
+	}
+
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	started := time.Now()
+	response := &ListenResponse{}
+	err := endly.Run(context, &ListenRequest{
+		Source: url.NewResource("k8s://test/app/main"),
+		Types:  []*Type{{Name: "app", Format: "logfmt"}},
+	}, response)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "app-5d8f", podName)
+	if assert.NotNil(t, logOptions) {
+		assert.Equal(t, "main", logOptions.Container)
+		assert.True(t, logOptions.Follow)
+		if assert.NotNil(t, logOptions.SinceTime) {
+			assert.True(t, logOptions.SinceTime.Sub(started) >= 0)
+		}
+	}
+	records := waitForRecords(response.Meta["app"].LogFiles["app-5d8f/main"], 2)
+	if assert.Equal(t, 2, len(records)) {
+		assert.Equal(t, "level=info message=ready", records[1].Line)
+	}
+}
+
+func TestFile_ProcessLine(t *testing.T) {
+	newFile := func(logType *Type) *File {
+		assert.Nil(t, logType.Init())
+		return &File{Type: logType, Mutex: &sync.RWMutex{}, IndexedRecords: make(map[string]*Record)}
+	}
+	{ //header, multiline record start and exclusion
+		file := newFile(&Type{Name: "csv", Format: "csv", RecordStart: `^\d{4}-`, Exclusion: "DEBUG"})
+		lines := []string{
+			"time,level,message",
+			"2024-01-01,INFO,started",
+			"2024-01-01,DEBUG,noise",
+			"  debug details",
+			"2024-01-01,ERROR,failed",
+			"java.lang.Exception: io",
+			"\tat Main.main",
+		}
+		for i, line := range lines {
+			assert.Nil(t, file.processLine(line, i+1))
+		}
+		assert.Equal(t, 1, len(file.PendingRecords()), "multiline record is held till it is complete")
+		assert.Nil(t, file.processLine("\tat Main.run", len(lines)+1))
+		file.flushPendingRecord(time.Hour)
+		assert.Equal(t, 1, len(file.PendingRecords()), "multiline record is held till it is idle")
+		file.flushPendingRecord(0)
+		if assert.Equal(t, 2, len(file.Records)) {
+			assert.Equal(t, "2024-01-01,INFO,started", file.Records[0].Line)
+			assert.Equal(t, 2, file.Records[0].Number)
+			assert.Equal(t, "2024-01-01,ERROR,failed\njava.lang.Exception: io\nat Main.main\nat Main.run", file.Records[1].Line)
+			assert.Equal(t, 5, file.Records[1].Number)
+		}
+	}
+	{ //inclusion and default multiline continuation
+		file := newFile(&Type{Name: "json", Multiline: true, Inclusion: "order"})
+		lines := []string{
+			`{"message":"order created"}`,
+			"  at OrderService.create",
+			`{"message":"user created"}`,
+			"  at UserService.create",
+			"",
+		}
+		for i, line := range lines {
+			assert.Nil(t, file.processLine(line, i+1))
+		}
+		if assert.Equal(t, 1, len(file.Records)) {
+			assert.Equal(t, "{\"message\":\"order created\"}\nat OrderService.create", file.Records[0].Line)
+		}
+	}
+}
@@ -57,7 +57,7 @@ func NewPubSubClient(context *endly.Context, dest *Resource, timeout time.Durati
 	case ResourceVendorAmazonWebService:
 		return newAwsSqsClient(credConfig, timeout)
 	case ResourceVendorKafka:
-		return newKafkaClient(timeout)
+		return newKafkaClient(context, timeout)
 	}
 	return nil, fmt.Errorf("unsupported vendor: '%v'", dest.Vendor)
 
@@ -74,7 +74,11 @@ func expandResource(context *endly.Context, resource *Resource) *Resource {
 		Partitions:        resource.Partitions,
 		Partition:         resource.Partition,
 		Offset:            resource.Offset,
+		OffsetAt:          state.ExpandAsText(resource.OffsetAt),
+		StartOffset:       resource.StartOffset,
+		GroupID:           state.ExpandAsText(resource.GroupID),
 		ReplicationFactor: resource.ReplicationFactor,
+		SchemaRegistry:    resource.SchemaRegistry,
 	}
 }
 
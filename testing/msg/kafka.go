@@ -2,79 +2,236 @@ package msg
 
 import (
 	"context"
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/segmentio/kafka-go"
+	"github.com/viant/endly"
 	"github.com/viant/toolbox"
+	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const keyAttribute = "key"
 const idAttribute = "id"
 
+const (
+	//KafkaStartOffsetFirst reads from the first available offset
+	KafkaStartOffsetFirst = "first"
+	//KafkaStartOffsetLast reads from the last offset at the time of a pull
+	KafkaStartOffsetLast = "last"
+	//KafkaStartOffsetSession reads from the latest offset captured when the topic was first used in the session
+	KafkaStartOffsetSession = "session"
+)
+
+const kafkaCleanupPolicy = "cleanup.policy"
+
+//kafkaOffsets represents topic/partition offsets captured when the topic was first used in the session
+type kafkaOffsets struct {
+	mux     *sync.Mutex
+	offsets map[string]int64
+}
+
+var kafkaOffsetsKey = (*kafkaOffsets)(nil)
+
+func sessionOffsetKey(topic string, partition int) string {
+	return fmt.Sprintf("%v/%v", topic, partition)
+}
+
 type kafkaClient struct {
-	timeout time.Duration
+	context  *endly.Context
+	timeout  time.Duration
+	registry *schemaRegistryClient
+}
+
+func (k *kafkaClient) schemaRegistry(resource *Resource) *schemaRegistryClient {
+	if resource.SchemaRegistry == nil || resource.SchemaRegistry.URL == "" {
+		return nil
+	}
+	if k.registry == nil {
+		k.registry = newSchemaRegistryClient(resource.SchemaRegistry.URL, k.timeout)
+	}
+	return k.registry
+}
+
+func (k *kafkaClient) sessionOffsets() *kafkaOffsets {
+	var result *kafkaOffsets
+	if k.context == nil {
+		return &kafkaOffsets{mux: &sync.Mutex{}, offsets: make(map[string]int64)}
+	}
+	if !k.context.GetInto(kafkaOffsetsKey, &result) {
+		result = &kafkaOffsets{mux: &sync.Mutex{}, offsets: make(map[string]int64)}
+		_ = k.context.Put(kafkaOffsetsKey, result)
+	}
+	return result
+}
+
+//markOffset captures the latest topic partition offset the first time the topic is used in the session
+func (k *kafkaClient) markOffset(ctx context.Context, resource *Resource) (int64, error) {
+	sessionOffsets := k.sessionOffsets()
+	sessionOffsets.mux.Lock()
+	defer sessionOffsets.mux.Unlock()
+	key := sessionOffsetKey(resource.Name, resource.Partition)
+	if offset, ok := sessionOffsets.offsets[key]; ok {
+		return offset, nil
+	}
+	if err := validateBrokers(resource); err != nil {
+		return 0, err
+	}
+	conn, err := kafka.DialLeader(ctx, "tcp", resource.Brokers[0], resource.Name, resource.Partition)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to connect to %v", resource.Brokers[0])
+	}
+	defer conn.Close()
+	offset, err := conn.ReadLastOffset()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read last offset: %v", key)
+	}
+	sessionOffsets.offsets[key] = offset
+	return offset, nil
 }
 
 func (k *kafkaClient) Push(ctx context.Context, dest *Resource, message *Message) (Result, error) {
+	if err := validateBrokers(dest); err != nil {
+		return nil, err
+	}
+	if dest.StartOffset == KafkaStartOffsetSession {
+		if _, err := k.markOffset(ctx, dest); err != nil {
+			return nil, err
+		}
+	}
 	config := kafka.WriterConfig{
 		Brokers:  dest.Brokers,
 		Topic:    dest.Name,
 		Balancer: &kafka.LeastBytes{},
 	}
-	body := toolbox.AsString(message.Data)
+	var body []byte
+	if registry := k.schemaRegistry(dest); registry != nil {
+		var err error
+		if body, err = registry.Encode(dest.SchemaRegistry, message.Data); err != nil {
+			return nil, err
+		}
+	} else {
+		body = []byte(toolbox.AsString(message.Data))
+	}
 	writer := kafka.NewWriter(config)
+	defer writer.Close()
 	key := ""
-
+	var headers = make([]kafka.Header, 0)
 	for k := range message.Attributes {
 		candidate := strings.ToLower(k)
 		if candidate == keyAttribute || candidate == idAttribute {
 			key = toolbox.AsString(message.Attributes[k])
+			continue
 		}
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(toolbox.AsString(message.Attributes[k]))})
 	}
 	messages := make([]kafka.Message, 0)
 	messages = append(messages, kafka.Message{
 		Partition: dest.Partition,
 		Key:       []byte(key),
-		Value:     []byte(body),
+		Value:     body,
+		Headers:   headers,
 	})
 	err := writer.WriteMessages(ctx, messages...)
 	if err != nil {
 		return nil, err
 	}
-	_ = writer.Close()
 	return key, nil
 }
 
-func (k *kafkaClient) PullN(ctx context.Context, source *Resource, count int, nack bool) ([]*Message, error) {
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:   source.Brokers,
-		Topic:     source.Name,
-		Partition: source.Partition,
-		MinBytes:  10e3, // 10KB
-		MaxBytes:  10e6, // 10MB
-		MaxWait:   k.timeout,
-	})
-	if source.Offset > 0 {
-		if err := reader.SetOffset(int64(source.Offset)); err != nil {
-			return nil, errors.Wrapf(err, "failed to set offset: %v", source.Offset)
+func (k *kafkaClient) newReader(ctx context.Context, source *Resource) (*kafka.Reader, error) {
+	if err := validateBrokers(source); err != nil {
+		return nil, err
+	}
+	config := kafka.ReaderConfig{
+		Brokers:  source.Brokers,
+		Topic:    source.Name,
+		MinBytes: 10e3, // 10KB
+		MaxBytes: 10e6, // 10MB
+		MaxWait:  k.timeout,
+	}
+	if source.GroupID != "" {
+		config.GroupID = source.GroupID
+		switch source.StartOffset {
+		case KafkaStartOffsetLast:
+			config.StartOffset = kafka.LastOffset
+		case KafkaStartOffsetSession:
+			return nil, fmt.Errorf("%v start offset is not supported with consumer group: %v", source.StartOffset, source.GroupID)
+		default:
+			config.StartOffset = kafka.FirstOffset
+		}
+		return kafka.NewReader(config), nil
+	}
+	config.Partition = source.Partition
+	reader := kafka.NewReader(config)
+	var err error
+	switch {
+	case source.Offset > 0:
+		if err = reader.SetOffset(int64(source.Offset)); err != nil {
+			err = errors.Wrapf(err, "failed to set offset: %v", source.Offset)
+		}
+	case source.OffsetAt != "":
+		var offsetAt *time.Time
+		if offsetAt, err = toolbox.ToTime(source.OffsetAt, time.RFC3339); err != nil {
+			err = errors.Wrapf(err, "invalid offsetAt: %v", source.OffsetAt)
+			break
+		}
+		if err = reader.SetOffsetAt(ctx, *offsetAt); err != nil {
+			err = errors.Wrapf(err, "failed to set offset at: %v", source.OffsetAt)
 		}
+	case source.StartOffset == KafkaStartOffsetLast:
+		err = reader.SetOffset(kafka.LastOffset)
+	case source.StartOffset == KafkaStartOffsetSession:
+		var offset int64
+		if offset, err = k.markOffset(ctx, source); err == nil {
+			err = reader.SetOffset(offset)
+		}
+	default:
+		err = reader.SetOffset(kafka.FirstOffset)
+	}
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+	return reader, nil
+}
+
+func (k *kafkaClient) PullN(ctx context.Context, source *Resource, count int, nack bool) ([]*Message, error) {
+	reader, err := k.newReader(ctx, source)
+	if err != nil {
+		return nil, err
 	}
+	defer reader.Close()
+	registry := k.schemaRegistry(source)
 	var result = make([]*Message, 0)
 	for i := 0; i < count; i++ {
-		message, err := reader.ReadMessage(ctx)
+		readCtx, cancel := context.WithTimeout(ctx, k.timeout)
+		message, err := reader.FetchMessage(readCtx)
+		cancel()
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "failed to read %v message from %v, received: %v", i+1, source.Name, len(result))
 		}
 		msg := &Message{
+			ID:         strconv.FormatInt(message.Offset, 10),
 			Data:       message.Value,
 			Attributes: map[string]interface{}{},
 		}
+		if registry != nil {
+			if msg.Data, err = registry.Decode(message.Value); err != nil {
+				return nil, err
+			}
+		}
 		if len(message.Key) > 0 {
 			msg.Attributes[keyAttribute] = string(message.Key)
 		}
+		for _, header := range message.Headers {
+			msg.Attributes[header.Key] = string(header.Value)
+		}
 		result = append(result, msg)
-		if !nack {
+		if !nack && source.GroupID != "" {
 			if err = reader.CommitMessages(ctx, message); err != nil {
 				return nil, errors.Wrapf(err, "failed to commit message: %v", msg)
 			}
@@ -83,11 +240,42 @@ func (k *kafkaClient) PullN(ctx context.Context, source *Resource, count int, na
 	return result, nil
 }
 
+//controller returns connection to the cluster controller, topic admin requests have to be sent to it
+//validateBrokers checks if resource has brokers, kafka writer and reader panic otherwise
+func validateBrokers(resource *Resource) error {
+	if len(resource.Brokers) == 0 {
+		return fmt.Errorf("brokers were empty: %v", resource.Name)
+	}
+	return nil
+}
+
+func (k *kafkaClient) controller(brokers []string) (*kafka.Conn, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("brokers were empty")
+	}
+	conn, err := kafka.Dial("tcp", brokers[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %v", brokers[0])
+	}
+	defer conn.Close()
+	controller, err := conn.Controller()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get controller from %v", brokers[0])
+	}
+	address := net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port))
+	controllerConn, err := kafka.Dial("tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to controller %v", address)
+	}
+	return controllerConn, nil
+}
+
 func (k *kafkaClient) SetupResource(resource *ResourceSetup) (*Resource, error) {
-	conn, err := kafka.DialLeader(context.Background(), "tcp", resource.Brokers[0], resource.Name, resource.Partition)
+	conn, err := k.controller(resource.Brokers)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to connect to %v", resource.Brokers[0])
+		return nil, err
 	}
+	defer conn.Close()
 	if resource.Recreate {
 		_ = conn.DeleteTopics(resource.Name)
 	}
@@ -95,15 +283,43 @@ func (k *kafkaClient) SetupResource(resource *ResourceSetup) (*Resource, error)
 		Topic:             resource.Name,
 		ReplicationFactor: resource.ReplicationFactor,
 		NumPartitions:     resource.Partitions,
+		ConfigEntries:     make([]kafka.ConfigEntry, 0),
+	}
+	if topicConfig.ReplicationFactor == 0 {
+		topicConfig.ReplicationFactor = 1
+	}
+	if topicConfig.NumPartitions == 0 {
+		topicConfig.NumPartitions = 1
+	}
+	if resource.Compacted {
+		if _, has := resource.TopicConfig[kafkaCleanupPolicy]; !has {
+			topicConfig.ConfigEntries = append(topicConfig.ConfigEntries, kafka.ConfigEntry{ConfigName: kafkaCleanupPolicy, ConfigValue: "compact"})
+		}
+	}
+	for name, value := range resource.TopicConfig {
+		topicConfig.ConfigEntries = append(topicConfig.ConfigEntries, kafka.ConfigEntry{ConfigName: name, ConfigValue: value})
+	}
+	if err = conn.CreateTopics(topicConfig); err != nil {
+		return nil, errors.Wrapf(err, "failed to create topic: %v", resource.Name)
+	}
+	if resource.StartOffset == KafkaStartOffsetSession {
+		//topic was created in this session, thus all its messages are produced after the session start
+		sessionOffsets := k.sessionOffsets()
+		sessionOffsets.mux.Lock()
+		for i := 0; i < topicConfig.NumPartitions; i++ {
+			sessionOffsets.offsets[sessionOffsetKey(resource.Name, i)] = 0
+		}
+		sessionOffsets.mux.Unlock()
 	}
-	return &resource.Resource, conn.CreateTopics(topicConfig)
+	return &resource.Resource, nil
 }
 
 func (k *kafkaClient) DeleteResource(resource *Resource) error {
-	conn, err := kafka.DialLeader(context.Background(), "tcp", resource.Brokers[0], resource.Name, resource.Partition)
+	conn, err := k.controller(resource.Brokers)
 	if err != nil {
-		return errors.Wrapf(err, "failed to connect to %v", resource.Brokers[0])
+		return err
 	}
+	defer conn.Close()
 	return conn.DeleteTopics(resource.Name)
 }
 
@@ -111,6 +327,6 @@ func (k *kafkaClient) Close() error {
 	return nil
 }
 
-func newKafkaClient(timeout time.Duration) (Client, error) {
-	return &kafkaClient{timeout: timeout}, nil
+func newKafkaClient(context *endly.Context, timeout time.Duration) (Client, error) {
+	return &kafkaClient{context: context, timeout: timeout}, nil
 }
@@ -0,0 +1,17 @@
+package msg
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestKafkaClient_EmptyBrokers(t *testing.T) {
+	client := &kafkaClient{timeout: time.Second}
+	resource := &Resource{Name: "users", StartOffset: KafkaStartOffsetSession}
+	_, err := client.Push(context.Background(), resource, &Message{Data: "abc"})
+	assert.NotNil(t, err)
+	_, err = client.markOffset(context.Background(), resource)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,209 @@
+package msg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/linkedin/goavro"
+	"github.com/pkg/errors"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	schemaRegistryMagicByte    = 0
+	schemaRegistryHeaderSize   = 5
+	schemaRegistryContentType  = "application/vnd.schemaregistry.v1+json"
+	schemaRegistrySubjectValue = "-value"
+)
+
+//SchemaRegistry represents confluent schema registry avro encoding settings
+type SchemaRegistry struct {
+	URL      string `description:"schema registry URL i.e. http://localhost:8081"`
+	Subject  string `description:"schema subject, default: <topic>-value"`
+	Schema   string `description:"avro schema or schema URL, registered under the subject on push"`
+	SchemaID int    `description:"schema ID used to encode pushed messages, takes precedence over schema and subject latest version"`
+}
+
+//Init initializes schema registry settings
+func (r *SchemaRegistry) Init(topic string) {
+	r.URL = strings.TrimRight(r.URL, "/")
+	if r.Subject == "" && topic != "" {
+		r.Subject = topic + schemaRegistrySubjectValue
+	}
+}
+
+type schemaResponse struct {
+	ID     int    `json:"id,omitempty"`
+	Schema string `json:"schema,omitempty"`
+}
+
+type schemaRegistryClient struct {
+	URL        string
+	httpClient *http.Client
+	mux        *sync.Mutex
+	codecs     map[int]*goavro.Codec
+	schemaIDs  map[string]int
+}
+
+func (c *schemaRegistryClient) call(method, URI string, request interface{}, response interface{}) error {
+	var body *bytes.Buffer
+	if request != nil {
+		payload, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(payload)
+	} else {
+		body = new(bytes.Buffer)
+	}
+	httpRequest, err := http.NewRequest(method, c.URL+URI, body)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", schemaRegistryContentType)
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call schema registry: %v", c.URL+URI)
+	}
+	defer httpResponse.Body.Close()
+	data, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode/100 != 2 {
+		return fmt.Errorf("schema registry %v %v failed: %v, %s", method, URI, httpResponse.Status, data)
+	}
+	return json.Unmarshal(data, response)
+}
+
+//codec returns a codec for supplied schema ID
+func (c *schemaRegistryClient) codec(ID int) (*goavro.Codec, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if codec, ok := c.codecs[ID]; ok {
+		return codec, nil
+	}
+	response := &schemaResponse{}
+	if err := c.call(http.MethodGet, fmt.Sprintf("/schemas/ids/%d", ID), nil, response); err != nil {
+		return nil, err
+	}
+	codec, err := goavro.NewCodec(response.Schema)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schema: %v", ID)
+	}
+	c.codecs[ID] = codec
+	return codec, nil
+}
+
+//register registers schema under the subject, returns schema ID
+func (c *schemaRegistryClient) register(subject, schema string) (int, error) {
+	response := &schemaResponse{}
+	err := c.call(http.MethodPost, fmt.Sprintf("/subjects/%v/versions", subject), &schemaResponse{Schema: schema}, response)
+	return response.ID, err
+}
+
+//latest returns the latest schema ID for the subject
+func (c *schemaRegistryClient) latest(subject string) (int, error) {
+	response := &schemaResponse{}
+	err := c.call(http.MethodGet, fmt.Sprintf("/subjects/%v/versions/latest", subject), nil, response)
+	return response.ID, err
+}
+
+//schemaID returns schema ID used to encode message for supplied settings, registered schema IDs are cached by subject and schema
+func (c *schemaRegistryClient) schemaID(registry *SchemaRegistry) (int, error) {
+	if registry.SchemaID > 0 {
+		return registry.SchemaID, nil
+	}
+	if registry.Schema == "" {
+		return c.latest(registry.Subject)
+	}
+	key := registry.Subject + ":" + registry.Schema
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if ID, ok := c.schemaIDs[key]; ok {
+		return ID, nil
+	}
+	schema, err := loadSchema(registry.Schema)
+	if err != nil {
+		return 0, err
+	}
+	ID, err := c.register(registry.Subject, schema)
+	if err != nil {
+		return 0, err
+	}
+	c.schemaIDs[key] = ID
+	return ID, nil
+}
+
+//Encode encodes data with confluent wire format: magic byte, 4 bytes schema ID, avro binary payload
+func (c *schemaRegistryClient) Encode(registry *SchemaRegistry, data interface{}) ([]byte, error) {
+	ID, err := c.schemaID(registry)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get schema ID for subject: %v", registry.Subject)
+	}
+	codec, err := c.codec(ID)
+	if err != nil {
+		return nil, err
+	}
+	var native interface{}
+	switch value := data.(type) {
+	case []byte, string:
+		if native, _, err = codec.NativeFromTextual([]byte(toolbox.AsString(value))); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s to avro", value)
+		}
+	default:
+		textual, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		if native, _, err = codec.NativeFromTextual(textual); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s to avro", textual)
+		}
+	}
+	var header = make([]byte, schemaRegistryHeaderSize)
+	header[0] = schemaRegistryMagicByte
+	binary.BigEndian.PutUint32(header[1:], uint32(ID))
+	return codec.BinaryFromNative(header, native)
+}
+
+//Decode decodes confluent wire format payload, non registry payload is returned as is
+func (c *schemaRegistryClient) Decode(payload []byte) (interface{}, error) {
+	if len(payload) < schemaRegistryHeaderSize || payload[0] != schemaRegistryMagicByte {
+		return payload, nil
+	}
+	ID := int(binary.BigEndian.Uint32(payload[1:schemaRegistryHeaderSize]))
+	codec, err := c.codec(ID)
+	if err != nil {
+		return nil, err
+	}
+	native, _, err := codec.NativeFromBinary(payload[schemaRegistryHeaderSize:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode avro payload with schema: %v", ID)
+	}
+	return native, nil
+}
+
+func loadSchema(schema string) (string, error) {
+	schema = strings.TrimSpace(schema)
+	if strings.HasPrefix(schema, "{") || strings.HasPrefix(schema, "[") || strings.HasPrefix(schema, `"`) {
+		return schema, nil
+	}
+	return url.NewResource(schema).DownloadText()
+}
+
+func newSchemaRegistryClient(URL string, timeout time.Duration) *schemaRegistryClient {
+	return &schemaRegistryClient{
+		URL:        strings.TrimRight(URL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+		mux:        &sync.Mutex{},
+		codecs:     make(map[int]*goavro.Codec),
+		schemaIDs:  make(map[string]int),
+	}
+}
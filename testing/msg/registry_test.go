@@ -0,0 +1,70 @@
+package msg
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSchemaRegistryClient_EncodeDecode(t *testing.T) {
+	schema := `{"type":"record","name":"User","fields":[{"name":"id","type":"long"},{"name":"name","type":"string"}]}`
+	nextSchema := `{"type":"record","name":"User","fields":[{"name":"id","type":"long"}]}`
+	registered := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/subjects/users-value/versions":
+			registered++
+			body := &schemaResponse{}
+			_ = json.NewDecoder(request.Body).Decode(body)
+			if body.Schema == nextSchema {
+				_, _ = writer.Write([]byte(`{"id":8}`))
+				return
+			}
+			_, _ = writer.Write([]byte(`{"id":7}`))
+		case "/schemas/ids/7":
+			payload, _ := json.Marshal(&schemaResponse{Schema: schema})
+			_, _ = writer.Write(payload)
+		case "/schemas/ids/8":
+			payload, _ := json.Marshal(&schemaResponse{Schema: nextSchema})
+			_, _ = writer.Write(payload)
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registry := &SchemaRegistry{URL: server.URL, Schema: schema}
+	registry.Init("users")
+	assert.Equal(t, "users-value", registry.Subject)
+
+	client := newSchemaRegistryClient(registry.URL, time.Duration(defaultTimeoutMs)*time.Millisecond)
+	encoded, err := client.Encode(registry, `{"id":3, "name":"abc"}`)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []byte{0, 0, 0, 0, 7}, encoded[:schemaRegistryHeaderSize])
+	assert.Equal(t, 0, registry.SchemaID, "settings are not modified")
+	_, err = client.Encode(registry, `{"id":4, "name":"xyz"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, registered, "schema ID is cached")
+
+	registry.Schema = nextSchema
+	next, err := client.Encode(registry, `{"id":5}`)
+	if assert.Nil(t, err) {
+		assert.Equal(t, []byte{0, 0, 0, 0, 8}, next[:schemaRegistryHeaderSize])
+	}
+	assert.Equal(t, 2, registered)
+
+	decoded, err := client.Decode(encoded)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, map[string]interface{}{"id": int64(3), "name": "abc"}, decoded)
+
+	plain, err := client.Decode([]byte("plain text"))
+	assert.Nil(t, err)
+	assert.EqualValues(t, []byte("plain text"), plain)
+}
@@ -19,7 +19,9 @@ type Resource struct {
 	Brokers           []string
 	Credentials       string
 	Offset            int
-	GroupID           string
+	OffsetAt          string `description:"kafka: seek to the first offset at or after RFC3339 timestamp"`
+	StartOffset       string `description:"kafka: first, last or session - latest offset captured when the topic was first used in the session"`
+	GroupID           string `description:"kafka: consumer group ID, when specified offsets are committed by the group"`
 	Partition         int
 	ReplicationFactor int
 	Partitions        int
@@ -27,7 +29,8 @@ type Resource struct {
 	Name              string
 	Type              string `description:"resource type: topic, subscription"`
 	Vendor            string
-	Config            interface{} `description:"vendor client config"`
+	Config            interface{}     `description:"vendor client config"`
+	SchemaRegistry    *SchemaRegistry `description:"kafka: confluent schema registry used to encode pushed and decode pulled avro messages"`
 	projectID         string
 }
 
@@ -58,6 +61,9 @@ func (r *Resource) Init() error {
 			}
 		}
 	}
+	if r.SchemaRegistry != nil {
+		r.SchemaRegistry.Init(r.Name)
+	}
 	return nil
 }
 
@@ -73,8 +79,10 @@ func NewResource(resourceType, URL, credentials string) *Resource {
 //Resource represents resource setup
 type ResourceSetup struct {
 	Resource
-	Recreate bool
-	Config   *Config
+	Recreate    bool
+	Config      *Config
+	Compacted   bool              `description:"kafka: creates compacted topic, shortcut for cleanup.policy: compact"`
+	TopicConfig map[string]string `description:"kafka: topic level config entries i.e. retention.ms, min.compaction.lag.ms"`
 }
 
 //Init initializes setup resource
@@ -104,7 +112,7 @@ func (r *ResourceSetup) Validate() error {
 		}
 	}
 
-	if r.Type == ResourceVendorKafka {
+	if r.Vendor == ResourceVendorKafka {
 		if len(r.Brokers) == 0 {
 			return fmt.Errorf("brokers where empty")
 		}
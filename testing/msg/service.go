@@ -13,6 +13,8 @@ import (
 const (
 	//ServiceID represents gloud msg  pubsub service id.
 	ServiceID = "msg"
+
+	ledgerResource = "msg/resource"
 )
 
 //service represent SMTP service
@@ -191,10 +193,28 @@ func (s *service) create(context *endly.Context, request *CreateRequest) (interf
 			return nil, err
 		}
 		response.Resources = append(response.Resources, responseResource)
+		if err = registerResource(context, &resource.Resource); err != nil {
+			return nil, err
+		}
 	}
 	return response, nil
 }
 
+//registerResource registers created resource in session ledger
+func registerResource(context *endly.Context, resource *Resource) error {
+	deleteResource := &Resource{
+		URL:         resource.URL,
+		Brokers:     resource.Brokers,
+		Credentials: resource.Credentials,
+		ID:          resource.ID,
+		Name:        resource.Name,
+		Type:        resource.Type,
+		Vendor:      resource.Vendor,
+	}
+	deleteRequest := &DeleteRequest{Credentials: resource.Credentials, Resources: []*Resource{deleteResource}}
+	return context.Ledger().Register(ledgerResource, resource.URL, ServiceID, "deleteResource", deleteRequest)
+}
+
 func (s *service) delete(context *endly.Context, request *DeleteRequest) (interface{}, error) {
 	response := &DeleteRequest{}
 	for _, resource := range request.Resources {
@@ -213,7 +233,10 @@ func (s *service) deleteResource(context *endly.Context, resource *Resource) err
 	defer client.Close()
 	var state = context.State()
 	resource.URL = state.ExpandAsText(resource.URL)
-	return client.DeleteResource(resource)
+	if err = client.DeleteResource(resource); err != nil {
+		return err
+	}
+	return context.Ledger().Release(ledgerResource, resource.URL)
 }
 
 //New creates a new NoOperation service.
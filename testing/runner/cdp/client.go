@@ -0,0 +1,195 @@
+package cdp
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultCallTimeout = 60 * time.Second
+
+//command represents DevTools protocol command
+type command struct {
+	ID     int64       `json:"id"`
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+//protocolError represents DevTools protocol error
+type protocolError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+func (e *protocolError) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("%v (%v): %v", e.Message, e.Code, e.Data)
+	}
+	return fmt.Sprintf("%v (%v)", e.Message, e.Code)
+}
+
+//message represents DevTools protocol response or event
+type message struct {
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *protocolError  `json:"error,omitempty"`
+}
+
+//target represents DevTools target
+type target struct {
+	ID                   string `json:"id"`
+	Type                 string `json:"type"`
+	URL                  string `json:"url"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+//client represents DevTools protocol websocket client
+type client struct {
+	conn     *websocket.Conn
+	sequence int64
+	mutex    *sync.Mutex
+	pending  map[int64]chan *message
+	listener func(event *message)
+	done     chan bool
+	err      error
+}
+
+//call sends command and waits for its result
+func (c *client) call(method string, params interface{}, result interface{}) error {
+	id := atomic.AddInt64(&c.sequence, 1)
+	response := make(chan *message, 1)
+	c.mutex.Lock()
+	c.pending[id] = response
+	c.mutex.Unlock()
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+	}()
+	if err := c.write(&command{ID: id, Method: method, Params: params}); err != nil {
+		return err
+	}
+	select {
+	case msg := <-response:
+		if msg.Error != nil {
+			return fmt.Errorf("%v failed: %v", method, msg.Error)
+		}
+		if result == nil || len(msg.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	case <-c.done:
+		return fmt.Errorf("%v failed: connection closed: %v", method, c.err)
+	case <-time.After(defaultCallTimeout):
+		return fmt.Errorf("%v failed: timeout", method)
+	}
+}
+
+//send sends command without waiting for its result, it is used by event listener
+func (c *client) send(method string, params interface{}) error {
+	return c.write(&command{ID: atomic.AddInt64(&c.sequence, 1), Method: method, Params: params})
+}
+
+func (c *client) write(cmd *command) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.conn.WriteJSON(cmd)
+}
+
+//readLoop dispatches command results and events, events are dispatched sequentially before subsequent results
+func (c *client) readLoop() {
+	defer close(c.done)
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			c.err = err
+			return
+		}
+		msg := &message{}
+		if err = json.Unmarshal(data, msg); err != nil {
+			continue
+		}
+		if msg.ID == 0 {
+			if c.listener != nil && msg.Method != "" {
+				c.listener(msg)
+			}
+			continue
+		}
+		c.mutex.Lock()
+		response, ok := c.pending[msg.ID]
+		c.mutex.Unlock()
+		if ok {
+			response <- msg
+		}
+	}
+}
+
+func (c *client) close() error {
+	return c.conn.Close()
+}
+
+//pageTarget returns page target websocket URL, a new page is created if none exists
+func pageTarget(devToolsURL string) (string, error) {
+	devToolsURL = strings.TrimRight(devToolsURL, "/")
+	var targets = make([]*target, 0)
+	if err := getJSON(http.MethodGet, devToolsURL+"/json/list", &targets); err != nil {
+		return "", err
+	}
+	for _, candidate := range targets {
+		if candidate.Type == "page" && candidate.WebSocketDebuggerURL != "" {
+			return candidate.WebSocketDebuggerURL, nil
+		}
+	}
+	created := &target{}
+	//recent chrome versions require PUT, older accept GET only
+	if err := getJSON(http.MethodPut, devToolsURL+"/json/new?about:blank", created); err != nil {
+		if err = getJSON(http.MethodGet, devToolsURL+"/json/new?about:blank", created); err != nil {
+			return "", err
+		}
+	}
+	if created.WebSocketDebuggerURL == "" {
+		return "", fmt.Errorf("failed to create page target: %v", devToolsURL)
+	}
+	return created.WebSocketDebuggerURL, nil
+}
+
+func getJSON(method, URL string, result interface{}) error {
+	request, err := http.NewRequest(method, URL, nil)
+	if err != nil {
+		return err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to %v %v: %v", method, URL, response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+//newClient connects to page target websocket
+func newClient(webSocketURL string, listener func(event *message)) (*client, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(webSocketURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect %v: %v", webSocketURL, err)
+	}
+	result := &client{
+		conn:     conn,
+		mutex:    &sync.Mutex{},
+		pending:  make(map[int64]chan *message),
+		listener: listener,
+		done:     make(chan bool),
+	}
+	go result.readLoop()
+	return result, nil
+}
@@ -0,0 +1,185 @@
+package cdp
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/endly/testing/runner/selenium"
+	"github.com/viant/endly/testing/validator"
+	"github.com/viant/toolbox"
+	"strings"
+)
+
+//LaunchOptions represents local chrome launch or remote DevTools attach options
+type LaunchOptions struct {
+	ChromePath     string   `description:"chrome/chromium binary path, by default google-chrome, chromium or chrome is looked up in PATH"`
+	Port           int      `description:"remote debugging port, random free port by default"`
+	Headless       bool     `description:"run chrome without display, i.e. on CI"`
+	WindowSize     string   `description:"window size, i.e. 1920x1080"`
+	Arguments      []string `description:"additional chrome command line arguments"`
+	DevToolsURL    string   `description:"already running chrome DevTools endpoint, i.e. http://127.0.0.1:9222, if specified chrome is not launched"`
+	StartTimeoutMs int      `description:"max time to wait for chrome DevTools endpoint, default 20000"`
+}
+
+//Validate checks if options are valid
+func (o *LaunchOptions) Validate() error {
+	if o.WindowSize == "" {
+		return nil
+	}
+	_, _, err := o.windowSize()
+	return err
+}
+
+//windowSize returns window width and height
+func (o *LaunchOptions) windowSize() (int, int, error) {
+	fragments := strings.Split(strings.ToLower(o.WindowSize), "x")
+	if len(fragments) != 2 {
+		return 0, 0, fmt.Errorf("invalid window size: %v, expected WIDTHxHEIGHT", o.WindowSize)
+	}
+	width, err := toolbox.ToInt(strings.TrimSpace(fragments[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid window width: %v", o.WindowSize)
+	}
+	height, err := toolbox.ToInt(strings.TrimSpace(fragments[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid window height: %v", o.WindowSize)
+	}
+	return width, height, nil
+}
+
+//OpenRequest represents open session request, it launches local chrome or attaches to DevTools endpoint
+type OpenRequest struct {
+	SessionID string `description:"if specified this ID will be used for a session ID"`
+	LaunchOptions
+}
+
+//Validate checks if request is valid
+func (r *OpenRequest) Validate() error {
+	return r.LaunchOptions.Validate()
+}
+
+//OpenResponse represents open session response
+type OpenResponse struct {
+	SessionID   string
+	DevToolsURL string
+	Pid         int
+}
+
+//CloseRequest represents close session request, launched chrome is terminated
+type CloseRequest struct {
+	SessionID string
+}
+
+//Validate checks if request is valid
+func (r *CloseRequest) Validate() error {
+	if r.SessionID == "" {
+		return errors.New("sessionID was empty")
+	}
+	return nil
+}
+
+//CloseResponse represents close session response
+type CloseResponse struct {
+	SessionID string
+}
+
+//Interception represents network request interception, matched request is fulfilled with mocked response or aborted
+type Interception struct {
+	URL     string            `description:"URL pattern, * matches any characters, otherwise URL fragment is matched"`
+	Method  string            `description:"optional HTTP method"`
+	Status  int               `description:"mocked response status code, default 200"`
+	Headers map[string]string `description:"mocked response headers"`
+	Body    string            `description:"mocked response body"`
+	Abort   bool              `description:"fails matched request with network error"`
+}
+
+//Init initializes interception
+func (i *Interception) Init() {
+	if i.Status == 0 {
+		i.Status = 200
+	}
+}
+
+//Validate checks if interception is valid
+func (i *Interception) Validate() error {
+	if i.URL == "" {
+		return errors.New("URL was empty")
+	}
+	return nil
+}
+
+//Matches returns true if interception matches request method and URL
+func (i *Interception) Matches(method, URL string) bool {
+	if i.Method != "" && !strings.EqualFold(i.Method, method) {
+		return false
+	}
+	return matchURL(i.URL, URL)
+}
+
+//RunRequest represents group of web element and DevTools calls using selenium run command syntax
+type RunRequest struct {
+	SessionID        string
+	LaunchOptions    `description:"chrome options used when session is opened"`
+	Actions          []*selenium.Action
+	Commands         []interface{}   `description:"list of command: {web element selector}.WebElementMethod(params),  or PageMethod(params), or wait map, see selenium runner"`
+	Intercept        []*Interception `description:"network interceptions applied during this run"`
+	ActionDelaysInMs int             `description:"slows down action with specified delay"`
+	Expect           interface{}     `description:"If specified it will validated response as actual"`
+}
+
+//Init parses commands with selenium runner parser
+func (r *RunRequest) Init() error {
+	seleniumRequest := &selenium.RunRequest{Actions: r.Actions, Commands: r.Commands}
+	if err := seleniumRequest.Init(); err != nil {
+		return err
+	}
+	r.Actions = seleniumRequest.Actions
+	for _, action := range r.Actions {
+		if action.Selector != nil && action.Selector.Key == "" {
+			if action.Selector.Key = action.Key; action.Selector.Key == "" {
+				action.Selector.Key = action.Selector.Value
+			}
+		}
+	}
+	for _, interception := range r.Intercept {
+		interception.Init()
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *RunRequest) Validate() error {
+	if len(r.Actions) == 0 {
+		return errors.New("both actions/commands were empty")
+	}
+	for i, action := range r.Actions {
+		if len(action.Calls) == 0 {
+			return fmt.Errorf("actions[%d].Calls were empty", i)
+		}
+	}
+	for i, interception := range r.Intercept {
+		if err := interception.Validate(); err != nil {
+			return fmt.Errorf("invalid intercept[%d]: %v", i, err)
+		}
+	}
+	return r.LaunchOptions.Validate()
+}
+
+//Request represents network request observed by DevTools
+type Request struct {
+	URL         string
+	Method      string
+	Type        string
+	Status      int
+	Error       string `json:",omitempty"`
+	Intercepted bool   `json:",omitempty"`
+}
+
+//RunResponse represents run response
+type RunResponse struct {
+	SessionID    string
+	Data         map[string]interface{}
+	LookupErrors []string
+	Requests     []*Request
+	ConsoleLogs  []string
+	Assert       *validator.AssertResponse
+}
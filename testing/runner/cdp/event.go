@@ -0,0 +1,58 @@
+package cdp
+
+import (
+	"fmt"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+)
+
+//Messages returns messages
+func (r *RunRequest) Messages() []*msg.Message {
+	var actionCalls = make([]*msg.Styled, 0)
+	for _, action := range r.Actions {
+		var selector = ""
+		if action.Selector != nil {
+			selector = fmt.Sprintf("(%v:%v).", action.Selector.By, action.Selector.Value)
+		}
+		for _, call := range action.Calls {
+			actionCalls = append(actionCalls, msg.NewStyled(fmt.Sprintf("%v%v(%v)", selector, call.Method, call.Parameters), msg.MessageStyleInput))
+		}
+	}
+	return []*msg.Message{
+		msg.NewMessage(msg.NewStyled("Request", msg.MessageStyleGeneric), msg.NewStyled("cdp.run", msg.MessageStyleGeneric), actionCalls...),
+	}
+}
+
+//Messages returns messages
+func (r *RunResponse) Messages() []*msg.Message {
+	var dataMessages = make([]*msg.Styled, 0)
+	for k, v := range r.Data {
+		value := v
+		if toolbox.IsStructuredJSON(toolbox.AsString(v)) {
+			value, _ = toolbox.AsJSONText(v)
+		}
+		dataMessages = append(dataMessages, msg.NewStyled(fmt.Sprintf("%v = %v", k, value), msg.MessageStyleOutput))
+	}
+	var result = []*msg.Message{
+		msg.NewMessage(msg.NewStyled("Response", msg.MessageStyleGeneric), msg.NewStyled("cdp", msg.MessageStyleGeneric), dataMessages...),
+	}
+	for _, errMessage := range r.LookupErrors {
+		result = append(result,
+			msg.NewMessage(msg.NewStyled(errMessage, msg.MessageStyleOutput), msg.NewStyled("lookup", msg.MessageStyleError)))
+	}
+	for _, log := range r.ConsoleLogs {
+		result = append(result,
+			msg.NewMessage(msg.NewStyled(log, msg.MessageStyleOutput), msg.NewStyled("console", msg.MessageStyleGeneric)))
+	}
+	return result
+}
+
+//IsInput returns this request (CLI reporter interface)
+func (r *RunRequest) IsInput() bool {
+	return true
+}
+
+//IsOutput returns this response (CLI reporter interface)
+func (r *RunResponse) IsOutput() bool {
+	return true
+}
@@ -0,0 +1,113 @@
+package cdp
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/tebeka/selenium"
+	"regexp"
+	"strings"
+)
+
+//specialKey represents DevTools key event definition
+type specialKey struct {
+	Key     string
+	Code    string
+	Text    string
+	KeyCode int
+}
+
+//specialKeys maps selenium key codes to DevTools key events
+var specialKeys = map[rune]*specialKey{
+	[]rune(selenium.EnterKey)[0]:     {Key: "Enter", Code: "Enter", Text: "\r", KeyCode: 13},
+	[]rune(selenium.ReturnKey)[0]:    {Key: "Enter", Code: "Enter", Text: "\r", KeyCode: 13},
+	[]rune(selenium.TabKey)[0]:       {Key: "Tab", Code: "Tab", KeyCode: 9},
+	[]rune(selenium.BackspaceKey)[0]: {Key: "Backspace", Code: "Backspace", KeyCode: 8},
+	[]rune(selenium.EscapeKey)[0]:    {Key: "Escape", Code: "Escape", KeyCode: 27},
+}
+
+//elementScripts represents web element method scripts, el is the looked up element, args are call parameters
+var elementScripts = map[string]string{
+	"click":        "el.scrollIntoView({block: 'center'}); el.click(); return null;",
+	"clear":        "el.value = ''; el.dispatchEvent(new Event('input', {bubbles: true})); el.dispatchEvent(new Event('change', {bubbles: true})); return null;",
+	"submit":       "var form = el.form || el; if (form.requestSubmit) { form.requestSubmit(); } else { form.submit(); } return null;",
+	"sendkeys":     "el.scrollIntoView({block: 'center'}); el.focus(); if (el.setSelectionRange && typeof el.value === 'string') { try { el.setSelectionRange(el.value.length, el.value.length); } catch (e) {} } return null;",
+	"text":         "return el.innerText;",
+	"tagname":      "return el.tagName.toLowerCase();",
+	"getattribute": "return el.getAttribute(args[0]);",
+	"getproperty":  "return el[args[0]];",
+	"cssproperty":  "return window.getComputedStyle(el).getPropertyValue(args[0]);",
+	"isselected":   "return !!(el.checked || el.selected);",
+	"isenabled":    "return !el.disabled;",
+	"isdisplayed":  "var rect = el.getBoundingClientRect(); return window.getComputedStyle(el).visibility !== 'hidden' && rect.width > 0 && rect.height > 0;",
+}
+
+//pageScripts represents page (WebDriver) method scripts
+var pageScripts = map[string]string{
+	"title":      "document.title",
+	"currenturl": "window.location.href",
+	"pagesource": "document.documentElement.outerHTML",
+}
+
+//asJSLiteral returns JavaScript literal for supplied value
+func asJSLiteral(value interface{}) string {
+	literal, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(literal)
+}
+
+//finderScript returns JavaScript expression looking up element with selenium selector
+func finderScript(by, value string) string {
+	literal := asJSLiteral(value)
+	switch by {
+	case selenium.ByID:
+		return fmt.Sprintf("document.getElementById(%v)", literal)
+	case selenium.ByXPATH:
+		return fmt.Sprintf("document.evaluate(%v, document, null, XPathResult.FIRST_ORDERED_NODE_TYPE, null).singleNodeValue", literal)
+	case selenium.ByClassName:
+		if !strings.HasPrefix(value, ".") {
+			literal = asJSLiteral("." + value)
+		}
+		return fmt.Sprintf("document.querySelector(%v)", literal)
+	case selenium.ByLinkText:
+		return fmt.Sprintf("Array.prototype.find.call(document.querySelectorAll('a'), function(a) { return a.textContent.trim() === %v; })", literal)
+	case selenium.ByPartialLinkText:
+		return fmt.Sprintf("Array.prototype.find.call(document.querySelectorAll('a'), function(a) { return a.textContent.indexOf(%v) !== -1; })", literal)
+	}
+	return fmt.Sprintf("document.querySelector(%v)", literal)
+}
+
+//elementScript returns JavaScript expression calling web element method, expression evaluates to {found, value}
+func elementScript(by, value, method string, parameters []interface{}) (string, error) {
+	body, ok := elementScripts[strings.ToLower(method)]
+	if !ok {
+		return "", fmt.Errorf("unsupported web element method: %v", method)
+	}
+	if parameters == nil {
+		parameters = []interface{}{}
+	}
+	return fmt.Sprintf("(function(args) { var el = %v; if (!el) { return {found: false}; } return {found: true, value: (function() { %v })()}; })(%v)",
+		finderScript(by, value), body, asJSLiteral(parameters)), nil
+}
+
+//executeScript returns JavaScript expression calling script body with arguments, like WebDriver ExecuteScript
+func executeScript(script string, arguments []interface{}) string {
+	if arguments == nil {
+		arguments = []interface{}{}
+	}
+	return fmt.Sprintf("(function() { %v }).apply(null, %v)", script, asJSLiteral(arguments))
+}
+
+//matchURL returns true if URL matches pattern, * matches any characters, otherwise pattern is matched as URL fragment
+func matchURL(pattern, URL string) bool {
+	if !strings.Contains(pattern, "*") {
+		return strings.Contains(URL, pattern)
+	}
+	fragments := strings.Split(pattern, "*")
+	for i, fragment := range fragments {
+		fragments[i] = regexp.QuoteMeta(fragment)
+	}
+	matched, _ := regexp.MatchString("^"+strings.Join(fragments, ".*")+"$", URL)
+	return matched
+}
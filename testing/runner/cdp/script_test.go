@@ -0,0 +1,71 @@
+package cdp
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/tebeka/selenium"
+	"strings"
+	"testing"
+)
+
+func TestMatchURL(t *testing.T) {
+	var useCases = []struct {
+		pattern string
+		URL     string
+		expect  bool
+	}{
+		{"/api/user", "http://127.0.0.1/api/user?id=1", true},
+		{"/api/order", "http://127.0.0.1/api/user?id=1", false},
+		{"*/api/user*", "http://127.0.0.1/api/user?id=1", true},
+		{"http://127.0.0.1/*.js", "http://127.0.0.1/app/main.js", true},
+		{"http://127.0.0.1/*.js", "http://127.0.0.1/app/main.css", false},
+		{"*?id=*", "http://127.0.0.1/api/user?id=1", true},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expect, matchURL(useCase.pattern, useCase.URL), useCase.pattern+" "+useCase.URL)
+	}
+	interception := &Interception{URL: "/api/user", Method: "POST"}
+	assert.False(t, interception.Matches("GET", "http://127.0.0.1/api/user"))
+	assert.True(t, interception.Matches("post", "http://127.0.0.1/api/user"))
+}
+
+func TestElementScript(t *testing.T) {
+	var useCases = []struct {
+		by     string
+		value  string
+		method string
+		params []interface{}
+		expect []string
+	}{
+		{by: selenium.ByCSSSelector, value: "#email", method: "SendKeys", params: []interface{}{"bob"}, expect: []string{`document.querySelector("#email")`, "el.focus()", `(["bob"])`}},
+		{by: selenium.ByXPATH, value: "//INPUT[@id='name']", method: "Text", expect: []string{`document.evaluate("//INPUT[@id='name']"`, "el.innerText", "([])"}},
+		{by: selenium.ByClassName, value: "btn", method: "Click", expect: []string{`document.querySelector(".btn")`, "el.click()"}},
+		{by: selenium.ByLinkText, value: "Sign up", method: "GetAttribute", params: []interface{}{"href"}, expect: []string{`=== "Sign up"`, "el.getAttribute(args[0])"}},
+	}
+	for _, useCase := range useCases {
+		script, err := elementScript(useCase.by, useCase.value, useCase.method, useCase.params)
+		if !assert.Nil(t, err, useCase.method) {
+			continue
+		}
+		for _, expect := range useCase.expect {
+			assert.True(t, strings.Contains(script, expect), useCase.method+": "+expect)
+		}
+	}
+	_, err := elementScript(selenium.ByCSSSelector, "#email", "DoubleClick", nil)
+	assert.NotNil(t, err)
+}
+
+func TestRunRequest_Init(t *testing.T) {
+	request := &RunRequest{
+		Commands:  []interface{}{"get(http://127.0.0.1/)", "(#email).sendKeys(bob)", "(#email).submit"},
+		Intercept: []*Interception{{URL: "/api"}},
+	}
+	if assert.Nil(t, request.Init()) {
+		assert.Nil(t, request.Validate())
+		assert.EqualValues(t, 2, len(request.Actions))
+		assert.EqualValues(t, 2, len(request.Actions[1].Calls))
+		assert.EqualValues(t, 200, request.Intercept[0].Status)
+	}
+	request = &RunRequest{Commands: []interface{}{"get"}, Intercept: []*Interception{{}}}
+	assert.Nil(t, request.Init())
+	assert.NotNil(t, request.Validate())
+}
@@ -0,0 +1,356 @@
+package cdp
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/testing/runner/selenium"
+	"github.com/viant/endly/testing/validator"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"strings"
+	"time"
+)
+
+const (
+	//ServiceID represents Chrome DevTools Protocol runner service id
+	ServiceID = "cdp/runner"
+
+	runnerCaller            = "cdpRunnerCaller"
+	defaultWaitForRequestMs = 30000
+)
+
+const runExample = `{
+  "Headless": true,
+  "WindowSize": "1280x720",
+  "Intercept": [
+    {
+      "URL": "*/api/user*",
+      "Status": 200,
+      "Headers": {"Content-Type": "application/json"},
+      "Body": "{\"name\":\"Bob\"}"
+    },
+    {
+      "URL": "analytics",
+      "Abort": true
+    }
+  ],
+  "Commands": [
+    "get(http://127.0.0.1:8080/signup/)",
+    "(#email).sendKeys(bob@example.com)",
+    "(#submit).click",
+    "user = waitForRequest(/api/user, 5000)",
+    "(#name).text"
+  ],
+  "Expect": {
+    "#name": {
+      "Text": "Bob"
+    }
+  }
+}`
+
+type service struct {
+	*endly.AbstractService
+}
+
+func (s *service) openSession(context *endly.Context, request *OpenRequest) (*Session, error) {
+	sessions := Sessions(context)
+	if session, ok := sessions[request.SessionID]; ok {
+		return session, nil
+	}
+	if request.SessionID == "" && len(sessions) == 1 {
+		//reuse the only opened session
+		for _, session := range sessions {
+			return session, nil
+		}
+	}
+	session, err := newSession(request.SessionID, &request.LaunchOptions)
+	if err != nil {
+		return nil, err
+	}
+	sessions[session.ID] = session
+	context.Deffer(func() {
+		session.close()
+	})
+	return session, nil
+}
+
+func (s *service) open(context *endly.Context, request *OpenRequest) (*OpenResponse, error) {
+	session, err := s.openSession(context, request)
+	if err != nil {
+		return nil, err
+	}
+	return &OpenResponse{SessionID: session.ID, DevToolsURL: session.DevToolsURL, Pid: session.Pid()}, nil
+}
+
+func (s *service) close(context *endly.Context, request *CloseRequest) (*CloseResponse, error) {
+	sessions := Sessions(context)
+	if session, ok := sessions[request.SessionID]; ok {
+		session.close()
+		delete(sessions, request.SessionID)
+	}
+	return &CloseResponse{SessionID: request.SessionID}, nil
+}
+
+//setResult sets scalar call result in response data
+func setResult(result interface{}, response data.Map, resultPath ...string) {
+	if result == nil || toolbox.IsMap(result) || toolbox.IsSlice(result) {
+		return
+	}
+	response.SetValue(strings.Join(resultPath, "."), toolbox.AsString(result))
+}
+
+//getResultPath returns result path, it uses the same convention as selenium runner
+func getResultPath(key string, call *selenium.MethodCall) []string {
+	var method = call.Method
+	if len(call.Parameters) == 1 && toolbox.IsString(call.Parameters[0]) {
+		method = strings.Replace(method, "Get", "", 1)
+		method = strings.Replace(method, "Property", "", 1)
+	}
+	return []string{key, method}
+}
+
+//callPage calls page (WebDriver) method
+func (s *service) callPage(session *Session, call *selenium.MethodCall, requestOffset int) (interface{}, error) {
+	method := strings.ToLower(call.Method)
+	if expression, ok := pageScripts[method]; ok {
+		return session.evaluate(expression)
+	}
+	switch method {
+	case "get":
+		if len(call.Parameters) == 0 {
+			return nil, fmt.Errorf("%v: URL was empty", call.Method)
+		}
+		return nil, session.navigate("Page.navigate", map[string]interface{}{"url": toolbox.AsString(call.Parameters[0])})
+	case "refresh":
+		return nil, session.navigate("Page.reload", nil)
+	case "executescript":
+		if len(call.Parameters) == 0 {
+			return nil, fmt.Errorf("%v: script was empty", call.Method)
+		}
+		return session.evaluate(executeScript(toolbox.AsString(call.Parameters[0]), call.Parameters[1:]))
+	case "waitforrequest":
+		return s.waitForRequest(session, call, requestOffset)
+	}
+	return nil, fmt.Errorf("unsupported page method: %v", call.Method)
+}
+
+//waitForRequest waits for completed request matching URL pattern, optional second parameter defines timeout in ms
+func (s *service) waitForRequest(session *Session, call *selenium.MethodCall, offset int) (interface{}, error) {
+	if len(call.Parameters) == 0 {
+		return nil, fmt.Errorf("%v: URL pattern was empty", call.Method)
+	}
+	pattern := toolbox.AsString(call.Parameters[0])
+	timeoutMs := defaultWaitForRequestMs
+	if len(call.Parameters) > 1 {
+		timeoutMs = toolbox.AsInt(call.Parameters[1])
+	} else if index := strings.LastIndex(pattern, ","); index != -1 {
+		//selenium command parser passes parameters as a single text
+		if timeout, err := toolbox.ToInt(strings.TrimSpace(pattern[index+1:])); err == nil {
+			pattern, timeoutMs = strings.TrimSpace(pattern[:index]), timeout
+		}
+	}
+	deadline := time.Now().Add(time.Duration(timeoutMs) * time.Millisecond)
+	for {
+		if request := session.findRequest(pattern, offset); request != nil {
+			return request.URL, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for request: %v", pattern)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+//callElement calls web element method, lookupError is returned if element was not found
+func (s *service) callElement(session *Session, selector *selenium.WebElementSelector, call *selenium.MethodCall) (result interface{}, lookupError string, err error) {
+	if err = selector.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid selector: %v", err)
+	}
+	expression, err := elementScript(selector.By, selector.Value, call.Method, call.Parameters)
+	if err != nil {
+		return nil, "", err
+	}
+	output, err := session.evaluate(expression)
+	if err != nil {
+		return nil, "", err
+	}
+	aMap := toolbox.AsMap(output)
+	if !toolbox.AsBoolean(aMap["found"]) {
+		return nil, fmt.Sprintf("failed to lookup element: %v %v", selector.By, selector.Value), nil
+	}
+	if strings.EqualFold(call.Method, "sendKeys") && len(call.Parameters) > 0 {
+		return nil, "", session.sendKeys(toolbox.AsString(call.Parameters[0]))
+	}
+	return aMap["value"], "", nil
+}
+
+//call runs handler with optional wait repeater, handler returns false if element was not found so that call can be repeated
+func (s *service) call(context *endly.Context, call *selenium.MethodCall, response data.Map, handler func() (interface{}, bool, error)) error {
+	repeater := call.Wait.Init()
+	return repeater.Run(s.AbstractService, runnerCaller, context, func() (interface{}, error) {
+		result, found, err := handler()
+		if err != nil || !found {
+			return nil, err
+		}
+		if result == nil {
+			return []interface{}{""}, nil
+		}
+		if toolbox.IsMap(result) {
+			return result, nil
+		}
+		return []interface{}{toolbox.AsString(result)}, nil
+	}, response)
+}
+
+func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse, error) {
+	session, err := s.openSession(context, &OpenRequest{SessionID: request.SessionID, LaunchOptions: request.LaunchOptions})
+	if err != nil {
+		return nil, err
+	}
+	var response = &RunResponse{
+		SessionID:    session.ID,
+		Data:         make(map[string]interface{}),
+		LookupErrors: make([]string, 0),
+	}
+	consoleOffset, requestOffset := session.offsets()
+	if len(request.Intercept) > 0 {
+		session.setInterceptions(request.Intercept)
+		if err = session.client.call("Fetch.enable", map[string]interface{}{"patterns": []map[string]string{{"urlPattern": "*"}}}, nil); err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = session.client.call("Fetch.disable", nil, nil)
+			session.setInterceptions(nil)
+		}()
+	}
+	var state = context.State()
+	var responseData = data.Map(response.Data)
+	actionDelay := time.Duration(request.ActionDelaysInMs) * time.Millisecond
+	for _, action := range request.Actions {
+		for _, call := range action.Calls {
+			for i, item := range call.Parameters {
+				call.Parameters[i] = state.Expand(item)
+			}
+			if action.Selector == nil {
+				var key = action.Key
+				if key == "" {
+					key = call.Method
+				}
+				if err = s.call(context, call, responseData, func() (interface{}, bool, error) {
+					result, err := s.callPage(session, call, requestOffset)
+					setResult(result, responseData, key)
+					return result, true, err
+				}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			var lookupError string
+			resultPath := getResultPath(action.Selector.Key, call)
+			if err = s.call(context, call, responseData, func() (interface{}, bool, error) {
+				var result interface{}
+				var err error
+				result, lookupError, err = s.callElement(session, action.Selector, call)
+				setResult(result, responseData, resultPath...)
+				return result, lookupError == "", err
+			}); err != nil {
+				return nil, err
+			}
+			if lookupError != "" {
+				response.LookupErrors = append(response.LookupErrors, lookupError)
+			}
+			if actionDelay > 0 {
+				time.Sleep(actionDelay)
+			}
+		}
+	}
+	response.ConsoleLogs, response.Requests = session.since(consoleOffset, requestOffset)
+	if request.Expect != nil {
+		response.Assert, err = validator.Assert(context, request, request.Expect, response.Data, "cdp", "assert cdp response")
+	}
+	return response, err
+}
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "open",
+		RequestInfo: &endly.ActionInfo{
+			Description: "launch local chrome with DevTools enabled or attach to running chrome",
+			Examples: []*endly.UseCase{
+				{
+					Description: "headless chrome",
+					Data: `{
+  "Headless": true,
+  "WindowSize": "1920x1080"
+}`,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &OpenRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &OpenResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*OpenRequest); ok {
+				return s.open(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "run",
+		RequestInfo: &endly.ActionInfo{
+			Description: "run selenium style commands over Chrome DevTools Protocol with network interception and console capture",
+			Examples: []*endly.UseCase{
+				{
+					Description: "run with network interception",
+					Data:        runExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &RunRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &RunResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*RunRequest); ok {
+				return s.run(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
+	s.Register(&endly.Route{
+		Action: "close",
+		RequestInfo: &endly.ActionInfo{
+			Description: "close DevTools session and terminate launched chrome",
+		},
+		RequestProvider: func() interface{} {
+			return &CloseRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &CloseResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*CloseRequest); ok {
+				return s.close(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+//New creates a new Chrome DevTools Protocol runner service
+func New() endly.Service {
+	var result = &service{
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
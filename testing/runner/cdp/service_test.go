@@ -0,0 +1,123 @@
+package cdp
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//newDevToolsServer returns fake DevTools endpoint, page elements are represented by selector to text map
+func newDevToolsServer(t *testing.T, elements map[string]string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json/list", func(writer http.ResponseWriter, request *http.Request) {
+		_ = json.NewEncoder(writer).Encode([]*target{{ID: "1", Type: "page", WebSocketDebuggerURL: "ws" + strings.TrimPrefix(server.URL, "http") + "/devtools/page/1"}})
+	})
+	mux.HandleFunc("/devtools/page/1", func(writer http.ResponseWriter, request *http.Request) {
+		conn, err := upgrader.Upgrade(writer, request, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+		var title = ""
+		for {
+			cmd := &struct {
+				ID     int64
+				Method string
+				Params map[string]interface{}
+			}{}
+			if err := conn.ReadJSON(cmd); err != nil {
+				return
+			}
+			var result interface{} = map[string]interface{}{}
+			var events = make([]map[string]interface{}, 0)
+			switch cmd.Method {
+			case "Page.navigate":
+				title = "Home"
+				events = append(events,
+					map[string]interface{}{"method": "Network.requestWillBeSent", "params": map[string]interface{}{"requestId": "1", "type": "Document", "request": map[string]interface{}{"url": cmd.Params["url"], "method": "GET"}}},
+					map[string]interface{}{"method": "Network.requestWillBeSent", "params": map[string]interface{}{"requestId": "2", "type": "XHR", "request": map[string]interface{}{"url": "http://127.0.0.1/api/user?id=1", "method": "GET"}}},
+					map[string]interface{}{"method": "Network.responseReceived", "params": map[string]interface{}{"requestId": "1", "response": map[string]interface{}{"status": 200}}},
+					map[string]interface{}{"method": "Network.responseReceived", "params": map[string]interface{}{"requestId": "2", "response": map[string]interface{}{"status": 201}}},
+					map[string]interface{}{"method": "Runtime.consoleAPICalled", "params": map[string]interface{}{"type": "log", "args": []interface{}{map[string]interface{}{"type": "string", "value": "app started"}}}},
+				)
+				result = map[string]interface{}{"frameId": "1"}
+			case "Runtime.evaluate":
+				expression := cmd.Params["expression"].(string)
+				var value interface{} = title
+				if strings.Contains(expression, "(function(args)") {
+					value = map[string]interface{}{"found": false}
+					for selector, text := range elements {
+						if strings.Contains(expression, "querySelector(\""+selector+"\")") {
+							value = map[string]interface{}{"found": true, "value": text}
+						}
+					}
+				}
+				result = map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": value}}
+			}
+			for _, event := range events {
+				assert.Nil(t, conn.WriteJSON(event))
+			}
+			assert.Nil(t, conn.WriteJSON(map[string]interface{}{"id": cmd.ID, "result": result}))
+			if cmd.Method == "Page.navigate" {
+				assert.Nil(t, conn.WriteJSON(map[string]interface{}{"method": "Page.loadEventFired", "params": map[string]interface{}{}}))
+			}
+		}
+	})
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestService_Run(t *testing.T) {
+	server := newDevToolsServer(t, map[string]string{"#name": "Bob"})
+	defer server.Close()
+
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+
+	request := &RunRequest{
+		LaunchOptions: LaunchOptions{DevToolsURL: server.URL},
+		Commands: []interface{}{
+			"get(http://127.0.0.1/app)",
+			"title = Title",
+			"user = waitForRequest(*/api/user*, 1000)",
+			"(#name).text",
+			"(#missing).click",
+		},
+		Expect: map[string]interface{}{
+			"title": "Home",
+			"#name": map[string]interface{}{"Text": "Bob"},
+		},
+	}
+	response := &RunResponse{}
+	err := endly.Run(context, request, response)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, strings.Replace(server.URL, "http://", "", 1), response.SessionID)
+	assert.EqualValues(t, "http://127.0.0.1/api/user?id=1", response.Data["user"])
+	assert.EqualValues(t, []string{"failed to lookup element: css selector #missing"}, response.LookupErrors)
+	assert.EqualValues(t, []string{"[log] app started"}, response.ConsoleLogs)
+	if assert.EqualValues(t, 2, len(response.Requests)) {
+		assert.EqualValues(t, &Request{URL: "http://127.0.0.1/app", Method: "GET", Type: "Document", Status: 200}, response.Requests[0])
+		assert.EqualValues(t, 201, response.Requests[1].Status)
+	}
+	if assert.NotNil(t, response.Assert) {
+		assert.EqualValues(t, 0, response.Assert.FailedCount)
+		assert.EqualValues(t, 2, response.Assert.PassedCount)
+	}
+
+	request = &RunRequest{SessionID: response.SessionID, Commands: []interface{}{"waitForRequest(/api/order, 100)"}}
+	err = endly.Run(context, request, &RunResponse{})
+	assert.NotNil(t, err, "request made before run should not be matched")
+
+	err = endly.Run(context, &CloseRequest{SessionID: response.SessionID}, &CloseResponse{})
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, len(Sessions(context)))
+}
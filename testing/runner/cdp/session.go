@@ -0,0 +1,496 @@
+package cdp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultStartTimeoutMs = 20000
+
+//chromeCandidates represents chrome binaries looked up in PATH
+var chromeCandidates = []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "chrome"}
+
+//Session represents DevTools session
+type Session struct {
+	ID            string
+	DevToolsURL   string
+	client        *client
+	cmd           *exec.Cmd
+	userDataDir   string
+	mutex         *sync.RWMutex
+	consoleLogs   []string
+	requests      []*Request
+	requestIndex  map[string]*Request
+	interceptions []*Interception
+	loaded        chan bool
+}
+
+type sessions struct {
+	Sessions map[string]*Session
+}
+
+var sessionKey = (*sessions)(nil)
+
+//Sessions returns DevTools sessions
+func Sessions(context *endly.Context) map[string]*Session {
+	var result *sessions
+	if !context.Contains(sessionKey) {
+		result = &sessions{
+			Sessions: make(map[string]*Session),
+		}
+		context.Put(sessionKey, result)
+	}
+	context.GetInto(sessionKey, &result)
+	return result.Sessions
+}
+
+//Pid returns launched chrome process id
+func (s *Session) Pid() int {
+	if s.cmd == nil || s.cmd.Process == nil {
+		return 0
+	}
+	return s.cmd.Process.Pid
+}
+
+//offsets returns current console logs and requests count
+func (s *Session) offsets() (int, int) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.consoleLogs), len(s.requests)
+}
+
+//since returns console logs and requests recorded after supplied offsets
+func (s *Session) since(consoleOffset, requestOffset int) ([]string, []*Request) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var logs = append([]string{}, s.consoleLogs[consoleOffset:]...)
+	var requests = make([]*Request, 0)
+	for _, request := range s.requests[requestOffset:] {
+		var clone = *request
+		requests = append(requests, &clone)
+	}
+	return logs, requests
+}
+
+//findRequest returns first completed request matching pattern recorded after offset
+func (s *Session) findRequest(pattern string, offset int) *Request {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, request := range s.requests[offset:] {
+		if (request.Status > 0 || request.Error != "") && matchURL(pattern, request.URL) {
+			var clone = *request
+			return &clone
+		}
+	}
+	return nil
+}
+
+func (s *Session) setInterceptions(interceptions []*Interception) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.interceptions = interceptions
+}
+
+func (s *Session) addConsoleLog(level, text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.consoleLogs = append(s.consoleLogs, fmt.Sprintf("[%v] %v", level, text))
+}
+
+//request returns request for DevTools request id, caller has to hold the lock
+func (s *Session) request(requestID string) *Request {
+	request, ok := s.requestIndex[requestID]
+	if !ok {
+		request = &Request{}
+		s.requestIndex[requestID] = request
+		s.requests = append(s.requests, request)
+	}
+	return request
+}
+
+//onEvent handles DevTools events, it runs in the client read loop thus must not wait for command results
+func (s *Session) onEvent(event *message) {
+	switch event.Method {
+	case "Page.loadEventFired":
+		select {
+		case s.loaded <- true:
+		default:
+		}
+	case "Runtime.consoleAPICalled":
+		params := &struct {
+			Type string
+			Args []struct {
+				Type        string
+				Value       interface{}
+				Description string
+			}
+		}{}
+		if json.Unmarshal(event.Params, params) == nil {
+			var values = make([]string, 0)
+			for _, arg := range params.Args {
+				if arg.Value != nil {
+					values = append(values, fmt.Sprintf("%v", arg.Value))
+				} else {
+					values = append(values, arg.Description)
+				}
+			}
+			s.addConsoleLog(params.Type, strings.Join(values, " "))
+		}
+	case "Runtime.exceptionThrown":
+		params := &struct {
+			ExceptionDetails struct {
+				Text      string
+				Exception struct {
+					Description string
+				}
+			}
+		}{}
+		if json.Unmarshal(event.Params, params) == nil {
+			text := params.ExceptionDetails.Exception.Description
+			if text == "" {
+				text = params.ExceptionDetails.Text
+			}
+			s.addConsoleLog("exception", text)
+		}
+	case "Log.entryAdded":
+		params := &struct {
+			Entry struct {
+				Level string
+				Text  string
+				URL   string
+			}
+		}{}
+		if json.Unmarshal(event.Params, params) == nil {
+			s.addConsoleLog(params.Entry.Level, strings.TrimSpace(params.Entry.Text+" "+params.Entry.URL))
+		}
+	case "Network.requestWillBeSent":
+		params := &struct {
+			RequestID string
+			Type      string
+			Request   struct {
+				URL    string
+				Method string
+			}
+		}{}
+		if json.Unmarshal(event.Params, params) == nil {
+			s.mutex.Lock()
+			request := s.request(params.RequestID)
+			request.URL, request.Method, request.Type = params.Request.URL, params.Request.Method, params.Type
+			s.mutex.Unlock()
+		}
+	case "Network.responseReceived":
+		params := &struct {
+			RequestID string
+			Response  struct {
+				Status int
+			}
+		}{}
+		if json.Unmarshal(event.Params, params) == nil {
+			s.mutex.Lock()
+			s.request(params.RequestID).Status = params.Response.Status
+			s.mutex.Unlock()
+		}
+	case "Network.loadingFailed":
+		params := &struct {
+			RequestID string
+			ErrorText string
+		}{}
+		if json.Unmarshal(event.Params, params) == nil {
+			s.mutex.Lock()
+			s.request(params.RequestID).Error = params.ErrorText
+			s.mutex.Unlock()
+		}
+	case "Fetch.requestPaused":
+		s.intercept(event)
+	}
+}
+
+//intercept fulfills, fails or continues paused request
+func (s *Session) intercept(event *message) {
+	params := &struct {
+		RequestID string
+		NetworkID string
+		Request   struct {
+			URL    string
+			Method string
+		}
+	}{}
+	if json.Unmarshal(event.Params, params) != nil {
+		return
+	}
+	var matched *Interception
+	s.mutex.Lock()
+	for _, candidate := range s.interceptions {
+		if candidate.Matches(params.Request.Method, params.Request.URL) {
+			matched = candidate
+			break
+		}
+	}
+	if matched != nil && params.NetworkID != "" {
+		s.request(params.NetworkID).Intercepted = true
+	}
+	s.mutex.Unlock()
+	if matched == nil {
+		_ = s.client.send("Fetch.continueRequest", map[string]interface{}{"requestId": params.RequestID})
+		return
+	}
+	if matched.Abort {
+		_ = s.client.send("Fetch.failRequest", map[string]interface{}{"requestId": params.RequestID, "errorReason": "Failed"})
+		return
+	}
+	var headers = make([]map[string]string, 0)
+	for name, value := range matched.Headers {
+		headers = append(headers, map[string]string{"name": name, "value": value})
+	}
+	_ = s.client.send("Fetch.fulfillRequest", map[string]interface{}{
+		"requestId":       params.RequestID,
+		"responseCode":    matched.Status,
+		"responseHeaders": headers,
+		"body":            base64.StdEncoding.EncodeToString([]byte(matched.Body)),
+	})
+}
+
+//navigate navigates to URL or reloads page and waits for load event
+func (s *Session) navigate(method string, params interface{}) error {
+	select {
+	case <-s.loaded:
+	default:
+	}
+	result := &struct {
+		ErrorText string
+	}{}
+	if err := s.client.call(method, params, result); err != nil {
+		return err
+	}
+	if result.ErrorText != "" {
+		return fmt.Errorf("%v failed: %v", method, result.ErrorText)
+	}
+	select {
+	case <-s.loaded:
+		return nil
+	case <-time.After(defaultCallTimeout):
+		return fmt.Errorf("%v failed: load event timeout", method)
+	}
+}
+
+//evaluate evaluates JavaScript expression and returns its value
+func (s *Session) evaluate(expression string) (interface{}, error) {
+	result := &struct {
+		Result struct {
+			Type  string
+			Value interface{}
+		}
+		ExceptionDetails *struct {
+			Text      string
+			Exception struct {
+				Description string
+			}
+		}
+	}{}
+	if err := s.client.call("Runtime.evaluate", map[string]interface{}{
+		"expression":    expression,
+		"returnByValue": true,
+		"awaitPromise":  true,
+	}, result); err != nil {
+		return nil, err
+	}
+	if result.ExceptionDetails != nil {
+		text := result.ExceptionDetails.Exception.Description
+		if text == "" {
+			text = result.ExceptionDetails.Text
+		}
+		return nil, fmt.Errorf("script error: %v", text)
+	}
+	return result.Result.Value, nil
+}
+
+//sendKeys types text into focused element, selenium special keys are sent as key events
+func (s *Session) sendKeys(text string) error {
+	var buffer = make([]rune, 0)
+	flush := func() error {
+		if len(buffer) == 0 {
+			return nil
+		}
+		err := s.client.call("Input.insertText", map[string]interface{}{"text": string(buffer)}, nil)
+		buffer = buffer[:0]
+		return err
+	}
+	for _, char := range text {
+		key, ok := specialKeys[char]
+		if !ok {
+			buffer = append(buffer, char)
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		for _, eventType := range []string{"keyDown", "keyUp"} {
+			params := map[string]interface{}{"type": eventType, "key": key.Key, "code": key.Code, "windowsVirtualKeyCode": key.KeyCode}
+			if eventType == "keyDown" && key.Text != "" {
+				params["text"] = key.Text
+			}
+			if err := s.client.call("Input.dispatchKeyEvent", params, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+//close closes DevTools connection and terminates launched chrome
+func (s *Session) close() {
+	if s.client != nil {
+		if s.cmd != nil {
+			_ = s.client.call("Browser.close", nil, nil)
+		}
+		_ = s.client.close()
+	}
+	if s.cmd != nil && s.cmd.Process != nil {
+		done := make(chan error, 1)
+		go func() { done <- s.cmd.Wait() }()
+		select {
+		case <-done:
+		case <-time.After(3 * time.Second):
+			_ = s.cmd.Process.Kill()
+			<-done
+		}
+	}
+	if s.userDataDir != "" {
+		_ = os.RemoveAll(s.userDataDir)
+	}
+}
+
+//chromePath returns chrome binary path
+func chromePath(options *LaunchOptions) (string, error) {
+	if options.ChromePath != "" {
+		return options.ChromePath, nil
+	}
+	for _, candidate := range chromeCandidates {
+		if location, err := exec.LookPath(candidate); err == nil {
+			return location, nil
+		}
+	}
+	if runtime.GOOS == "darwin" {
+		location := "/Applications/Google Chrome.app/Contents/MacOS/Google Chrome"
+		if _, err := os.Stat(location); err == nil {
+			return location, nil
+		}
+	}
+	return "", fmt.Errorf("failed to lookup chrome binary, tried: %v, use ChromePath", strings.Join(chromeCandidates, ", "))
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+//chromeArguments returns chrome command line arguments
+func chromeArguments(options *LaunchOptions, port int, userDataDir string) []string {
+	var result = []string{
+		fmt.Sprintf("--remote-debugging-port=%d", port),
+		"--user-data-dir=" + userDataDir,
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--disable-background-networking",
+		"--disable-extensions",
+	}
+	if options.Headless {
+		result = append(result, "--headless", "--disable-gpu")
+	}
+	if os.Geteuid() == 0 {
+		result = append(result, "--no-sandbox")
+	}
+	if width, height, _ := options.windowSize(); width > 0 && height > 0 {
+		result = append(result, fmt.Sprintf("--window-size=%d,%d", width, height))
+	}
+	result = append(result, options.Arguments...)
+	return append(result, "about:blank")
+}
+
+//launch starts local chrome with remote debugging enabled and waits for DevTools endpoint
+func launch(session *Session, options *LaunchOptions) error {
+	binary, err := chromePath(options)
+	if err != nil {
+		return err
+	}
+	port := options.Port
+	if port == 0 {
+		if port, err = freePort(); err != nil {
+			return err
+		}
+	}
+	if session.userDataDir, err = ioutil.TempDir("", "endly_cdp"); err != nil {
+		return err
+	}
+	session.cmd = exec.Command(binary, chromeArguments(options, port, session.userDataDir)...)
+	if err = session.cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %v: %v", binary, err)
+	}
+	session.DevToolsURL = fmt.Sprintf("http://127.0.0.1:%d", port)
+	timeoutMs := options.StartTimeoutMs
+	if timeoutMs == 0 {
+		timeoutMs = defaultStartTimeoutMs
+	}
+	deadline := time.Now().Add(time.Duration(timeoutMs) * time.Millisecond)
+	for {
+		version := make(map[string]interface{})
+		if err = getJSON("GET", session.DevToolsURL+"/json/version", &version); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("failed to connect chrome DevTools %v: %v", session.DevToolsURL, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+//newSession launches chrome or attaches to DevTools endpoint and enables required domains
+func newSession(sessionID string, options *LaunchOptions) (*Session, error) {
+	var result = &Session{
+		ID:           sessionID,
+		DevToolsURL:  options.DevToolsURL,
+		mutex:        &sync.RWMutex{},
+		consoleLogs:  make([]string, 0),
+		requests:     make([]*Request, 0),
+		requestIndex: make(map[string]*Request),
+		loaded:       make(chan bool, 1),
+	}
+	if result.DevToolsURL == "" {
+		if err := launch(result, options); err != nil {
+			result.close()
+			return nil, err
+		}
+	}
+	webSocketURL, err := pageTarget(result.DevToolsURL)
+	if err == nil {
+		result.client, err = newClient(webSocketURL, result.onEvent)
+	}
+	if err != nil {
+		result.close()
+		return nil, err
+	}
+	if result.ID == "" {
+		result.ID = strings.Replace(strings.Replace(result.DevToolsURL, "http://", "", 1), "https://", "", 1)
+	}
+	for _, domain := range []string{"Page", "Runtime", "Network", "Log"} {
+		if err = result.client.call(domain+".enable", nil, nil); err != nil {
+			result.close()
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -6,12 +6,14 @@ import (
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
 	"github.com/viant/toolbox/url"
+	"strings"
 )
 
 //SendRequest represents a send http request.
 type SendRequest struct {
-	Options     map[string]interface{} `description:"http client httpOptions: key value pairs, where key is one of the following: HTTP httpOptions:RequestTimeoutMs,TimeoutMs,KeepAliveTimeMs,TLSHandshakeTimeoutMs,ResponseHeaderTimeoutMs,MaxIdleConns,FollowRedirects"`
+	Options     map[string]interface{} `description:"http client httpOptions: key value pairs, where key is one of the following: HTTP httpOptions:RequestTimeoutMs,TimeoutMs,KeepAliveTimeMs,TLSHandshakeTimeoutMs,ResponseHeaderTimeoutMs,MaxIdleConns,FollowRedirects,Proxy (i.e. socks5://127.0.0.1:1080)"`
 	httpOptions []*toolbox.HttpOptions
+	proxy       string
 	Requests    []*Request
 	Expect      map[string]interface{} `description:"If specified it will validated response as actual"`
 }
@@ -30,6 +32,10 @@ func (s *SendRequest) Init() error {
 	if len(s.Options) > 0 {
 		s.httpOptions = make([]*toolbox.HttpOptions, 0)
 		for k, v := range s.Options {
+			if strings.EqualFold(k, ProxyOption) {
+				s.proxy = toolbox.AsString(v)
+				continue
+			}
 			s.httpOptions = append(s.httpOptions, &toolbox.HttpOptions{Key: k, Value: v})
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"io"
 	ioutil "io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -22,6 +23,23 @@ func initializeContext(c *endly.Context) {
 	}
 }
 
+//applyProxy sets client transport proxy, http, https and socks5 proxy URL schemes are supported
+func applyProxy(client *http.Client, proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid %v: %v, %v", ProxyOption, proxy, err)
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unsupported transport type: %T", client.Transport)
+	}
+	transport.Proxy = http.ProxyURL(proxyURL)
+	return nil
+}
+
 //copyHeaders copy source to target headers
 func copyHeaders(source http.Header, target http.Header) {
 	for key, values := range source {
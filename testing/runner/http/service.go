@@ -23,12 +23,18 @@ import (
 const ServiceID = "http/runner"
 const RunnerID = "HttpRunner"
 
+//ProxyOption represents http client proxy URL option
+const ProxyOption = "Proxy"
+
 type service struct {
 	*endly.AbstractService
 }
 
 func (s *service) send(context *endly.Context, sendGroupRequest *SendRequest) (*SendResponse, error) {
 	client, err := toolbox.NewHttpClient(s.applyDefaultTimeoutIfNeeded(sendGroupRequest.httpOptions)...)
+	if err == nil {
+		err = applyProxy(client, sendGroupRequest.proxy)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to send req: %v", err)
 	}
@@ -396,6 +402,9 @@ func (s *service) initClients(request *LoadRequest, sendChannel chan *stressTest
 		if client, err = toolbox.NewHttpClient(options...); err != nil {
 			return nil, err
 		}
+		if err = applyProxy(client, request.proxy); err != nil {
+			return nil, err
+		}
 
 		go s.handleRequests(client, sendChannel, metric, done)
 		clients[i] = client
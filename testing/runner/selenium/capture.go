@@ -0,0 +1,127 @@
+package selenium
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/tebeka/selenium"
+	"github.com/viant/endly/model/msg"
+	"html"
+	"strings"
+	"time"
+)
+
+const (
+	//CaptureFailure captures browser state when run fails, has lookup errors or failed assertion
+	CaptureFailure = "failure"
+	//CaptureAlways captures browser state once after each run request, not after each of its commands
+	CaptureAlways = "always"
+	//CaptureNone disables browser state capture
+	CaptureNone = "none"
+
+	browserLogType = "browser"
+)
+
+//CaptureEvent represents browser state captured after selenium run, artifacts are stored in the workflow log directory
+type CaptureEvent struct {
+	SessionID   string
+	Reason      string
+	URL         string
+	Title       string
+	ConsoleLogs []string
+	Errors      []string `json:",omitempty"`
+	screenshot  []byte
+	pageSource  string
+	artifacts   []*msg.Artifact
+}
+
+//Artifacts returns screenshot, page source, console logs and HTML report
+func (e *CaptureEvent) Artifacts() []*msg.Artifact {
+	if e.artifacts != nil {
+		return e.artifacts
+	}
+	var result = make([]*msg.Artifact, 0)
+	if len(e.screenshot) > 0 {
+		result = append(result, &msg.Artifact{Name: "screenshot.png", Content: e.screenshot})
+	}
+	if e.pageSource != "" {
+		result = append(result, &msg.Artifact{Name: "page_source.html", Content: []byte(e.pageSource)})
+	}
+	if len(e.ConsoleLogs) > 0 {
+		result = append(result, &msg.Artifact{Name: "console.log", Content: []byte(strings.Join(e.ConsoleLogs, "\n") + "\n")})
+	}
+	result = append(result, &msg.Artifact{Name: "capture.html", Content: e.report()})
+	e.artifacts = result
+	return result
+}
+
+//report returns self contained HTML report
+func (e *CaptureEvent) report() []byte {
+	report := new(bytes.Buffer)
+	report.WriteString("<html><head><title>selenium " + html.EscapeString(e.SessionID) + " capture</title></head><body>\n")
+	report.WriteString("<h3>" + html.EscapeString(e.Reason) + "</h3>\n")
+	report.WriteString("<p>URL: " + html.EscapeString(e.URL) + "<br/>Title: " + html.EscapeString(e.Title) + "</p>\n")
+	for _, message := range e.Errors {
+		report.WriteString("<p>capture error: " + html.EscapeString(message) + "</p>\n")
+	}
+	if len(e.screenshot) > 0 {
+		report.WriteString("<img style=\"max-width:100%\" src=\"data:image/png;base64," + base64.StdEncoding.EncodeToString(e.screenshot) + "\"/>\n")
+	}
+	if len(e.ConsoleLogs) > 0 {
+		report.WriteString("<h4>console logs</h4>\n<pre>" + html.EscapeString(strings.Join(e.ConsoleLogs, "\n")) + "</pre>\n")
+	}
+	if e.pageSource != "" {
+		report.WriteString("<h4>page source</h4>\n<pre>" + html.EscapeString(e.pageSource) + "</pre>\n")
+	}
+	report.WriteString("</body></html>\n")
+	return report.Bytes()
+}
+
+//captureReason returns capture reason or empty string if capture is not needed
+func captureReason(request *RunRequest, response *RunResponse, err error) string {
+	if request.Capture == CaptureNone {
+		return ""
+	}
+	switch {
+	case err != nil:
+		return fmt.Sprintf("run failed: %v", err)
+	case response != nil && len(response.LookupErrors) > 0:
+		return strings.Join(response.LookupErrors, ", ")
+	case response != nil && response.Assert != nil && response.Assert.Validation != nil && response.Assert.HasFailure():
+		return fmt.Sprintf("assertion failed: %v", response.Assert.Report())
+	case request.Capture == CaptureAlways:
+		return "capture always"
+	}
+	return ""
+}
+
+//newCaptureEvent captures current browser state, capture errors are recorded on the event
+func newCaptureEvent(session *Session, reason string) *CaptureEvent {
+	var result = &CaptureEvent{
+		SessionID:   session.ID,
+		Reason:      reason,
+		ConsoleLogs: make([]string, 0),
+		Errors:      make([]string, 0),
+	}
+	var err error
+	driver := session.driver
+	if result.URL, err = driver.CurrentURL(); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("url: %v", err))
+	}
+	if result.Title, err = driver.Title(); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("title: %v", err))
+	}
+	if result.screenshot, err = driver.Screenshot(); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("screenshot: %v", err))
+	}
+	if result.pageSource, err = driver.PageSource(); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("page source: %v", err))
+	}
+	//not all drivers support log endpoint (i.e. geckodriver), thus error is ignored
+	if messages, err := driver.Log(selenium.LogType(browserLogType)); err == nil {
+		for _, message := range messages {
+			result.ConsoleLogs = append(result.ConsoleLogs, fmt.Sprintf("%v [%v] %v", time.Unix(0, int64(message.Timestamp)*int64(time.Millisecond)).UTC().Format("15:04:05.000"), message.Level, message.Message))
+		}
+	}
+	return result
+}
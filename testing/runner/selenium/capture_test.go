@@ -0,0 +1,91 @@
+package selenium
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/endly/testing/validator"
+	"strings"
+	"testing"
+)
+
+func TestCaptureReason(t *testing.T) {
+	failed := &assertly.Validation{}
+	failed.AddFailure(assertly.NewFailure("", "/title", "equal", "Home", "Login"))
+
+	var useCases = []struct {
+		description string
+		capture     string
+		response    *RunResponse
+		err         error
+		expect      string
+	}{
+		{
+			description: "run error",
+			capture:     CaptureFailure,
+			err:         errors.New("timeout"),
+			expect:      "run failed: timeout",
+		},
+		{
+			description: "lookup error",
+			capture:     CaptureFailure,
+			response:    &RunResponse{LookupErrors: []string{"failed to lookup element: css selector #submit"}},
+			expect:      "failed to lookup element",
+		},
+		{
+			description: "assertion failure",
+			capture:     CaptureFailure,
+			response:    &RunResponse{Assert: &validator.AssertResponse{Validation: failed}},
+			expect:      "assertion failed",
+		},
+		{
+			description: "passed run",
+			capture:     CaptureFailure,
+			response:    &RunResponse{Assert: &validator.AssertResponse{Validation: &assertly.Validation{}}},
+		},
+		{
+			description: "capture always",
+			capture:     CaptureAlways,
+			response:    &RunResponse{},
+			expect:      "capture always",
+		},
+		{
+			description: "capture disabled",
+			capture:     CaptureNone,
+			err:         errors.New("timeout"),
+		},
+	}
+	for _, useCase := range useCases {
+		reason := captureReason(&RunRequest{Capture: useCase.capture}, useCase.response, useCase.err)
+		if useCase.expect == "" {
+			assert.EqualValues(t, "", reason, useCase.description)
+			continue
+		}
+		assert.True(t, strings.HasPrefix(reason, useCase.expect), useCase.description+": "+reason)
+	}
+}
+
+func TestCaptureEvent_Artifacts(t *testing.T) {
+	event := &CaptureEvent{
+		SessionID:   "localhost:4444",
+		Reason:      "assertion failed",
+		URL:         "http://127.0.0.1/login",
+		Title:       "Login",
+		ConsoleLogs: []string{"10:00:00.000 [SEVERE] Uncaught TypeError"},
+		screenshot:  []byte{0x89, 'P', 'N', 'G'},
+		pageSource:  "<html><body><form id=\"login\"></form></body></html>",
+	}
+	artifacts := event.Artifacts()
+	var names = make([]string, 0)
+	for _, artifact := range artifacts {
+		names = append(names, artifact.Name)
+	}
+	assert.EqualValues(t, []string{"screenshot.png", "page_source.html", "console.log", "capture.html"}, names)
+	report := string(artifacts[3].Content)
+	assert.True(t, strings.Contains(report, "data:image/png;base64,iVBORw=="))
+	assert.True(t, strings.Contains(report, "Uncaught TypeError"))
+	assert.True(t, strings.Contains(report, "&lt;form id=&#34;login&#34;&gt;"))
+
+	artifacts[0].Location = "/tmp/logs/0003_screenshot.png"
+	assert.EqualValues(t, "/tmp/logs/0003_screenshot.png", event.Artifacts()[0].Location, "artifacts should be built once")
+}
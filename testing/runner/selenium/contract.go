@@ -9,15 +9,39 @@ import (
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
 	"github.com/viant/toolbox/url"
+	"strings"
+)
+
+const (
+	//BrowserFirefox represents firefox browser
+	BrowserFirefox = "firefox"
+	//BrowserChrome represents chrome browser
+	BrowserChrome = "chrome"
+	//BrowserChromium represents chromium browser (uses chromedriver)
+	BrowserChromium = "chromium"
 )
 
 //StartRequest represents a selenium server start request
 type StartRequest struct {
-	Target     *url.Resource
-	Port       int
-	Sdk        string
-	SdkVersion string
-	Version    string
+	Target        *url.Resource
+	Port          int
+	Sdk           string
+	SdkVersion    string
+	Version       string
+	Browser       string `description:"browser driver to deploy: firefox (geckodriver) or chrome/chromium (chromedriver), default firefox"`
+	DriverVersion string `description:"chromedriver version, has to match installed chrome major version"`
+}
+
+//Init initializes request
+func (r *StartRequest) Init() error {
+	r.Browser = normalizeBrowser(r.Browser)
+	if r.Browser == "" {
+		r.Browser = BrowserFirefox
+	}
+	if r.Browser == BrowserChrome && r.DriverVersion == "" {
+		r.DriverVersion = defaultChromeDriverVersion
+	}
+	return nil
 }
 
 func (r *StartRequest) Validate() error {
@@ -30,6 +54,9 @@ func (r *StartRequest) Validate() error {
 	if r.Version == "" {
 		return errors.New("version was empty")
 	}
+	if r.Browser != "" && r.Browser != BrowserFirefox && r.Browser != BrowserChrome {
+		return fmt.Errorf("unsupported browser: %v", r.Browser)
+	}
 	return nil
 }
 
@@ -43,9 +70,10 @@ func NewStartRequestFromURL(URL string) (*StartRequest, error) {
 
 //StartResponse repreents a selenium server stop request
 type StartResponse struct {
-	Pid             int
-	ServerPath      string
-	GeckodriverPath string
+	Pid              int
+	ServerPath       string
+	GeckodriverPath  string
+	ChromedriverPath string
 }
 
 //StopRequest represents server stop request
@@ -123,15 +151,111 @@ type WebElementCallResponse struct {
 	Data        map[string]interface{}
 }
 
+//BrowserOptions represents browser session options
+type BrowserOptions struct {
+	Headless     bool                   `description:"run browser in headless mode"`
+	WindowSize   string                 `description:"browser window size, i.e. 1920x1080"`
+	Arguments    []string               `description:"additional browser command line arguments"`
+	Capabilities map[string]interface{} `description:"additional WebDriver capabilities, i.e. goog:chromeOptions, acceptInsecureCerts"`
+}
+
+//Validate checks if options are valid
+func (o *BrowserOptions) Validate() error {
+	if o.WindowSize == "" {
+		return nil
+	}
+	if _, _, err := o.windowSize(); err != nil {
+		return err
+	}
+	return nil
+}
+
+//windowSize returns window width and height
+func (o *BrowserOptions) windowSize() (int, int, error) {
+	fragments := strings.Split(strings.ToLower(o.WindowSize), "x")
+	if len(fragments) != 2 {
+		return 0, 0, fmt.Errorf("invalid window size: %v, expected WIDTHxHEIGHT", o.WindowSize)
+	}
+	width, err := toolbox.ToInt(strings.TrimSpace(fragments[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid window width: %v", o.WindowSize)
+	}
+	height, err := toolbox.ToInt(strings.TrimSpace(fragments[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid window height: %v", o.WindowSize)
+	}
+	return width, height, nil
+}
+
+//capabilities returns WebDriver capabilities for supplied browser
+func (o *BrowserOptions) capabilities(browser string) map[string]interface{} {
+	browser = normalizeBrowser(browser)
+	var result = map[string]interface{}{"browserName": browser}
+	var args = make([]string, 0)
+	width, height, _ := o.windowSize()
+	switch browser {
+	case BrowserChrome:
+		//enables browser console logs for failure capture
+		result["goog:loggingPrefs"] = map[string]interface{}{"browser": "ALL"}
+		if o.Headless {
+			args = append(args, "--headless", "--disable-gpu", "--no-sandbox", "--disable-dev-shm-usage")
+		}
+		if width > 0 && height > 0 {
+			args = append(args, fmt.Sprintf("--window-size=%d,%d", width, height))
+		}
+	case BrowserFirefox:
+		if o.Headless {
+			args = append(args, "-headless")
+		}
+		if width > 0 && height > 0 {
+			args = append(args, fmt.Sprintf("--width=%d", width), fmt.Sprintf("--height=%d", height))
+		}
+	}
+	args = append(args, o.Arguments...)
+	if len(args) > 0 {
+		switch browser {
+		case BrowserChrome:
+			result["goog:chromeOptions"] = map[string]interface{}{"args": args}
+		case BrowserFirefox:
+			result["moz:firefoxOptions"] = map[string]interface{}{"args": args}
+		}
+	}
+	for k, v := range o.Capabilities {
+		if existing, ok := result[k]; ok && toolbox.IsMap(existing) && toolbox.IsMap(v) {
+			merged := toolbox.AsMap(existing)
+			for key, value := range toolbox.AsMap(v) {
+				if key == "args" && toolbox.IsSlice(value) {
+					value = append(toolbox.AsSlice(merged[key]), toolbox.AsSlice(value)...)
+				}
+				merged[key] = value
+			}
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+//normalizeBrowser returns WebDriver browser name, chromium uses chrome driver
+func normalizeBrowser(browser string) string {
+	browser = strings.ToLower(strings.TrimSpace(browser))
+	if browser == BrowserChromium || browser == "googlechrome" {
+		return BrowserChrome
+	}
+	return browser
+}
+
 //RunRequest represents group of selenium web elements calls
 type RunRequest struct {
 	SessionID        string
 	Browser          string
 	RemoteSelenium   *url.Resource //remote selenium resource
+	BrowserOptions   `description:"browser options used when session is opened"`
 	Actions          []*Action
 	ActionDelaysInMs int           `description:"slows down action with specified delay"`
 	Commands         []interface{} `description:"list of selenium command: {web element selector}.WebElementMethod(params),  or WebDriverMethod(params), or wait map "`
 	Expect           interface{}   `description:"If specified it will validated response as actual"`
+	Capture          string        `description:"failure (default), always or none: captures screenshot, page source and console logs into workflow log directory once per run, after all commands"`
 }
 
 func (r *RunRequest) asWaitAction(parser *parser, candidate interface{}) (*Action, error) {
@@ -152,6 +276,9 @@ func (r *RunRequest) asWaitAction(parser *parser, candidate interface{}) (*Actio
 }
 
 func (r *RunRequest) Init() error {
+	if r.Capture == "" {
+		r.Capture = CaptureFailure
+	}
 	if len(r.Actions) > 0 {
 		for _, action := range r.Actions {
 			if action.Selector != nil {
@@ -279,7 +406,12 @@ func (r *RunRequest) Validate() error {
 			return fmt.Errorf("actions[%d].Calls were empty", i)
 		}
 	}
-	return nil
+	switch r.Capture {
+	case "", CaptureFailure, CaptureAlways, CaptureNone:
+	default:
+		return fmt.Errorf("unsupported capture: %v, supported: %v, %v, %v", r.Capture, CaptureFailure, CaptureAlways, CaptureNone)
+	}
+	return r.BrowserOptions.Validate()
 }
 
 //NewMethodCall creates a new method call
@@ -293,9 +425,10 @@ func NewMethodCall(method string, repeatable *model.Repeater, parameters ...inte
 
 //OpenSessionRequest represents open session request
 type OpenSessionRequest struct {
-	Browser        string
+	Browser        string        `description:"firefox, chrome or chromium"`
 	RemoteSelenium *url.Resource `description:"http selenium server endpoint"`
 	SessionID      string        `description:"if specified this ID will be used for a sessionID"`
+	BrowserOptions
 }
 
 //Validate validate open session request
@@ -309,7 +442,7 @@ func (r *OpenSessionRequest) Validate() error {
 	if r.Browser == "" {
 		return errors.New("browser was empty")
 	}
-	return nil
+	return r.BrowserOptions.Validate()
 }
 
 //NewOpenSessionRequest creates a new open session request
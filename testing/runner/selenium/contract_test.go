@@ -222,6 +222,11 @@ func TestRunRequest_Validate(t *testing.T) {
 		var req = NewRunRequest("123", "", nil, NewAction("", "", "get", "localhost"))
 		assert.Nil(t, req.Validate(), "valid request")
 	}
+	{
+		var req = NewRunRequest("123", "", nil, NewAction("", "", "get", "localhost"))
+		req.Capture = "sometimes"
+		assert.NotNil(t, req.Validate(), "invalid capture")
+	}
 }
 
 func TestRunRequest_Init(t *testing.T) {
@@ -237,6 +242,7 @@ func TestRunRequest_Init(t *testing.T) {
 	{
 		var req = NewRunRequest("123", "", nil)
 		assert.Nil(t, req.Init())
+		assert.EqualValues(t, CaptureFailure, req.Capture)
 	}
 }
 
@@ -289,3 +295,69 @@ func TestNewCloseRequestFromURL(t *testing.T) {
 	assert.Nil(t, err)
 	assert.EqualValues(t, "abc", req.SessionID)
 }
+
+func TestBrowserOptions_Capabilities(t *testing.T) {
+	var useCases = []struct {
+		description string
+		browser     string
+		options     *BrowserOptions
+		expect      interface{}
+		hasError    bool
+	}{
+		{
+			description: "default firefox",
+			browser:     "firefox",
+			options:     &BrowserOptions{},
+			expect:      `{"browserName":"firefox", "moz:firefoxOptions":"@!exists@"}`,
+		},
+		{
+			description: "headless chromium with window size",
+			browser:     "Chromium",
+			options:     &BrowserOptions{Headless: true, WindowSize: "1280x720"},
+			expect:      `{"browserName":"chrome", "goog:loggingPrefs":{"browser":"ALL"}, "goog:chromeOptions":{"args":["--headless", "--disable-gpu", "--no-sandbox", "--disable-dev-shm-usage", "--window-size=1280,720"]}}`,
+		},
+		{
+			description: "headless firefox with capabilities",
+			browser:     "firefox",
+			options:     &BrowserOptions{Headless: true, Capabilities: map[string]interface{}{"acceptInsecureCerts": true}},
+			expect:      `{"browserName":"firefox", "acceptInsecureCerts":true, "moz:firefoxOptions":{"args":["-headless"]}}`,
+		},
+		{
+			description: "chrome options merge",
+			browser:     "chrome",
+			options: &BrowserOptions{Headless: true, Arguments: []string{"--lang=en"}, Capabilities: map[string]interface{}{
+				"goog:chromeOptions": map[string]interface{}{"args": []interface{}{"--incognito"}, "binary": "/usr/bin/chromium"},
+			}},
+			expect: `{"browserName":"chrome", "goog:chromeOptions":{"binary":"/usr/bin/chromium", "args":["--headless", "--disable-gpu", "--no-sandbox", "--disable-dev-shm-usage", "--lang=en", "--incognito"]}}`,
+		},
+		{
+			description: "invalid window size",
+			browser:     "chrome",
+			options:     &BrowserOptions{WindowSize: "1280"},
+			hasError:    true,
+		},
+	}
+	for _, useCase := range useCases {
+		err := useCase.options.Validate()
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assertly.AssertValues(t, useCase.expect, useCase.options.capabilities(useCase.browser), useCase.description)
+	}
+}
+
+func TestOpenSessionRequest_BrowserOptions(t *testing.T) {
+	var request = &OpenSessionRequest{}
+	err := url.NewResource("test/open_headless.yaml").Decode(request)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, "chrome", request.Browser)
+	assert.True(t, request.Headless)
+	assert.EqualValues(t, "1920x1080", request.WindowSize)
+	assert.EqualValues(t, true, request.Capabilities["acceptInsecureCerts"])
+}
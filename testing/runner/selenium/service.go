@@ -26,6 +26,10 @@ const (
 	Selenium = "selenium-server-standalone"
 	//GeckoDriver represents name of gecko driver
 	GeckoDriver = "geckodriver"
+	//ChromeDriver represents name of chrome driver
+	ChromeDriver = "chromedriver"
+
+	defaultChromeDriverVersion = "131.0.6778.85"
 
 	runnerCaller = "runnerCaller"
 )
@@ -62,6 +66,23 @@ func (s *service) getResultPath(key string, call *MethodCall) []string {
 }
 
 func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse, error) {
+	response, err := s.runActions(context, request)
+	if reason := captureReason(request, response, err); reason != "" {
+		s.capture(context, request.SessionID, reason)
+	}
+	return response, err
+}
+
+//capture publishes captured browser state event, artifacts are stored by workflow event logger
+func (s *service) capture(context *endly.Context, sessionID string, reason string) {
+	seleniumSession, err := s.session(context, sessionID)
+	if err != nil || seleniumSession.driver == nil {
+		return
+	}
+	context.Publish(newCaptureEvent(seleniumSession, reason))
+}
+
+func (s *service) runActions(context *endly.Context, request *RunRequest) (*RunResponse, error) {
 	var response = &RunResponse{
 		Data:         make(map[string]interface{}),
 		LookupErrors: make([]string, 0),
@@ -74,6 +95,7 @@ func (s *service) run(context *endly.Context, request *RunRequest) (*RunResponse
 			RemoteSelenium: request.RemoteSelenium,
 			Browser:        request.Browser,
 			SessionID:      request.SessionID,
+			BrowserOptions: request.BrowserOptions,
 		})
 		if err != nil {
 			return nil, err
@@ -237,6 +259,20 @@ func (s *service) deployServerIfNeeded(context *endly.Context, request *StartReq
 	if deployServerResponse.Error != "" {
 		return nil, errors.New(deployServerResponse.Error)
 	}
+	var response = &StartResponse{}
+	response.ServerPath = "/opt/selenium/selenium-server-standalone.jar"
+	if request.Browser == BrowserChrome {
+		deployChromeDriverResponse := deploymentService.Run(context, &deploy.Request{
+			Target:  target,
+			AppName: ChromeDriver,
+			Version: request.DriverVersion,
+		})
+		if deployChromeDriverResponse.Error != "" {
+			return nil, errors.New(deployChromeDriverResponse.Error)
+		}
+		response.ChromedriverPath = "/opt/selenium/chromedriver"
+		return response, nil
+	}
 	deployGeckoDriverResponse := deploymentService.Run(context, &deploy.Request{
 		Target:  target,
 		AppName: GeckoDriver,
@@ -244,9 +280,7 @@ func (s *service) deployServerIfNeeded(context *endly.Context, request *StartReq
 	if deployGeckoDriverResponse.Error != "" {
 		return nil, errors.New(deployGeckoDriverResponse.Error)
 	}
-	var response = &StartResponse{}
 	response.GeckodriverPath = "/opt/selenium/geckodriver"
-	response.ServerPath = "/opt/selenium/selenium-server-standalone.jar"
 	return response, nil
 }
 
@@ -278,12 +312,14 @@ func (s *service) stop(context *endly.Context, request *StopRequest) (*StopRespo
 	if serviceResponse.Error != "" {
 		return nil, errors.New(serviceResponse.Error)
 	}
-	serviceResponse = processService.Run(context, &process.StopRequest{
-		Target: target,
-		Input:  "/opt/selenium/geckodriver",
-	})
-	if serviceResponse.Error != "" {
-		return nil, errors.New(serviceResponse.Error)
+	for _, driver := range []string{"/opt/selenium/geckodriver", "/opt/selenium/chromedriver"} {
+		serviceResponse = processService.Run(context, &process.StopRequest{
+			Target: target,
+			Input:  driver,
+		})
+		if serviceResponse.Error != "" {
+			return nil, errors.New(serviceResponse.Error)
+		}
 	}
 	return &StopResponse{}, nil
 }
@@ -307,6 +343,10 @@ func (s *service) start(context *endly.Context, request *StartRequest) (*StartRe
 		Target: target,
 		Port:   request.Port,
 	})
+	driverProperty := fmt.Sprintf("-Dwebdriver.gecko.driver=%v", response.GeckodriverPath)
+	if response.ChromedriverPath != "" {
+		driverProperty = fmt.Sprintf("-Dwebdriver.chrome.driver=%v", response.ChromedriverPath)
+	}
 	processService, _ := context.Service(process.ServiceID)
 	serviceResponse := processService.Run(context, &process.StartRequest{
 		Command: "java",
@@ -315,7 +355,7 @@ func (s *service) start(context *endly.Context, request *StartRequest) (*StartRe
 			Directory:  "/opt/selenium",
 			CheckError: true,
 		},
-		Arguments:       []string{"-jar", fmt.Sprintf("-Dwebdriver.gecko.driver=%v", response.GeckodriverPath), "-jar", response.ServerPath, "-port", toolbox.AsString(request.Port)},
+		Arguments:       []string{"-jar", driverProperty, "-jar", response.ServerPath, "-port", toolbox.AsString(request.Port)},
 		ImmuneToHangups: true,
 	})
 	if serviceResponse.Error != "" {
@@ -358,13 +398,18 @@ func (s *service) openSession(context *endly.Context, request *OpenSessionReques
 			Browser: request.Browser,
 		}
 	}
-	caps := selenium.Capabilities{"browserName": request.Browser}
+	caps := selenium.Capabilities(request.capabilities(request.Browser))
 	seleniumEndpoint := fmt.Sprintf("http://%v/wd/hub", resource.ParsedURL.Host)
 	seleniumSession.driver, err = selenium.NewRemote(caps, seleniumEndpoint)
 
 	if err != nil {
 		return nil, err
 	}
+	if width, height, _ := request.windowSize(); width > 0 && height > 0 {
+		if err = seleniumSession.driver.ResizeWindow("", width, height); err != nil {
+			return nil, err
+		}
+	}
 	sessions[sessionID] = seleniumSession
 	context.Deffer(func() {
 		seleniumSession.driver.Quit()
@@ -384,6 +429,19 @@ const (
 		"Version": "3.4"
 	}`
 
+	seleniumServiceStartChromeExample = `{
+		"Target": {
+			"URL": "127.0.0.1",
+			"Credentials": "${env.HOME}/.secret/localhost.json"
+		},
+		"Port": 8085,
+		"Sdk": "jdk",
+		"SdkVersion": "1.8",
+		"Version": "3.141",
+		"Browser": "chrome",
+		"DriverVersion": "131.0.6778.85"
+	}`
+
 	seleniumServiceStopExample = `{
 		"Target": {
 			"URL": "file://127.0.0.1",
@@ -399,6 +457,18 @@ const (
 		}
 	}`
 
+	seleniumServiceOpenHeadlessSessionExample = ` {
+		"Browser": "chrome",
+		"RemoteSelenium": {
+			"URL": "http://127.0.0.1:8085/"
+		},
+		"Headless": true,
+		"WindowSize": "1920x1080",
+		"Capabilities": {
+			"acceptInsecureCerts": true
+		}
+	}`
+
 	seleniumServiceCloseExample = `{
 "SessionID": "127.0.0.1:8085"
 }`
@@ -445,6 +515,10 @@ func (s *service) registerRoutes() {
 					Description: "start server",
 					Data:        seleniumServiceStartExample,
 				},
+				{
+					Description: "start server with chromedriver",
+					Data:        seleniumServiceStartChromeExample,
+				},
 			},
 		},
 		RequestProvider: func() interface{} {
@@ -495,6 +569,10 @@ func (s *service) registerRoutes() {
 					Description: "open session",
 					Data:        seleniumServiceOpenSessionExample,
 				},
+				{
+					Description: "open headless chrome session",
+					Data:        seleniumServiceOpenHeadlessSessionExample,
+				},
 			},
 		},
 		RequestProvider: func() interface{} {
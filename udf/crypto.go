@@ -0,0 +1,154 @@
+package udf
+
+import (
+	"crypto"
+	"crypto/hmac"
+	_ "crypto/md5"
+	"crypto/rand"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+)
+
+var hashAlgorithms = map[string]crypto.Hash{
+	"MD5":    crypto.MD5,
+	"SHA1":   crypto.SHA1,
+	"SHA256": crypto.SHA256,
+	"SHA384": crypto.SHA384,
+	"SHA512": crypto.SHA512,
+}
+
+//Hmac returns HMAC signature, it takes [alg, secret, payload, encoding] or map with Alg, Secret, Payload and Encoding,
+//alg: MD5, SHA1, SHA256 (default), SHA384, SHA512, encoding: hex (default), base64 or base64url
+func Hmac(source interface{}, state data.Map) (interface{}, error) {
+	args, err := udfArgs(source, "Alg", "Secret", "Payload", "Encoding")
+	if err != nil {
+		return nil, err
+	}
+	algName := strings.ToUpper(strings.Replace(argText(args, "Alg"), "-", "", -1))
+	algName = strings.TrimPrefix(algName, "HMAC")
+	if algName == "" {
+		algName = "SHA256"
+	}
+	hash, ok := hashAlgorithms[algName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hmac algorithm: %v", args["Alg"])
+	}
+	return encodeDigest(hmacSum(hash, []byte(argText(args, "Secret")), []byte(argText(args, "Payload"))), argText(args, "Encoding"))
+}
+
+//HmacSHA256 returns hex encoded HMAC SHA256 signature, it takes [secret, payload]
+func HmacSHA256(source interface{}, state data.Map) (interface{}, error) {
+	args, err := udfArgs(source, "Secret", "Payload")
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(hmacSum(crypto.SHA256, []byte(argText(args, "Secret")), []byte(argText(args, "Payload")))), nil
+}
+
+//Md5 returns hex encoded MD5 hash
+func Md5(source interface{}, state data.Map) (interface{}, error) {
+	return hex.EncodeToString(hashSum(crypto.MD5, asBytes(source))), nil
+}
+
+//Sha1 returns hex encoded SHA1 hash
+func Sha1(source interface{}, state data.Map) (interface{}, error) {
+	return hex.EncodeToString(hashSum(crypto.SHA1, asBytes(source))), nil
+}
+
+//Sha256 returns hex encoded SHA256 hash
+func Sha256(source interface{}, state data.Map) (interface{}, error) {
+	return hex.EncodeToString(hashSum(crypto.SHA256, asBytes(source))), nil
+}
+
+//Sha512 returns hex encoded SHA512 hash
+func Sha512(source interface{}, state data.Map) (interface{}, error) {
+	return hex.EncodeToString(hashSum(crypto.SHA512, asBytes(source))), nil
+}
+
+//UUIDv4 returns a new random UUID
+func UUIDv4(source interface{}, state data.Map) (interface{}, error) {
+	var uuid = make([]byte, 16)
+	if _, err := rand.Read(uuid); err != nil {
+		return nil, err
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return formatUUID(uuid), nil
+}
+
+//UUIDv7 returns a new time ordered UUID
+func UUIDv7(source interface{}, state data.Map) (interface{}, error) {
+	var uuid = make([]byte, 16)
+	if _, err := rand.Read(uuid[6:]); err != nil {
+		return nil, err
+	}
+	var timestamp = make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, uint64(time.Now().UnixNano()/int64(time.Millisecond)))
+	copy(uuid[:6], timestamp[2:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x70
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return formatUUID(uuid), nil
+}
+
+//Base64URLEncode encodes source using unpadded base64url encoding
+func Base64URLEncode(source interface{}, state data.Map) (interface{}, error) {
+	return base64.RawURLEncoding.EncodeToString(asBytes(source)), nil
+}
+
+//Base64URLDecode decodes padded or unpadded base64url source to text
+func Base64URLDecode(source interface{}, state data.Map) (interface{}, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(string(asBytes(source)), "="))
+	if err != nil {
+		return nil, err
+	}
+	return string(decoded), nil
+}
+
+func formatUUID(uuid []byte) string {
+	encoded := hex.EncodeToString(uuid)
+	return encoded[:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:]
+}
+
+func asBytes(source interface{}) []byte {
+	switch value := source.(type) {
+	case nil:
+		return []byte{}
+	case []byte:
+		return value
+	}
+	return []byte(toolbox.AsString(source))
+}
+
+func hashSum(hash crypto.Hash, data []byte) []byte {
+	hasher := hash.New()
+	_, _ = hasher.Write(data)
+	return hasher.Sum(nil)
+}
+
+func hmacSum(hash crypto.Hash, secret, data []byte) []byte {
+	mac := hmac.New(hash.New, secret)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func encodeDigest(digest []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "", "hex":
+		return hex.EncodeToString(digest), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(digest), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(digest), nil
+	}
+	return "", fmt.Errorf("unsupported encoding: %v", encoding)
+}
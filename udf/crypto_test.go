@@ -0,0 +1,73 @@
+package udf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"regexp"
+	"testing"
+)
+
+func TestHmac(t *testing.T) {
+	var payload = "The quick brown fox jumps over the lazy dog"
+	var useCases = []struct {
+		description string
+		args        interface{}
+		expect      string
+		hasError    bool
+	}{
+		{description: "default", args: []interface{}{nil, "key", payload}, expect: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+		{description: "sha1", args: []interface{}{"sha1", "key", payload}, expect: "de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9"},
+		{description: "base64", args: map[string]interface{}{"alg": "HmacSHA256", "secret": "key", "payload": payload, "encoding": "base64"}, expect: "97yD9DBThCSxMpjmqm+xQ+9NWaFJRhdZl0edvC0aPNg="},
+		{description: "unsupported", args: []interface{}{"md4", "key", payload}, hasError: true},
+	}
+	for _, useCase := range useCases {
+		actual, err := Hmac(useCase.args, nil)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		}
+	}
+	aMap := data.NewMap()
+	aMap.Put("HmacSHA256", HmacSHA256)
+	aMap.Put("body", payload)
+	assert.EqualValues(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", aMap.ExpandAsText(`sha256=$HmacSHA256(["key", "$body"])`))
+}
+
+func TestHash(t *testing.T) {
+	actual, _ := Md5("abc", nil)
+	assert.EqualValues(t, "900150983cd24fb0d6963f7d28e17f72", actual)
+	actual, _ = Sha1("abc", nil)
+	assert.EqualValues(t, "a9993e364706816aba3e25717850c26c9cd0d89d", actual)
+	actual, _ = Sha256("abc", nil)
+	assert.EqualValues(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", actual)
+	actual, _ = Sha512([]byte("abc"), nil)
+	assert.EqualValues(t, 128, len(toolbox.AsString(actual)))
+}
+
+func TestUUID(t *testing.T) {
+	v4, err := UUIDv4(nil, nil)
+	if assert.Nil(t, err) {
+		assert.True(t, regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$").MatchString(toolbox.AsString(v4)))
+	}
+	first, err := UUIDv7(nil, nil)
+	if assert.Nil(t, err) {
+		assert.True(t, regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$").MatchString(toolbox.AsString(first)))
+	}
+	second, _ := UUIDv7(nil, nil)
+	assert.True(t, toolbox.AsString(first)[:8] <= toolbox.AsString(second)[:8])
+}
+
+func TestBase64URL(t *testing.T) {
+	encoded, _ := Base64URLEncode("subjects?_d=1", nil)
+	assert.EqualValues(t, "c3ViamVjdHM_X2Q9MQ", encoded)
+	decoded, err := Base64URLDecode("c3ViamVjdHM_X2Q9MQ==", nil)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, "subjects?_d=1", decoded)
+	}
+	_, err = Base64URLDecode("@@", nil)
+	assert.NotNil(t, err)
+}
@@ -15,6 +15,20 @@ func init() {
 	endly.UdfRegistry["GZipper"] = GZipper
 	endly.UdfRegistry["GZipContentCorrupter"] = GZipContentCorrupter
 	endly.UdfRegistry["AvroReader"] = NewAvroReader
+	endly.UdfRegistry["JwtSign"] = JwtSign
+	endly.UdfRegistry["JwtVerify"] = JwtVerify
+	endly.UdfRegistry["JwtClaims"] = JwtClaims
+	endly.UdfRegistry["JwtHeader"] = JwtHeader
+	endly.UdfRegistry["Hmac"] = Hmac
+	endly.UdfRegistry["HmacSHA256"] = HmacSHA256
+	endly.UdfRegistry["Md5"] = Md5
+	endly.UdfRegistry["Sha1"] = Sha1
+	endly.UdfRegistry["Sha256"] = Sha256
+	endly.UdfRegistry["Sha512"] = Sha512
+	endly.UdfRegistry["UUIDv4"] = UUIDv4
+	endly.UdfRegistry["UUIDv7"] = UUIDv7
+	endly.UdfRegistry["Base64URLEncode"] = Base64URLEncode
+	endly.UdfRegistry["Base64URLDecode"] = Base64URLDecode
 
 	endly.UdfRegistryProvider["AvroWriter"] = NewAvroWriter
 	endly.UdfRegistryProvider["ProtoReader"] = NewProtoReader
@@ -0,0 +1,186 @@
+package udf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/viant/endly/util/jwt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"github.com/viant/toolbox/url"
+)
+
+//JwtSign returns signed JWT, it takes [claims, alg, key, kid, header] or map with Claims, Alg, Key, KeyID and Header,
+//key is HMAC secret for HS algorithms or PEM private key content/location for RS and ES algorithms
+func JwtSign(source interface{}, state data.Map) (interface{}, error) {
+	args, err := udfArgs(source, "Claims", "Alg", "Key", "KeyID", "Header")
+	if err != nil {
+		return nil, err
+	}
+	claims, err := asJSONMap(args["Claims"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid claims: %v", err)
+	}
+	algName := argText(args, "Alg")
+	if algName == "" {
+		algName = "HS256"
+	}
+	alg, err := jwt.LookupAlgorithm(algName)
+	if err != nil {
+		return nil, err
+	}
+	var header = map[string]interface{}{}
+	if args["Header"] != nil {
+		if header, err = asJSONMap(args["Header"]); err != nil {
+			return nil, fmt.Errorf("invalid header: %v", err)
+		}
+	}
+	if keyID := argText(args, "KeyID"); keyID != "" {
+		header["kid"] = keyID
+	}
+	var key interface{} = []byte(argText(args, "Key"))
+	if !alg.IsHMAC() {
+		content, err := loadPEM(argText(args, "Key"))
+		if err != nil {
+			return nil, err
+		}
+		if key, err = jwt.ParsePrivateKey(content); err != nil {
+			return nil, err
+		}
+	}
+	return jwt.Sign(alg.Name, header, claims, key)
+}
+
+//JwtVerify verifies JWT signature, expiry and not before claims and returns token claims, it takes [token, key, alg] or map with Token, Key and Alg,
+//key is HMAC secret for HS algorithms or PEM public key, certificate or private key content/location for RS and ES algorithms,
+//token algorithm has to match Alg if supplied, otherwise PEM key content or location only verifies RS and ES tokens and other key only HS tokens
+func JwtVerify(source interface{}, state data.Map) (interface{}, error) {
+	args, err := udfArgs(source, "Token", "Key", "Alg")
+	if err != nil {
+		return nil, err
+	}
+	token := argText(args, "Token")
+	keyText := argText(args, "Key")
+	var expected []string
+	if algName := argText(args, "Alg"); algName != "" {
+		alg, err := jwt.LookupAlgorithm(algName)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, alg.Name)
+		if alg.IsHMAC() {
+			return jwt.Verify(token, []byte(keyText), expected...)
+		}
+	} else if !isPEMKey(keyText) {
+		return jwt.Verify(token, []byte(keyText))
+	}
+	content, err := loadPEM(keyText)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwt.ParsePublicKey(content)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.Verify(token, key, expected...)
+}
+
+//isPEMKey returns true if key is PEM content or location
+func isPEMKey(key string) bool {
+	if strings.Contains(key, "-----BEGIN") || strings.Contains(key, "://") {
+		return true
+	}
+	return toolbox.FileExists(key)
+}
+
+//JwtClaims returns JWT claims without signature verification
+func JwtClaims(source interface{}, state data.Map) (interface{}, error) {
+	return jwtSegment(toolbox.AsString(source), 1)
+}
+
+//JwtHeader returns JWT header without signature verification
+func JwtHeader(source interface{}, state data.Map) (interface{}, error) {
+	return jwtSegment(toolbox.AsString(source), 0)
+}
+
+func jwtSegment(token string, index int) (map[string]interface{}, error) {
+	segments, err := jwt.Segments(token)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.DecodeSegment(segments[index])
+}
+
+//loadPEM returns PEM content for supplied PEM content or location
+func loadPEM(key string) ([]byte, error) {
+	if strings.Contains(key, "-----BEGIN") {
+		return []byte(key), nil
+	}
+	content, err := url.NewResource(key).DownloadText()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key: %v, %v", key, err)
+	}
+	return []byte(content), nil
+}
+
+//udfArgs returns named arguments from a map (case insensitive) or positional slice arguments
+func udfArgs(source interface{}, names ...string) (map[string]interface{}, error) {
+	var result = make(map[string]interface{})
+	if text, ok := source.(string); ok && strings.HasPrefix(strings.TrimSpace(text), "{") {
+		aMap, err := asJSONMap(text)
+		if err != nil {
+			return nil, err
+		}
+		source = aMap
+	}
+	if source != nil && toolbox.IsMap(source) {
+		for key, value := range toolbox.AsMap(source) {
+			for _, name := range names {
+				if strings.EqualFold(key, name) {
+					result[name] = value
+				}
+			}
+		}
+		return result, nil
+	}
+	if source == nil || !toolbox.IsSlice(source) {
+		return nil, fmt.Errorf("expected slice or map but had: %T %v", source, source)
+	}
+	for i, arg := range toolbox.AsSlice(source) {
+		if i < len(names) {
+			result[names[i]] = arg
+		}
+	}
+	return result, nil
+}
+
+//argText returns text argument or empty string if argument is missing
+func argText(args map[string]interface{}, name string) string {
+	if args[name] == nil {
+		return ""
+	}
+	return toolbox.AsString(args[name])
+}
+
+//asJSONMap returns map for supplied map or JSON object text
+func asJSONMap(source interface{}) (map[string]interface{}, error) {
+	if source == nil {
+		return map[string]interface{}{}, nil
+	}
+	if text, ok := source.(string); ok {
+		var result = make(map[string]interface{})
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	if !toolbox.IsMap(source) {
+		return nil, fmt.Errorf("expected map but had: %T", source)
+	}
+	var result = make(map[string]interface{})
+	for key, value := range toolbox.AsMap(source) {
+		result[key] = value
+	}
+	return result, nil
+}
@@ -0,0 +1,258 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/viant/toolbox"
+)
+
+//Algorithm represents JWT signing algorithm
+type Algorithm struct {
+	Name   string
+	family string
+	Hash   crypto.Hash
+	size   int
+}
+
+var algorithms = map[string]*Algorithm{
+	"HS256": {Name: "HS256", family: "HS", Hash: crypto.SHA256},
+	"HS384": {Name: "HS384", family: "HS", Hash: crypto.SHA384},
+	"HS512": {Name: "HS512", family: "HS", Hash: crypto.SHA512},
+	"RS256": {Name: "RS256", family: "RS", Hash: crypto.SHA256},
+	"RS384": {Name: "RS384", family: "RS", Hash: crypto.SHA384},
+	"RS512": {Name: "RS512", family: "RS", Hash: crypto.SHA512},
+	"ES256": {Name: "ES256", family: "ES", Hash: crypto.SHA256, size: 32},
+	"ES384": {Name: "ES384", family: "ES", Hash: crypto.SHA384, size: 48},
+	"ES512": {Name: "ES512", family: "ES", Hash: crypto.SHA512, size: 66},
+}
+
+//LookupAlgorithm returns algorithm for supplied name
+func LookupAlgorithm(name string) (*Algorithm, error) {
+	alg, ok := algorithms[strings.ToUpper(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported jwt algorithm: %v", name)
+	}
+	return alg, nil
+}
+
+//IsHMAC returns true if algorithm uses shared secret
+func (a *Algorithm) IsHMAC() bool {
+	return a.family == "HS"
+}
+
+//Sign returns signed token, key is []byte HMAC secret for HS algorithms or RSA/ECDSA private key
+func Sign(algName string, header, claims map[string]interface{}, key interface{}) (string, error) {
+	alg, err := LookupAlgorithm(algName)
+	if err != nil {
+		return "", err
+	}
+	var tokenHeader = map[string]interface{}{}
+	for k, v := range header {
+		tokenHeader[k] = v
+	}
+	tokenHeader["alg"] = alg.Name
+	if _, has := tokenHeader["typ"]; !has {
+		tokenHeader["typ"] = "JWT"
+	}
+	headerJSON, err := json.Marshal(tokenHeader)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := EncodeSegment(headerJSON) + "." + EncodeSegment(claimsJSON)
+	signature, err := alg.sign(unsigned, key)
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + EncodeSegment(signature), nil
+}
+
+//Verify verifies token signature, expiry and not before claims and returns token claims,
+//key is []byte HMAC secret for HS algorithms or RSA/ECDSA public key, token header algorithm has to match key type
+//and one of expected algorithms if supplied, so that a token can not pick verification algorithm for a supplied key
+func Verify(token string, key interface{}, expected ...string) (map[string]interface{}, error) {
+	segments, err := Segments(token)
+	if err != nil {
+		return nil, err
+	}
+	header, err := DecodeSegment(segments[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt header: %v", err)
+	}
+	alg, err := LookupAlgorithm(toolbox.AsString(header["alg"]))
+	if err != nil {
+		return nil, err
+	}
+	if err = alg.accepts(key, expected); err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt signature encoding: %v", err)
+	}
+	if err = alg.verify(segments[0]+"."+segments[1], signature, key); err != nil {
+		return nil, err
+	}
+	claims, err := DecodeSegment(segments[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt claims: %v", err)
+	}
+	now := time.Now().Unix()
+	if expiry, ok := claims["exp"]; ok && now > int64(toolbox.AsFloat(expiry)) {
+		return nil, fmt.Errorf("jwt expired at %v", time.Unix(int64(toolbox.AsFloat(expiry)), 0).UTC())
+	}
+	if notBefore, ok := claims["nbf"]; ok && now < int64(toolbox.AsFloat(notBefore)) {
+		return nil, fmt.Errorf("jwt not valid before %v", time.Unix(int64(toolbox.AsFloat(notBefore)), 0).UTC())
+	}
+	return claims, nil
+}
+
+//Segments returns header, claims and signature token segments, optional bearer prefix is removed
+func Segments(token string) ([]string, error) {
+	token = strings.TrimSpace(token)
+	if strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		token = strings.TrimSpace(token[len("bearer "):])
+	}
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("invalid jwt: expected 3 segments but had %v", len(segments))
+	}
+	return segments, nil
+}
+
+//EncodeSegment returns unpadded base64url encoded segment
+func EncodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+//DecodeSegment decodes padded or unpadded base64url JSON segment
+func DecodeSegment(segment string) (map[string]interface{}, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+	var result = make(map[string]interface{})
+	err = json.Unmarshal(decoded, &result)
+	return result, err
+}
+
+//accepts checks if algorithm is one of expected algorithms and matches key type
+func (a *Algorithm) accepts(key interface{}, expected []string) error {
+	if len(expected) > 0 {
+		matched := false
+		for _, name := range expected {
+			if strings.EqualFold(name, a.Name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("unexpected jwt algorithm: %v, expected: %v", a.Name, strings.Join(expected, ","))
+		}
+	}
+	family := ""
+	switch key.(type) {
+	case []byte:
+		family = "HS"
+	case *rsa.PublicKey:
+		family = "RS"
+	case *ecdsa.PublicKey:
+		family = "ES"
+	}
+	if family != a.family {
+		return fmt.Errorf("jwt algorithm %v does not match %T key", a.Name, key)
+	}
+	return nil
+}
+
+func (a *Algorithm) digest(unsigned string) []byte {
+	hasher := a.Hash.New()
+	_, _ = hasher.Write([]byte(unsigned))
+	return hasher.Sum(nil)
+}
+
+func (a *Algorithm) hmacSum(unsigned string, key interface{}) ([]byte, error) {
+	secret, ok := key.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected []byte secret but had: %T", key)
+	}
+	mac := hmac.New(a.Hash.New, secret)
+	_, _ = mac.Write([]byte(unsigned))
+	return mac.Sum(nil), nil
+}
+
+func (a *Algorithm) sign(unsigned string, key interface{}) ([]byte, error) {
+	switch a.family {
+	case "HS":
+		return a.hmacSum(unsigned, key)
+	case "RS":
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("expected RSA private key but had: %T", key)
+		}
+		return rsa.SignPKCS1v15(rand.Reader, rsaKey, a.Hash, a.digest(unsigned))
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected ECDSA private key but had: %T", key)
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, ecKey, a.digest(unsigned))
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 2*a.size)
+	r.FillBytes(signature[:a.size])
+	s.FillBytes(signature[a.size:])
+	return signature, nil
+}
+
+func (a *Algorithm) verify(unsigned string, signature []byte, key interface{}) error {
+	switch a.family {
+	case "HS":
+		expected, err := a.hmacSum(unsigned, key)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal(signature, expected) {
+			return fmt.Errorf("invalid jwt signature")
+		}
+		return nil
+	case "RS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("expected RSA public key but had: %T", key)
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, a.Hash, a.digest(unsigned), signature); err != nil {
+			return fmt.Errorf("invalid jwt signature: %v", err)
+		}
+		return nil
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("expected ECDSA public key but had: %T", key)
+	}
+	if len(signature) != 2*a.size {
+		return fmt.Errorf("invalid jwt signature size: %v", len(signature))
+	}
+	r := new(big.Int).SetBytes(signature[:a.size])
+	s := new(big.Int).SetBytes(signature[a.size:])
+	if !ecdsa.Verify(ecKey, a.digest(unsigned), r, s) {
+		return fmt.Errorf("invalid jwt signature")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.Nil(t, err) {
+		return
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		description string
+		alg         string
+		signKey     interface{}
+		verifyKey   interface{}
+		claims      map[string]interface{}
+		hasError    bool
+	}{
+		{
+			description: "HS256 token",
+			alg:         "HS256",
+			signKey:     []byte("secret"),
+			verifyKey:   []byte("secret"),
+			claims:      map[string]interface{}{"sub": "user1"},
+		},
+		{
+			description: "HS256 invalid secret",
+			alg:         "HS256",
+			signKey:     []byte("secret"),
+			verifyKey:   []byte("other"),
+			claims:      map[string]interface{}{"sub": "user1"},
+			hasError:    true,
+		},
+		{
+			description: "RS256 token",
+			alg:         "RS256",
+			signKey:     rsaKey,
+			verifyKey:   &rsaKey.PublicKey,
+			claims:      map[string]interface{}{"sub": "user2"},
+		},
+		{
+			description: "ES256 token",
+			alg:         "ES256",
+			signKey:     ecKey,
+			verifyKey:   &ecKey.PublicKey,
+			claims:      map[string]interface{}{"sub": "user3"},
+		},
+		{
+			description: "expired token",
+			alg:         "HS256",
+			signKey:     []byte("secret"),
+			verifyKey:   []byte("secret"),
+			claims:      map[string]interface{}{"sub": "user4", "exp": time.Now().Add(-time.Minute).Unix()},
+			hasError:    true,
+		},
+	}
+	for _, useCase := range useCases {
+		token, err := Sign(useCase.alg, map[string]interface{}{"kid": "k1"}, useCase.claims, useCase.signKey)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		claims, err := Verify("Bearer "+token, useCase.verifyKey)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.claims["sub"], claims["sub"], useCase.description)
+		segments, _ := Segments(token)
+		header, _ := DecodeSegment(segments[0])
+		assert.EqualValues(t, useCase.alg, header["alg"], useCase.description)
+		assert.EqualValues(t, "k1", header["kid"], useCase.description)
+	}
+}
+
+func TestVerify_Algorithm(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.Nil(t, err) {
+		return
+	}
+	secret := []byte("public key used as secret")
+	forged, err := Sign("HS256", nil, map[string]interface{}{"sub": "mallory"}, secret)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = Verify(forged, &rsaKey.PublicKey)
+	assert.NotNil(t, err, "HS256 token with RSA key")
+	_, err = Verify(forged, secret, "RS256")
+	assert.NotNil(t, err, "unexpected algorithm")
+	_, err = Verify(forged, secret, "HS256")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,105 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+)
+
+//Key represents JSON web key
+type Key struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+//KeySet represents JSON web key set
+type KeySet struct {
+	Keys []*Key `json:"keys"`
+}
+
+//NewRSAKeySet returns key set with supplied RSA public signing key
+func NewRSAKeySet(keyID, algName string, key *rsa.PublicKey) *KeySet {
+	return &KeySet{
+		Keys: []*Key{
+			{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: algName,
+				Kid: keyID,
+				N:   EncodeSegment(key.N.Bytes()),
+				E:   EncodeSegment(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	}
+}
+
+//DecodePEM returns the first PEM block of supplied content
+func DecodePEM(content []byte) (*pem.Block, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM key")
+	}
+	return block, nil
+}
+
+//ParsePrivateKey parses PKCS1, EC or PKCS8 PEM private key
+func ParsePrivateKey(content []byte) (crypto.PrivateKey, error) {
+	block, err := DecodePEM(content)
+	if err != nil {
+		return nil, err
+	}
+	return parsePrivateKey(block)
+}
+
+//ParsePublicKey parses PEM public key, certificate or private key public part
+func ParsePublicKey(content []byte) (crypto.PublicKey, error) {
+	block, err := DecodePEM(content)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		return certificate.PublicKey, nil
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	privateKey, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return &key.PublicKey, nil
+	case *ecdsa.PrivateKey:
+		return &key.PublicKey, nil
+	}
+	return nil, fmt.Errorf("unsupported key type: %T", privateKey)
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
+	}
+	return key, nil
+}
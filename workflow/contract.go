@@ -6,6 +6,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/viant/endly"
 	"github.com/viant/endly/model"
 	"github.com/viant/endly/model/msg"
 	"github.com/viant/endly/util"
@@ -32,6 +33,7 @@ type RunRequest struct {
 	TagIDs            string `description:"coma separated TagID list, if present in a task, only matched runs, other task runWorkflow as normal"`
 	Tasks             string `required:"true" description:"coma separated task list, if empty or '*' runs all tasks sequentially"` //tasks to runWorkflow with coma separated list or '*', or empty string for all tasks
 	Interactive       bool
+	Cleanup           bool `description:"tear down all resources created by the run session after workflow completes, even on failure"`
 	*model.InlineWorkflow
 	workflow *model.Workflow //inline workflow from pipeline
 }
@@ -224,6 +226,16 @@ type FailRequest struct {
 // FailResponse represents workflow exit response
 type FailResponse struct{}
 
+//CleanupRequest represents a request to tear down all resources created by a session
+type CleanupRequest struct {
+	SessionID string `description:"session which resources are torn down, current session by default"`
+}
+
+//CleanupResponse represents cleanup response
+type CleanupResponse struct {
+	Removed []*endly.LedgerEntry
+}
+
 //NopRequest represent no operation
 type NopRequest struct{}
 
@@ -6,6 +6,7 @@ import (
 	"github.com/viant/endly/model"
 	"github.com/viant/endly/model/msg"
 	"github.com/viant/toolbox"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
@@ -139,15 +140,26 @@ func (l *Logger) OnEvent(event msg.Event) {
 		return
 	}
 	_, _ = file.Write(buf)
+	if provider, ok := event.Value().(msg.ArtifactProvider); ok {
+		for _, artifact := range provider.Artifacts() {
+			artifactFilename := path.Join(l.directory, subPath, fmt.Sprintf("%04d_%v", tagCount, artifact.Name))
+			if err := ioutil.WriteFile(artifactFilename, artifact.Content, 0644); err != nil {
+				l.handlerError(err)
+				continue
+			}
+			artifact.Location = artifactFilename
+		}
+	}
 }
 
 //AsEventListener returns an event Listener
 func (l *Logger) AsEventListener() msg.Listener {
 	return func(event msg.Event) {
+		//log event first so that listener can access stored artifact locations
+		l.OnEvent(event)
 		if l.Listener != nil {
 			l.Listener(event)
 		}
-		l.OnEvent(event)
 	}
 }
 
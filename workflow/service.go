@@ -184,6 +184,11 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 					return response, nil
 				}
 			}
+			if action.TagID != process.RunTagID {
+				if err := notifyTagListeners(context, process, ""); err != nil {
+					return nil, nil, err
+				}
+			}
 			moveToNextTag, err := criteria.Evaluate(context, context.State(), action.Skip, "Skip", false)
 			if err != nil {
 				return nil, nil, err
@@ -194,13 +199,13 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 				}
 				continue
 			}
+			process.RunTagID = action.TagID
 			var extractable = make(map[string]interface{})
 			err = action.Repeater.Run(s.AbstractService, "action", context, handler(task.Actions[i]), extractable)
 			if err != nil {
 				return nil, nil, err
 			}
 		}
-
 		return state, result, nil
 	})
 
@@ -278,6 +283,9 @@ func (s *Service) applyVariables(candidates interface{}, process *model.Process,
 }
 
 func (s *Service) run(context *endly.Context, request *RunRequest) (response *RunResponse, err error) {
+	if request.Cleanup {
+		context.Ledger().EnablePersistence()
+	}
 	if request.Async {
 		context.Wait.Add(1)
 		go func() {
@@ -287,13 +295,41 @@ func (s *Service) run(context *endly.Context, request *RunRequest) (response *Ru
 			if err != nil {
 				context.Publish(msg.NewErrorEvent(fmt.Sprintf("%v", err)))
 			}
+			if e := s.cleanupIfNeeded(context, request); e != nil {
+				context.Publish(msg.NewErrorEvent(fmt.Sprintf("%v", e)))
+			}
 		}()
 		return &RunResponse{}, nil
 	}
 	defer context.Publish(NewEndEvent(context.SessionID))
+	defer func() {
+		if e := s.cleanupIfNeeded(context, request); e != nil && err == nil {
+			err = e
+		}
+	}()
 	return s.runWorkflow(context, request)
 }
 
+//cleanupIfNeeded tears down session resources if cleanup mode was requested
+func (s *Service) cleanupIfNeeded(context *endly.Context, request *RunRequest) error {
+	if !request.Cleanup {
+		return nil
+	}
+	_, err := s.cleanup(context, &CleanupRequest{})
+	return err
+}
+
+func (s *Service) cleanup(context *endly.Context, request *CleanupRequest) (*CleanupResponse, error) {
+	sessionID := request.SessionID
+	if sessionID == "" {
+		sessionID = context.SessionID
+	}
+	response := &CleanupResponse{}
+	var err error
+	response.Removed, err = endly.SessionLedger(sessionID).Teardown(context)
+	return response, err
+}
+
 func (s *Service) enableLoggingIfNeeded(context *endly.Context, request *RunRequest) {
 	if request.EnableLogging && !context.HasLogger {
 		var logDirectory = path.Join(request.LogDirectory, context.SessionID)
@@ -413,6 +449,9 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 		err = s.runTasks(context, process, filteredTasks)
 		return state, response.Data, err
 	})
+	if e := notifyTagListeners(context, process, ""); e != nil && err == nil {
+		err = e
+	}
 
 	if len(response.Data) > 0 {
 		for k, v := range response.Data {
@@ -422,6 +461,11 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	return response, err
 }
 
+//notifyTagListeners notifies tag listeners once process running TagID (use case) changes, so that a use case spanning tasks is notified once
+func notifyTagListeners(context *endly.Context, process *model.Process, tagID string) error {
+	return model.NotifyTagListeners(context, process.ChangeTagID(tagID))
+}
+
 func (s *Service) runNode(context *endly.Context, nodeType string, process *model.Process, node *model.AbstractNode, runHandler func(context *endly.Context, process *model.Process) (in, out data.Map, err error)) error {
 	if !process.CanRun() {
 		return nil
@@ -879,6 +923,25 @@ func (s *Service) registerRoutes() {
 		},
 	})
 
+	s.AbstractService.Register(&endly.Route{
+		Action: "cleanup",
+		RequestInfo: &endly.ActionInfo{
+			Description: "tear down all resources (containers, kubernetes resources, topics, assets, processes) created by a session",
+		},
+		RequestProvider: func() interface{} {
+			return &CleanupRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &CleanupResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*CleanupRequest); ok {
+				return s.cleanup(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
 	s.AbstractService.Register(&endly.Route{
 		Action: "setEnv",
 		RequestInfo: &endly.ActionInfo{